@@ -99,6 +99,12 @@ betweenValues(value, higher, lower) {
 	to_number(value) < lower
 }
 
+# securityContextRemediation returns the remediation adding the attribute to the securityContext of the container,
+# along with the securityContext itself when the container does not define it
+securityContextRemediation(container, attribute) = json.marshal(attribute) {
+	_ = container.securityContext
+} else = json.marshal({"securityContext": attribute})
+
 # Valid K8s/Knative Kinds that support podSpec or PodSpecTemplate
 # https://kubernetes.io/docs/reference/generated/kubernetes-api/v1.19/#podspec-v1-core
 valid_pod_spec_kind_list = [
@@ -18,6 +18,8 @@ CxPolicy[result] {
 		"issueType": "MissingAttribute",
 		"keyExpectedValue": sprintf("Resources.%s.Properties.Encrypted should be defined and not null", [name]),
 		"keyActualValue": sprintf("Resources.%s.Properties.Encrypted is undefined or null", [name]),
+		"remediation": json.marshal({"Encrypted": true}),
+		"remediationType": "addition",
 	}
 }
 
@@ -36,5 +38,10 @@ CxPolicy[result] {
 		"issueType": "IncorrectValue",
 		"keyExpectedValue": sprintf("Resources.%s.Properties.Encrypted should be true", [name]),
 		"keyActualValue": sprintf("Resources.%s.Properties.Encrypted is false", [name]),
+		"remediation": json.marshal({
+			"before": "false",
+			"after": "true"
+		}),
+		"remediationType": "replacement",
 	}
 }
@@ -17,5 +17,10 @@ CxPolicy[result] {
 		"issueType": "IncorrectValue",
 		"keyExpectedValue": sprintf("EFS resource '%s' should have encryption enabled", [key]),
 		"keyActualValue": sprintf("EFS resource '%s' has encryption set to false", [key]),
+		"remediation": json.marshal({
+			"before": "false",
+			"after": "true"
+		}),
+		"remediationType": "replacement",
 	}
 }
@@ -19,6 +19,8 @@ CxPolicy[result] {
 		"issueType": "MissingAttribute",
 		"keyExpectedValue": sprintf("Resources.%s.Properties.StorageEncrypted should be defined and set to true", [name]),
 		"keyActualValue": sprintf("Resources.%s.Properties.StorageEncrypted is undefined", [name]),
+		"remediation": json.marshal({"StorageEncrypted": true}),
+		"remediationType": "addition",
 	}
 }
 
@@ -40,5 +42,10 @@ CxPolicy[result] {
 		"issueType": "IncorrectValue",
 		"keyExpectedValue": sprintf("Resources.%s.Properties.StorageEncrypted should be set to true", [name]),
 		"keyActualValue": sprintf("Resources.%s.Properties.StorageEncrypted is set to false", [name]),
+		"remediation": json.marshal({
+			"before": "false",
+			"after": "true"
+		}),
+		"remediationType": "replacement",
 	}
 }
@@ -15,5 +15,10 @@ CxPolicy[result] {
 		"issueType": "IncorrectValue",
 		"keyExpectedValue": "Last User shouldn't be root",
 		"keyActualValue": "Last User is root",
+		"remediation": json.marshal({
+			"before": "root",
+			"after": "nobody"
+		}),
+		"remediationType": "replacement",
 	}
 }
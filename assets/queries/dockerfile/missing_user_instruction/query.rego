@@ -15,6 +15,8 @@ CxPolicy[result] {
 		"issueType": "MissingAttribute",
 		"keyExpectedValue": "The 'Dockerfile' should contain the 'USER' instruction",
 		"keyActualValue": "The 'Dockerfile' does not contain any 'USER' instruction",
+		"remediation": "USER nobody",
+		"remediationType": "addition",
 	}
 }
 
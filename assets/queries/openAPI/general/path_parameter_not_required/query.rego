@@ -13,14 +13,14 @@ CxPolicy[result] {
 	param.in == "path"
 	issueType := not_required(param)
 
-	result := {
+	result := object.union({
 		"documentId": doc.id,
 		"searchKey": sprintf("%s.parameters.name={{%s}}", [openapi_lib.concat_path(path), param.name]),
 		"issueType": issueType,
 		"keyExpectedValue": "Path parameter should have the field 'required' set to 'true' for location 'path'",
 		"keyActualValue": "Path parameter does not have the field 'required' set to 'true' for location 'path'",
 		"overrideKey": version,
-	}
+	}, get_remediation(issueType))
 }
 
 not_required(param) = issueType {
@@ -30,3 +30,11 @@ not_required(param) = issueType {
 	param.required == false
 	issueType = "IncorrectValue"
 }
+
+get_remediation(issueType) = remediation {
+	issueType == "MissingAttribute"
+	remediation := {
+		"remediation": json.marshal({"required": true}),
+		"remediationType": "addition",
+	}
+} else = {}
@@ -0,0 +1,98 @@
+package console
+
+import (
+	_ "embed" // Embed diff flags
+
+	"github.com/Checkmarx/kics/v2/internal/console/flags"
+	sentryReport "github.com/Checkmarx/kics/v2/internal/sentry"
+	"github.com/Checkmarx/kics/v2/pkg/diff"
+	"github.com/Checkmarx/kics/v2/pkg/engine/source"
+	internalPrinter "github.com/Checkmarx/kics/v2/pkg/printer"
+	"github.com/Checkmarx/kics/v2/pkg/report"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+var (
+	//go:embed assets/diff-flags.json
+	diffFlagsListContent string
+)
+
+// NewDiffCmd creates a new instance of the diff Command
+func NewDiffCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "diff",
+		Short: "Compares the results of two scans",
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return preDiff(cmd)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return executeDiff(getDiffParameters())
+		},
+	}
+}
+
+func initDiffCmd(diffCmd *cobra.Command) error {
+	if err := flags.InitJSONFlags(
+		diffCmd,
+		diffFlagsListContent,
+		false,
+		source.ListSupportedPlatforms(),
+		source.ListSupportedCloudProviders()); err != nil {
+		return err
+	}
+
+	for _, flag := range []string{flags.DiffBaseResults, flags.DiffHeadResults} {
+		if err := diffCmd.MarkFlagRequired(flag); err != nil {
+			sentryReport.ReportSentry(&sentryReport.Report{
+				Message:  "Failed to add command required flags",
+				Err:      err,
+				Location: "func initDiffCmd()",
+			}, true)
+			log.Err(err).Msg("Failed to add command required flags")
+		}
+	}
+	return nil
+}
+
+func preDiff(cmd *cobra.Command) error {
+	if err := flags.Validate(); err != nil {
+		return err
+	}
+	if err := internalPrinter.SetupPrinter(cmd.InheritedFlags()); err != nil {
+		return errors.New(initError + err.Error())
+	}
+	return nil
+}
+
+func getDiffParameters() *diff.Parameters {
+	return &diff.Parameters{
+		BaseResults:   flags.GetStrFlag(flags.DiffBaseResults),
+		HeadResults:   flags.GetStrFlag(flags.DiffHeadResults),
+		OutputName:    flags.GetStrFlag(flags.DiffOutputName),
+		OutputPath:    flags.GetStrFlag(flags.DiffOutputPath),
+		ReportFormats: flags.GetMultiStrFlag(flags.DiffReportFormats),
+	}
+}
+
+func executeDiff(diffParams *diff.Parameters) error {
+	log.Debug().Msg("console.diff()")
+
+	base, err := report.ReadJSONReport(diffParams.BaseResults)
+	if err != nil {
+		log.Err(err)
+		return err
+	}
+	head, err := report.ReadJSONReport(diffParams.HeadResults)
+	if err != nil {
+		log.Err(err)
+		return err
+	}
+
+	diffReport := diff.Compare(base, head, diffParams.BaseResults, diffParams.HeadResults)
+
+	internalPrinter.PrintDiff(diffReport, internalPrinter.NewPrinter(false))
+
+	return diff.Export(diffReport, diffParams.OutputPath, diffParams.OutputName, diffParams.ReportFormats)
+}
@@ -0,0 +1,10 @@
+package flags
+
+// Flags constants for diff
+const (
+	DiffBaseResults   = "base-results"
+	DiffHeadResults   = "head-results"
+	DiffOutputName    = "diff-output-name"
+	DiffOutputPath    = "diff-output-path"
+	DiffReportFormats = "diff-report-formats"
+)
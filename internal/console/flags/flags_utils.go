@@ -40,6 +40,14 @@ func ValidateTypeSelectionFlags() error {
 	return nil
 }
 
+// ValidateChangedLinesFlags reports the impossibility of reading the changed lines from a diff file and from git
+func ValidateChangedLinesFlags() error {
+	if GetStrFlag(ChangedLinesDiffFlag) != "" && GetStrFlag(ChangedLinesSinceFlag) != "" {
+		return FormatNewError(ChangedLinesDiffFlag, ChangedLinesSinceFlag)
+	}
+	return nil
+}
+
 // BindFlags fill flags values with config file or environment variables data
 func BindFlags(cmd *cobra.Command, v *viper.Viper) error {
 	log.Debug().Msg("console.bindFlags()")
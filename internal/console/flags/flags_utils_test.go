@@ -74,6 +74,19 @@ func TestFlags_ValidateTypeSelectionFlags(t *testing.T) {
 	require.NoError(t, got)
 }
 
+func TestFlags_ValidateChangedLinesFlags(t *testing.T) {
+	diffFile, since := "", ""
+	flagsStrReferences[ChangedLinesDiffFlag] = &diffFile
+	flagsStrReferences[ChangedLinesSinceFlag] = &since
+	require.NoError(t, ValidateChangedLinesFlags())
+
+	diffFile = "changes.diff"
+	require.NoError(t, ValidateChangedLinesFlags())
+
+	since = "main"
+	require.Error(t, ValidateChangedLinesFlags())
+}
+
 func TestFlags_BindFlags(t *testing.T) {
 	mockCmd := &cobra.Command{
 		Use:   "mock",
@@ -2,6 +2,9 @@ package flags
 
 // Flags constants for remediate
 const (
-	Results    = "results"
-	IncludeIds = "include-ids"
+	Results     = "results"
+	IncludeIds  = "include-ids"
+	DryRun      = "dry-run"
+	PatchFile   = "patch-file"
+	Interactive = "interactive"
 )
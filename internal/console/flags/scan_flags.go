@@ -2,7 +2,13 @@ package flags
 
 // Flags constants for scan
 const (
+	BaselineFlag            = "baseline"
 	BomFlag                 = "bom"
+	CachePathFlag           = "cache-path"
+	ChangedLinesDiffFlag    = "changed-lines-diff"
+	ChangedLinesSinceFlag   = "changed-lines-since"
+	ChangedSinceFlag        = "changed-since"
+	CIAnnotationsFlag       = "ci-annotations"
 	CloudProviderFlag       = "cloud-provider"
 	ConfigFlag              = "config"
 	DisableFullDescFlag     = "disable-full-descriptions"
@@ -12,11 +18,13 @@ const (
 	ExcludeResultsFlag      = "exclude-results"
 	ExcludeSeveritiesFlag   = "exclude-severities"
 	ExperimentalQueriesFlag = "experimental-queries"
+	ExplainQueryFlag        = "explain-query"
 	IncludeQueriesFlag      = "include-queries"
 	InputDataFlag           = "input-data"
 	FailOnFlag              = "fail-on"
 	IgnoreOnExitFlag        = "ignore-on-exit"
 	MinimalUIFlag           = "minimal-ui"
+	NoCacheFlag             = "no-cache"
 	NoProgressFlag          = "no-progress"
 	OutputNameFlag          = "output-name"
 	OutputPathFlag          = "output-path"
@@ -24,11 +32,16 @@ const (
 	PayloadPathFlag         = "payload-path"
 	PreviewLinesFlag        = "preview-lines"
 	QueriesPath             = "queries-path"
+	QueryProfileFlag        = "query-profile"
 	LibrariesPath           = "libraries-path"
 	ReportFormatsFlag       = "report-formats"
+	ScanIDFlag              = "scan-id"
+	StoragePathFlag         = "storage-path"
 	TypeFlag                = "type"
 	ExcludeTypeFlag         = "exclude-type"
 	TerraformVarsPathFlag   = "terraform-vars-path"
+	TerraformExpandFlag     = "terraform-expand-resources"
+	TerraformPlanFlag       = "terraform-plan-changes"
 	QueryExecTimeoutFlag    = "timeout"
 	LineInfoPayloadFlag     = "payload-lines"
 	DisableSecretsFlag      = "disable-secrets"
@@ -36,8 +49,10 @@ const (
 	ExcludeGitIgnore        = "exclude-gitignore"
 	OpenAPIReferencesFlag   = "enable-openapi-refs"
 	ParallelScanFile        = "parallel"
+	MarkdownMaxLengthFlag   = "markdown-max-length"
 	MaxFileSizeFlag         = "max-file-size"
 	UseOldSeveritiesFlag    = "old-severities"
+	WriteBaselineFlag       = "write-baseline"
 	MaxResolverDepth        = "max-resolver-depth"
 	KicsComputeNewSimIDFlag = "kics_compute_new_simid"
 )
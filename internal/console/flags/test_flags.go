@@ -0,0 +1,8 @@
+package flags
+
+// Flags constants for test
+const (
+	TestJUnitOutput   = "junit-output"
+	TestLibrariesPath = "test-libraries-path"
+	TestTimeout       = "test-timeout"
+)
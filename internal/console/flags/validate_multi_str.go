@@ -9,11 +9,14 @@ import (
 
 	"github.com/Checkmarx/kics/v2/internal/console/helpers"
 	"github.com/Checkmarx/kics/v2/internal/constants"
+	"github.com/Checkmarx/kics/v2/pkg/diff"
+	"github.com/Checkmarx/kics/v2/pkg/report"
 	"github.com/Checkmarx/kics/v2/pkg/utils"
 )
 
 var validMultiStrEnums = map[string]map[string]string{
 	CloudProviderFlag:     constants.AvailableCloudProviders,
+	DiffReportFormats:     convertSliceToDummyMap(diff.ReportFormats),
 	ExcludeCategoriesFlag: constants.AvailableCategories,
 	ExcludeSeveritiesFlag: convertSliceToDummyMap(constants.AvailableSeverities),
 	FailOnFlag:            convertSliceToDummyMap(constants.AvailableSeverities),
@@ -22,6 +25,12 @@ var validMultiStrEnums = map[string]map[string]string{
 	ExcludeTypeFlag:       constants.AvailablePlatforms,
 }
 
+// multiStrEnumPrefixes are the prefixes of the arguments of enum flags that are validated by their own function,
+// such as the custom templates of the report formats
+var multiStrEnumPrefixes = map[string]map[string]func(string) error{
+	ReportFormatsFlag: {report.TemplateFormatPrefix: report.ValidateTemplateFormat},
+}
+
 func sliceFlagsShouldNotStartWithFlags(flagName string) error {
 	values := GetMultiStrFlag(flagName)
 	re := regexp.MustCompile(`^--[a-z-]+$`)
@@ -52,6 +61,12 @@ func validateMultiStrEnum(flagName string) error {
 		caseInsensitiveMap[strings.ToLower(key)] = value
 	}
 	for _, enum := range enums {
+		if validate, ok := enumPrefixValidation(flagName, enum); ok {
+			if err := validate(enum); err != nil {
+				return fmt.Errorf("invalid argument --%s: %w", flagName, err)
+			}
+			continue
+		}
 		if _, ok := caseInsensitiveMap[strings.ToLower(enum)]; enum != "" && !ok {
 			invalidEnum = append(invalidEnum, enum)
 		}
@@ -68,6 +83,15 @@ func validateMultiStrEnum(flagName string) error {
 	return nil
 }
 
+func enumPrefixValidation(flagName, enum string) (func(string) error, bool) {
+	for prefix, validate := range multiStrEnumPrefixes[flagName] {
+		if strings.HasPrefix(strings.ToLower(enum), prefix) {
+			return validate, true
+		}
+	}
+	return nil, false
+}
+
 func validateWorkersFlag(flagName string) error {
 	workers := GetIntFlag(flagName)
 	if workers < 0 {
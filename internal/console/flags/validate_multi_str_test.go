@@ -2,6 +2,8 @@
 package flags
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -77,6 +79,12 @@ func TestFlags_validateMultiStrEnum(t *testing.T) {
 			flagValue: &[]string{"Ansible", "Terrraform"},
 			wantErr:   true,
 		},
+		{
+			name:      "should return an error when the report template does not exist",
+			flagName:  "report-formats",
+			flagValue: &[]string{"json", "template:missing.tmpl"},
+			wantErr:   true,
+		},
 	}
 	for _, test := range tests {
 		flagsMultiStrReferences[test.flagName] = test.flagValue
@@ -91,6 +99,19 @@ func TestFlags_validateMultiStrEnum(t *testing.T) {
 	}
 }
 
+func TestFlags_validateMultiStrEnum_Template(t *testing.T) {
+	templatePath := filepath.Join(t.TempDir(), "report.md.tmpl")
+	require.NoError(t, os.WriteFile(templatePath, []byte("{{ .TotalCounter }}"), 0600))
+	invalidPath := filepath.Join(t.TempDir(), "invalid.tmpl")
+	require.NoError(t, os.WriteFile(invalidPath, []byte("{{ .TotalCounter"), 0600))
+
+	flagsMultiStrReferences[ReportFormatsFlag] = &[]string{"json", "Template:" + templatePath}
+	require.NoError(t, validateMultiStrEnum(ReportFormatsFlag))
+
+	flagsMultiStrReferences[ReportFormatsFlag] = &[]string{"template:" + invalidPath}
+	require.Error(t, validateMultiStrEnum(ReportFormatsFlag))
+}
+
 func TestFlags_allQueriesID(t *testing.T) {
 	tests := []struct {
 		name      string
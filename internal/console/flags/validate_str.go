@@ -9,7 +9,8 @@ import (
 )
 
 var validStrEnums = map[string]map[string]string{
-	LogLevelFlag: convertSliceToDummyMap(constants.AvailableLogLevels),
+	LogLevelFlag:      convertSliceToDummyMap(constants.AvailableLogLevels),
+	CIAnnotationsFlag: convertSliceToDummyMap(constants.AvailableCIAnnotations),
 }
 
 func validateStrEnum(flagName string) error {
@@ -11,11 +11,15 @@ var shouldIgnore string
 var shouldFail map[string]struct{}
 
 // ResultsExitCode calculate exit code base on severity of results, returns 0 if no results was reported
+// when the scan was compared with a baseline only new results are considered
 func ResultsExitCode(summary *model.Summary) int {
 	// severityArr is needed to make sure 'for' cycle is made in an ordered fashion
 	severityArr := []model.Severity{"CRITICAL", "HIGH", "MEDIUM", "LOW", "INFO", "TRACE"}
 	codeMap := map[model.Severity]int{"CRITICAL": 60, "HIGH": 50, "MEDIUM": 40, "LOW": 30, "INFO": 20, "TRACE": 0}
 	exitMap := summary.SeveritySummary.SeverityCounters
+	if summary.Baseline != nil {
+		exitMap = summary.Baseline.SeverityCounters
+	}
 	for _, severity := range severityArr {
 		if _, reportSeverity := shouldFail[strings.ToLower(string(severity))]; !reportSeverity {
 			continue
@@ -81,3 +85,14 @@ func RemediateExitCode(selectedRemediationNumber, actualRemediationDoneNumber in
 
 	return 0
 }
+
+// QueryTestExitCode calculate exit code base on the number of queries failing their tests
+func QueryTestExitCode(failedQueries int) int {
+	statusCode := 80
+	if failedQueries > 0 {
+		// at least one query did not produce the expected results on its samples
+		return statusCode
+	}
+
+	return 0
+}
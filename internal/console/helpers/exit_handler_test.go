@@ -62,6 +62,38 @@ var resultsExitCodeTests = []struct {
 		},
 		expectedResult: 60,
 	},
+	{
+		caseTest: resultExitCode{
+			summary: model.Summary{
+				SeveritySummary: test.ComplexSummaryMock.SeveritySummary,
+				Baseline: &model.BaselineSummary{
+					SeverityCounters: map[model.Severity]int{model.SeverityLow: 1},
+				},
+			},
+			failOn: map[string]struct{}{
+				"critical": {},
+				"high":     {},
+				"medium":   {},
+				"low":      {},
+				"info":     {},
+			},
+		},
+		expectedResult: 30,
+	},
+	{
+		caseTest: resultExitCode{
+			summary: model.Summary{
+				SeveritySummary: test.ComplexSummaryMock.SeveritySummary,
+				Baseline: &model.BaselineSummary{
+					SeverityCounters: map[model.Severity]int{},
+				},
+			},
+			failOn: map[string]struct{}{
+				"critical": {},
+			},
+		},
+		expectedResult: 0,
+	},
 }
 
 func TestExitHandler_ResultsExitCode(t *testing.T) {
@@ -262,3 +294,14 @@ func Test_RemediateNotAll(t *testing.T) {
 		require.Equal(t, statusCode, 70)
 	})
 }
+
+func Test_QueryTestExitCode(t *testing.T) {
+	t.Run("QueryTestPassedExitCode", func(t *testing.T) {
+		statusCode := QueryTestExitCode(0)
+		require.Equal(t, statusCode, 0)
+	})
+	t.Run("QueryTestFailedExitCode", func(t *testing.T) {
+		statusCode := QueryTestExitCode(2)
+		require.Equal(t, statusCode, 80)
+	})
+}
@@ -37,6 +37,11 @@ var reportGenerators = map[string]func(path, filename string, body interface{})
 	"asff":        report.PrintASFFReport,
 	"csv":         report.PrintCSVReport,
 	"codeclimate": report.PrintCodeClimateReport,
+	"markdown":    report.PrintMarkdownReport,
+	"jsonl":       report.PrintJSONLReport,
+	"checkstyle":  report.PrintCheckstyleReport,
+	"rdjson":      report.PrintRDJSONReport,
+	"rdjsonl":     report.PrintRDJSONLReport,
 }
 
 // CustomConsoleWriter creates an output to print log in a files
@@ -109,6 +114,13 @@ func GenerateReport(path, filename string, body interface{}, formats []string, p
 	defer progressBar.Close()
 
 	for _, format := range formats {
+		if templatePath, ok := report.TemplatePath(format); ok {
+			if err = report.PrintTemplateReport(path, filename, templatePath, body); err != nil {
+				log.Error().Msgf("Failed to generate %s report", format)
+				break
+			}
+			continue
+		}
 		format = strings.ToLower(format)
 		if err = reportGenerators[format](path, filename, body); err != nil {
 			log.Error().Msgf("Failed to generate %s report", format)
@@ -19,7 +19,7 @@ import (
 )
 
 const (
-	scanID = "console"
+	defaultScanID = "console"
 )
 
 var (
@@ -44,12 +44,16 @@ func initialize(rootCmd *cobra.Command) error {
 	scanCmd := NewScanCmd()
 	remediateCmd := NewRemediateCmd()
 	analyzeCmd := NewAnalyzeCmd()
+	diffCmd := NewDiffCmd()
+	testCmd := NewTestCmd()
 	rootCmd.AddCommand(NewVersionCmd())
 	rootCmd.AddCommand(NewGenerateIDCmd())
 	rootCmd.AddCommand(scanCmd)
 	rootCmd.AddCommand(NewListPlatformsCmd())
 	rootCmd.AddCommand(remediateCmd)
 	rootCmd.AddCommand(analyzeCmd)
+	rootCmd.AddCommand(diffCmd)
+	rootCmd.AddCommand(testCmd)
 	rootCmd.CompletionOptions.DisableDefaultCmd = true
 
 	if err := flags.InitJSONFlags(
@@ -73,6 +77,14 @@ func initialize(rootCmd *cobra.Command) error {
 		return err
 	}
 
+	if err := initDiffCmd(diffCmd); err != nil {
+		return err
+	}
+
+	if err := initTestCmd(testCmd); err != nil {
+		return err
+	}
+
 	return initScanCmd(scanCmd)
 }
 
@@ -43,6 +43,11 @@ func preRun(cmd *cobra.Command) error {
 		return err
 	}
 
+	err = flags.ValidateChangedLinesFlags()
+	if err != nil {
+		return err
+	}
+
 	err = internalPrinter.SetupPrinter(cmd.InheritedFlags())
 	if err != nil {
 		return errors.New(initError + err.Error())
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 
 	"github.com/Checkmarx/kics/v2/internal/console/flags"
 	consoleHelpers "github.com/Checkmarx/kics/v2/internal/console/helpers"
@@ -80,6 +82,9 @@ func remediate() error {
 	include := flags.GetMultiStrFlag(flags.IncludeIds)
 	openAPIResolveReferences := flags.GetBoolFlag(flags.OpenAPIReferencesFlag)
 	maxResolverDepth := flags.GetIntFlag(flags.MaxResolverDepth)
+	dryRun := flags.GetBoolFlag(flags.DryRun)
+	patchFile := flags.GetStrFlag(flags.PatchFile)
+	interactive := flags.GetBoolFlag(flags.Interactive)
 
 	filepath.Clean(resultsPath)
 
@@ -105,16 +110,29 @@ func remediate() error {
 	// get all the remediationSets related to each filePath
 	remediationSets := summary.GetRemediationSets(results, include)
 
-	for filePath := range remediationSets {
-		fix := remediationSets[filePath].(remediation.Set)
-		err = summary.RemediateFile(filePath, fix, openAPIResolveReferences, maxResolverDepth)
-		if err != nil {
+	if interactive {
+		remediationSets = summary.Review(results, remediationSets, os.Stdin, os.Stdout)
+	}
+
+	if dryRun || patchFile != "" {
+		if err = previewRemediation(summary, remediationSets, dryRun, patchFile, openAPIResolveReferences, maxResolverDepth); err != nil {
 			return err
 		}
+	} else {
+		for filePath := range remediationSets {
+			fix := remediationSets[filePath].(remediation.Set)
+			err = summary.RemediateFile(filePath, fix, openAPIResolveReferences, maxResolverDepth)
+			if err != nil {
+				return err
+			}
+		}
 	}
 
 	fmt.Printf("\nSelected remediation: %d\n", summary.SelectedRemediationNumber)
 	fmt.Printf("Remediation done: %d\n", summary.ActualRemediationDoneNumber)
+	if interactive {
+		fmt.Printf("Remediation skipped: %d\n", summary.SkippedRemediationNumber)
+	}
 
 	exitCode := consoleHelpers.RemediateExitCode(summary.SelectedRemediationNumber, summary.ActualRemediationDoneNumber)
 	if exitCode != 0 {
@@ -123,3 +141,71 @@ func remediate() error {
 
 	return nil
 }
+
+// previewRemediation computes the remediations of the files without changing them, printing them as a unified diff
+// and/or writing them to a patch file
+func previewRemediation(
+	summary *remediation.Summary,
+	remediationSets map[string]interface{},
+	dryRun bool,
+	patchFile string,
+	openAPIResolveReferences bool,
+	maxResolverDepth int) error {
+	filePaths := make([]string, 0, len(remediationSets))
+	for filePath := range remediationSets {
+		filePaths = append(filePaths, filePath)
+	}
+	sort.Strings(filePaths)
+
+	var patch strings.Builder
+	for _, filePath := range filePaths {
+		fix := remediationSets[filePath].(remediation.Set)
+		original, remediated, err := summary.GetRemediatedContent(filePath, fix, openAPIResolveReferences, maxResolverDepth)
+		if err != nil {
+			return err
+		}
+		patch.WriteString(remediation.UnifiedDiff(patchPath(filePath), original, remediated))
+	}
+
+	if dryRun {
+		fmt.Print(patch.String())
+	}
+
+	if patchFile != "" {
+		if err := os.WriteFile(filepath.Clean(patchFile), []byte(patch.String()), remediation.FilePermMode); err != nil {
+			log.Error().Msgf("failed to write patch file: %s", err)
+			return err
+		}
+		log.Info().Msgf("Remediation patch written to %s", patchFile)
+	}
+
+	return nil
+}
+
+// patchPath returns the path of the file relative to the root of its git repository, where 'git apply' expects it,
+// falling back to the path relative to the working directory
+func patchPath(filePath string) string {
+	absPath, err := filepath.Abs(filePath)
+	if err != nil {
+		return filepath.ToSlash(filePath)
+	}
+
+	for dir := filepath.Dir(absPath); ; dir = filepath.Dir(dir) {
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			if relPath, err := filepath.Rel(dir, absPath); err == nil {
+				return filepath.ToSlash(relPath)
+			}
+		}
+		if dir == filepath.Dir(dir) {
+			break
+		}
+	}
+
+	if wd, err := os.Getwd(); err == nil {
+		if relPath, err := filepath.Rel(wd, absPath); err == nil && !strings.HasPrefix(relPath, "..") {
+			return filepath.ToSlash(relPath)
+		}
+	}
+
+	return filepath.ToSlash(filePath)
+}
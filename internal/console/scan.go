@@ -13,7 +13,9 @@ import (
 	"github.com/Checkmarx/kics/v2/internal/constants"
 	sentryReport "github.com/Checkmarx/kics/v2/internal/sentry"
 	"github.com/Checkmarx/kics/v2/pkg/engine/source"
+	"github.com/Checkmarx/kics/v2/pkg/report"
 	"github.com/Checkmarx/kics/v2/pkg/scan"
+	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
 )
@@ -90,6 +92,11 @@ func run(cmd *cobra.Command) error {
 			return err
 		}
 	}
+	if flags.GetStrFlag(flags.QueryProfileFlag) != "" && filepath.Dir(flags.GetStrFlag(flags.QueryProfileFlag)) != "." {
+		if err := os.MkdirAll(filepath.Dir(flags.GetStrFlag(flags.QueryProfileFlag)), os.ModePerm); err != nil {
+			return err
+		}
+	}
 	gracefulShutdown()
 
 	// save the scan parameters into the ScanParameters struct
@@ -99,9 +106,17 @@ func run(cmd *cobra.Command) error {
 }
 
 func updateReportFormats() {
-	for _, format := range flags.GetMultiStrFlag(flags.ReportFormatsFlag) {
+	formats := flags.GetMultiStrFlag(flags.ReportFormatsFlag)
+	for _, format := range formats {
 		if strings.EqualFold(format, "all") {
-			flags.SetMultiStrFlag(flags.ReportFormatsFlag, consoleHelpers.ListReportFormats())
+			// custom templates are not part of all the report formats, they are kept when given
+			allFormats := consoleHelpers.ListReportFormats()
+			for _, templateFormat := range formats {
+				if _, ok := report.TemplatePath(templateFormat); ok {
+					allFormats = append(allFormats, templateFormat)
+				}
+			}
+			flags.SetMultiStrFlag(flags.ReportFormatsFlag, allFormats)
 			break
 		}
 	}
@@ -130,11 +145,22 @@ func getScanParameters(changedDefaultQueryPath, changedDefaultLibrariesPath bool
 		Platform:                    flags.GetMultiStrFlag(flags.TypeFlag),
 		ExcludePlatform:             flags.GetMultiStrFlag(flags.ExcludeTypeFlag),
 		TerraformVarsPath:           flags.GetStrFlag(flags.TerraformVarsPathFlag),
+		TerraformExpandResources:    flags.GetBoolFlag(flags.TerraformExpandFlag),
+		TerraformPlanChanges:        flags.GetBoolFlag(flags.TerraformPlanFlag),
 		QueryExecTimeout:            flags.GetIntFlag(flags.QueryExecTimeoutFlag),
 		LineInfoPayload:             flags.GetBoolFlag(flags.LineInfoPayloadFlag),
 		DisableSecrets:              flags.GetBoolFlag(flags.DisableSecretsFlag),
 		SecretsRegexesPath:          flags.GetStrFlag(flags.SecretsRegexesPathFlag),
-		ScanID:                      scanID,
+		StoragePath:                 flags.GetStrFlag(flags.StoragePathFlag),
+		BaselinePath:                flags.GetStrFlag(flags.BaselineFlag),
+		WriteBaselinePath:           flags.GetStrFlag(flags.WriteBaselineFlag),
+		ChangedSince:                flags.GetStrFlag(flags.ChangedSinceFlag),
+		ChangedLinesDiff:            flags.GetStrFlag(flags.ChangedLinesDiffFlag),
+		ChangedLinesSince:           flags.GetStrFlag(flags.ChangedLinesSinceFlag),
+		CachePath:                   getCachePath(),
+		ExplainQuery:                flags.GetStrFlag(flags.ExplainQueryFlag),
+		QueryProfilePath:            flags.GetStrFlag(flags.QueryProfileFlag),
+		ScanID:                      getScanID(),
 		ChangedDefaultLibrariesPath: changedDefaultLibrariesPath,
 		ChangedDefaultQueryPath:     changedDefaultQueryPath,
 		BillOfMaterials:             flags.GetBoolFlag(flags.BomFlag),
@@ -142,6 +168,8 @@ func getScanParameters(changedDefaultQueryPath, changedDefaultLibrariesPath bool
 		OpenAPIResolveReferences:    flags.GetBoolFlag(flags.OpenAPIReferencesFlag),
 		ParallelScanFlag:            flags.GetIntFlag(flags.ParallelScanFile),
 		MaxFileSizeFlag:             flags.GetIntFlag(flags.MaxFileSizeFlag),
+		MarkdownMaxLength:           flags.GetIntFlag(flags.MarkdownMaxLengthFlag),
+		CIAnnotations:               flags.GetStrFlag(flags.CIAnnotationsFlag),
 		UseOldSeverities:            flags.GetBoolFlag(flags.UseOldSeveritiesFlag),
 		MaxResolverDepth:            flags.GetIntFlag(flags.MaxResolverDepth),
 		KicsComputeNewSimID:         flags.GetBoolFlag(flags.KicsComputeNewSimIDFlag),
@@ -150,6 +178,35 @@ func getScanParameters(changedDefaultQueryPath, changedDefaultLibrariesPath bool
 	return &scanParams
 }
 
+// getScanID returns the scan id flag value, when not provided scans kept on a storage get a new identifier
+// so they don't replace each other
+func getScanID() string {
+	if scanID := flags.GetStrFlag(flags.ScanIDFlag); scanID != "" {
+		return scanID
+	}
+	if flags.GetStrFlag(flags.StoragePathFlag) != "" {
+		return uuid.New().String()
+	}
+	return defaultScanID
+}
+
+// getCachePath returns the directory of the cache of parsed files and query results, the kics directory of the user
+// cache directory when not provided, or an empty path when the cache is disabled
+func getCachePath() string {
+	if flags.GetBoolFlag(flags.NoCacheFlag) {
+		return ""
+	}
+	if cachePath := flags.GetStrFlag(flags.CachePathFlag); cachePath != "" {
+		return cachePath
+	}
+	userCacheDir, err := os.UserCacheDir()
+	if err != nil {
+		log.Warn().Msgf("Scanning without cache, failed to find the user cache directory: %s", err)
+		return ""
+	}
+	return filepath.Join(userCacheDir, "kics")
+}
+
 func executeScan(scanParams *scan.Parameters) error {
 	log.Debug().Msg("console.scan()")
 
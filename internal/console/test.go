@@ -0,0 +1,87 @@
+package console
+
+import (
+	_ "embed" // Embed test flags
+	"os"
+
+	"github.com/Checkmarx/kics/v2/internal/console/flags"
+	consoleHelpers "github.com/Checkmarx/kics/v2/internal/console/helpers"
+	"github.com/Checkmarx/kics/v2/pkg/engine/source"
+	internalPrinter "github.com/Checkmarx/kics/v2/pkg/printer"
+	"github.com/Checkmarx/kics/v2/pkg/querytest"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+var (
+	//go:embed assets/test-flags.json
+	testFlagsListContent string
+)
+
+// NewTestCmd creates a new instance of the test Command
+func NewTestCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "test <queries-dir>",
+		Short: "Runs the positive and negative tests of queries",
+		Args:  cobra.ExactArgs(1),
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return preTest(cmd)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return executeTest(getTestParameters(args[0]))
+		},
+	}
+}
+
+func initTestCmd(testCmd *cobra.Command) error {
+	return flags.InitJSONFlags(
+		testCmd,
+		testFlagsListContent,
+		false,
+		source.ListSupportedPlatforms(),
+		source.ListSupportedCloudProviders())
+}
+
+func preTest(cmd *cobra.Command) error {
+	if err := flags.Validate(); err != nil {
+		return err
+	}
+	if err := internalPrinter.SetupPrinter(cmd.InheritedFlags()); err != nil {
+		return errors.New(initError + err.Error())
+	}
+	return nil
+}
+
+func getTestParameters(queriesPath string) *querytest.Parameters {
+	return &querytest.Parameters{
+		QueriesPath:   queriesPath,
+		LibrariesPath: flags.GetStrFlag(flags.TestLibrariesPath),
+		JUnitOutput:   flags.GetStrFlag(flags.TestJUnitOutput),
+		QueryTimeout:  flags.GetIntFlag(flags.TestTimeout),
+	}
+}
+
+func executeTest(testParams *querytest.Parameters) error {
+	log.Debug().Msg("console.test()")
+
+	testReport, err := querytest.Run(ctx, testParams)
+	if err != nil {
+		log.Err(err)
+		return err
+	}
+
+	internalPrinter.PrintQueryTests(testReport, internalPrinter.NewPrinter(false))
+
+	if err := querytest.ExportJUnit(testReport, testParams.JUnitOutput); err != nil {
+		log.Err(err)
+		return err
+	}
+
+	exitCode := consoleHelpers.QueryTestExitCode(testReport.Failed)
+	if exitCode != 0 {
+		os.Exit(exitCode)
+	}
+
+	return nil
+}
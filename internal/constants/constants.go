@@ -79,6 +79,14 @@ var (
 		"FATAL",
 	}
 
+	// AvailableCIAnnotations - All CI annotation formats available, auto detects the CI from the environment
+	AvailableCIAnnotations = []string{
+		"auto",
+		"azure",
+		"github",
+		"teamcity",
+	}
+
 	// AvailableCloudProviders - All cloud providers available
 	AvailableCloudProviders = map[string]string{
 		"alicloud":     "",
@@ -0,0 +1,338 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/Checkmarx/kics/v2/pkg/model"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+
+	_ "modernc.org/sqlite" // Register the pure go sqlite driver
+)
+
+const sqliteSchema = `
+CREATE TABLE IF NOT EXISTS files (
+	id                 TEXT NOT NULL,
+	scan_id            TEXT NOT NULL,
+	file_path          TEXT NOT NULL,
+	kind               TEXT NOT NULL,
+	orig_data          TEXT NOT NULL,
+	helm_id            TEXT NOT NULL,
+	is_minified        BOOLEAN NOT NULL,
+	document           TEXT NOT NULL,
+	line_info_document TEXT NOT NULL,
+	commands           TEXT NOT NULL,
+	PRIMARY KEY (scan_id, id)
+);
+
+CREATE TABLE IF NOT EXISTS vulnerabilities (
+	id                 INTEGER NOT NULL,
+	scan_id            TEXT NOT NULL,
+	similarity_id      TEXT NOT NULL,
+	old_similarity_id  TEXT NOT NULL,
+	file_id            TEXT NOT NULL,
+	file_name          TEXT NOT NULL,
+	query_id           TEXT NOT NULL,
+	query_name         TEXT NOT NULL,
+	query_uri          TEXT NOT NULL,
+	category           TEXT NOT NULL,
+	experimental       BOOLEAN NOT NULL,
+	description        TEXT NOT NULL,
+	description_id     TEXT NOT NULL,
+	platform           TEXT NOT NULL,
+	cwe                TEXT NOT NULL,
+	severity           TEXT NOT NULL,
+	line               INTEGER NOT NULL,
+	vuln_lines         TEXT NOT NULL,
+	resource_type      TEXT NOT NULL,
+	resource_name      TEXT NOT NULL,
+	issue_type         TEXT NOT NULL,
+	search_key         TEXT NOT NULL,
+	search_line        INTEGER NOT NULL,
+	search_value       TEXT NOT NULL,
+	key_expected_value TEXT NOT NULL,
+	key_actual_value   TEXT NOT NULL,
+	value              TEXT,
+	output             TEXT NOT NULL,
+	cloud_provider     TEXT NOT NULL,
+	remediation        TEXT NOT NULL,
+	remediation_type   TEXT NOT NULL,
+	module_call        TEXT NOT NULL,
+	change_action      TEXT NOT NULL,
+	UNIQUE (scan_id, query_id, file_name, line, similarity_id, search_key, key_actual_value)
+);
+
+CREATE INDEX IF NOT EXISTS vulnerabilities_scan_id ON vulnerabilities (scan_id);
+`
+
+const insertFileQuery = `
+INSERT OR REPLACE INTO files (
+	id, scan_id, file_path, kind, orig_data, helm_id, is_minified, document, line_info_document, commands
+) VALUES (
+	:id, :scan_id, :file_path, :kind, :orig_data, :helm_id, :is_minified, :document, :line_info_document, :commands
+)`
+
+// vulnerabilities are unique by the same key MemoryStorage uses to remove duplicated results
+const insertVulnerabilityQuery = `
+INSERT OR IGNORE INTO vulnerabilities (
+	id, scan_id, similarity_id, old_similarity_id, file_id, file_name, query_id, query_name, query_uri, category,
+	experimental, description, description_id, platform, cwe, severity, line, vuln_lines, resource_type,
+	resource_name, issue_type, search_key, search_line, search_value, key_expected_value, key_actual_value,
+	value, output, cloud_provider, remediation, remediation_type, module_call, change_action
+) VALUES (
+	:id, :scan_id, :similarity_id, :old_similarity_id, :file_id, :file_name, :query_id, :query_name, :query_uri,
+	:category, :experimental, :description, :description_id, :platform, :cwe, :severity, :line, :vuln_lines,
+	:resource_type, :resource_name, :issue_type, :search_key, :search_line, :search_value, :key_expected_value,
+	:key_actual_value, :value, :output, :cloud_provider, :remediation, :remediation_type, :module_call,
+	:change_action
+)`
+
+// fileRow is the files table representation of a model.FileMetadata
+type fileRow struct {
+	model.FileMetadata
+	DocumentJSON         string `db:"document"`
+	LineInfoDocumentJSON string `db:"line_info_document"`
+	CommandsJSON         string `db:"commands"`
+}
+
+// vulnerabilityRow is the vulnerabilities table representation of a model.Vulnerability
+type vulnerabilityRow struct {
+	model.Vulnerability
+	VulnLinesJSON  string `db:"vuln_lines"`
+	ModuleCallJSON string `db:"module_call"`
+}
+
+// SQLiteStorage keeps scans' results in a SQLite database, every scan is kept by its scan ID
+type SQLiteStorage struct {
+	db *sqlx.DB
+	mu sync.Mutex
+}
+
+// NewSQLiteStorage opens (or creates) the SQLite database on path and returns a SQLiteStorage using it
+func NewSQLiteStorage(path string) (*SQLiteStorage, error) {
+	log.Debug().Msgf("storage.NewSQLiteStorage(%s)", path)
+	db, err := sqlx.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open sqlite storage %s: %w", path, err)
+	}
+	// sqlite only supports one writer at a time
+	db.SetMaxOpenConns(1)
+
+	for _, pragma := range []string{"PRAGMA journal_mode = WAL", "PRAGMA synchronous = NORMAL"} {
+		if _, err = db.Exec(pragma); err != nil {
+			_ = db.Close()
+			return nil, fmt.Errorf("failed to configure sqlite storage %s: %w", path, err)
+		}
+	}
+	if _, err = db.Exec(sqliteSchema); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to create sqlite storage schema on %s: %w", path, err)
+	}
+
+	return &SQLiteStorage{
+		db: db,
+	}, nil
+}
+
+// SaveFile adds a new file metadata to the files table
+func (s *SQLiteStorage) SaveFile(ctx context.Context, metadata *model.FileMetadata) error {
+	row := fileRow{
+		FileMetadata: *metadata,
+	}
+	var err error
+	if row.DocumentJSON, err = marshalColumn(metadata.Document); err != nil {
+		return err
+	}
+	if row.LineInfoDocumentJSON, err = marshalColumn(metadata.LineInfoDocument); err != nil {
+		return err
+	}
+	if row.CommandsJSON, err = marshalColumn(metadata.Commands); err != nil {
+		return err
+	}
+
+	defer s.mu.Unlock()
+	s.mu.Lock()
+	if _, err = s.db.NamedExecContext(ctx, insertFileQuery, row); err != nil {
+		return fmt.Errorf("failed to save file %s: %w", metadata.FilePath, err)
+	}
+	return nil
+}
+
+// GetFiles returns the files saved for the scan with scanID
+func (s *SQLiteStorage) GetFiles(ctx context.Context, scanID string) (model.FileMetadatas, error) {
+	rows := make([]fileRow, 0)
+	if err := s.db.SelectContext(ctx, &rows,
+		`SELECT id, scan_id, file_path, kind, orig_data, helm_id, is_minified, document, line_info_document, commands
+		FROM files WHERE scan_id = ? ORDER BY rowid`, scanID); err != nil {
+		return nil, fmt.Errorf("failed to get files of scan %s: %w", scanID, err)
+	}
+
+	files := make(model.FileMetadatas, 0, len(rows))
+	for i := range rows {
+		file := rows[i].FileMetadata
+		if err := unmarshalColumn(rows[i].DocumentJSON, &file.Document); err != nil {
+			return nil, err
+		}
+		if err := unmarshalColumn(rows[i].LineInfoDocumentJSON, &file.LineInfoDocument); err != nil {
+			return nil, err
+		}
+		if err := unmarshalColumn(rows[i].CommandsJSON, &file.Commands); err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+// SaveVulnerabilities adds a list of vulnerabilities to the vulnerabilities table
+func (s *SQLiteStorage) SaveVulnerabilities(ctx context.Context, vulnerabilities []model.Vulnerability) error {
+	if len(vulnerabilities) == 0 {
+		return nil
+	}
+	rows := make([]vulnerabilityRow, 0, len(vulnerabilities))
+	for i := range vulnerabilities {
+		row := vulnerabilityRow{
+			Vulnerability: vulnerabilities[i],
+		}
+		var err error
+		if row.VulnLinesJSON, err = marshalColumn(vulnerabilities[i].VulnLines); err != nil {
+			return err
+		}
+		if row.ModuleCallJSON, err = marshalColumn(vulnerabilities[i].ModuleCall); err != nil {
+			return err
+		}
+		rows = append(rows, row)
+	}
+
+	defer s.mu.Unlock()
+	s.mu.Lock()
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to save vulnerabilities: %w", err)
+	}
+	stmt, err := tx.PrepareNamedContext(ctx, insertVulnerabilityQuery)
+	if err != nil {
+		_ = tx.Rollback()
+		return fmt.Errorf("failed to save vulnerabilities: %w", err)
+	}
+	defer stmt.Close()
+	for i := range rows {
+		if _, err = stmt.ExecContext(ctx, rows[i]); err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("failed to save vulnerability of query %s: %w", rows[i].QueryID, err)
+		}
+	}
+	return tx.Commit()
+}
+
+// GetVulnerabilities returns the vulnerabilities saved for the scan with scanID
+func (s *SQLiteStorage) GetVulnerabilities(ctx context.Context, scanID string) ([]model.Vulnerability, error) {
+	rows := make([]vulnerabilityRow, 0)
+	if err := s.db.SelectContext(ctx, &rows,
+		`SELECT id, scan_id, similarity_id, old_similarity_id, file_id, file_name, query_id, query_name, query_uri,
+		category, experimental, description, description_id, platform, cwe, severity, line, vuln_lines, resource_type,
+		resource_name, issue_type, search_key, search_line, search_value, key_expected_value, key_actual_value, value,
+		output, cloud_provider, remediation, remediation_type, module_call, change_action
+		FROM vulnerabilities WHERE scan_id = ? ORDER BY rowid`, scanID); err != nil {
+		return nil, fmt.Errorf("failed to get vulnerabilities of scan %s: %w", scanID, err)
+	}
+
+	vulnerabilities := make([]model.Vulnerability, 0, len(rows))
+	for i := range rows {
+		vulnerability := rows[i].Vulnerability
+		if err := unmarshalColumn(rows[i].VulnLinesJSON, &vulnerability.VulnLines); err != nil {
+			return nil, err
+		}
+		if err := unmarshalColumn(rows[i].ModuleCallJSON, &vulnerability.ModuleCall); err != nil {
+			return nil, err
+		}
+		vulnerabilities = append(vulnerabilities, vulnerability)
+	}
+	return vulnerabilities, nil
+}
+
+// GetScanSummary returns the severity summary of each scan in scanIDs, in the same order,
+// scans without vulnerabilities have all counters set to zero
+func (s *SQLiteStorage) GetScanSummary(ctx context.Context, scanIDs []string) ([]model.SeveritySummary, error) {
+	summaries := make([]model.SeveritySummary, 0, len(scanIDs))
+	if len(scanIDs) == 0 {
+		return summaries, nil
+	}
+
+	query, args, err := sqlx.In(
+		`SELECT scan_id, severity, COUNT(*) AS total FROM vulnerabilities WHERE scan_id IN (?) GROUP BY scan_id, severity`,
+		scanIDs)
+	if err != nil {
+		return nil, err
+	}
+	counts := make([]struct {
+		ScanID   string         `db:"scan_id"`
+		Severity model.Severity `db:"severity"`
+		Total    int            `db:"total"`
+	}, 0)
+	if err = s.db.SelectContext(ctx, &counts, s.db.Rebind(query), args...); err != nil {
+		return nil, fmt.Errorf("failed to get scan summary: %w", err)
+	}
+
+	indexes := make(map[string]int, len(scanIDs))
+	for _, scanID := range scanIDs {
+		indexes[scanID] = len(summaries)
+		summaries = append(summaries, model.SeveritySummary{
+			ScanID: scanID,
+			SeverityCounters: map[model.Severity]int{
+				model.SeverityTrace:    0,
+				model.SeverityInfo:     0,
+				model.SeverityLow:      0,
+				model.SeverityMedium:   0,
+				model.SeverityHigh:     0,
+				model.SeverityCritical: 0,
+			},
+		})
+	}
+	for i := range counts {
+		summary := &summaries[indexes[counts[i].ScanID]]
+		summary.SeverityCounters[counts[i].Severity] += counts[i].Total
+		// trace results are bill of materials entries, CreateSummary does not count them as vulnerabilities
+		if counts[i].Severity == model.SeverityTrace {
+			summary.TotalBOMResources += counts[i].Total
+		} else {
+			summary.TotalCounter += counts[i].Total
+		}
+	}
+	return summaries, nil
+}
+
+// DeleteScan removes the files and vulnerabilities saved for the scan with scanID
+func (s *SQLiteStorage) DeleteScan(ctx context.Context, scanID string) error {
+	defer s.mu.Unlock()
+	s.mu.Lock()
+	for _, table := range []string{"files", "vulnerabilities"} {
+		if _, err := s.db.ExecContext(ctx, "DELETE FROM "+table+" WHERE scan_id = ?", scanID); err != nil { //nolint:gosec
+			return fmt.Errorf("failed to delete scan %s: %w", scanID, err)
+		}
+	}
+	return nil
+}
+
+// Close closes the underlying database
+func (s *SQLiteStorage) Close() error {
+	return s.db.Close()
+}
+
+func marshalColumn(value interface{}) (string, error) {
+	content, err := json.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode storage column: %w", err)
+	}
+	return string(content), nil
+}
+
+func unmarshalColumn(content string, value interface{}) error {
+	if err := json.Unmarshal([]byte(content), value); err != nil {
+		return fmt.Errorf("failed to decode storage column: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+package storage
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/Checkmarx/kics/v2/pkg/model"
+)
+
+func newTestSQLiteStorage(t *testing.T) *SQLiteStorage {
+	s, err := NewSQLiteStorage(filepath.Join(t.TempDir(), "kics.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, s.Close())
+	})
+	return s
+}
+
+func testVulnerability(scanID, queryID string, severity model.Severity, line int) model.Vulnerability {
+	value := "value"
+	return model.Vulnerability{
+		ScanID:       scanID,
+		SimilarityID: queryID + "-similarity",
+		FileID:       "file_id",
+		FileName:     "main.tf",
+		QueryID:      queryID,
+		QueryName:    "query " + queryID,
+		Severity:     severity,
+		Line:         line,
+		VulnLines: &[]model.CodeLine{
+			{Position: line, Line: "resource \"aws_s3_bucket\" \"b\" {"},
+		},
+		IssueType:  model.IssueTypeMissingAttribute,
+		SearchKey:  "aws_s3_bucket[b]",
+		Value:      &value,
+		ModuleCall: &model.ModuleCall{Name: "bucket", Source: "./modules/bucket", FileName: "main.tf", Line: 3},
+	}
+}
+
+// TestSQLiteStorage_Files tests the functions [SaveFile(), GetFiles()]
+func TestSQLiteStorage_Files(t *testing.T) {
+	s := newTestSQLiteStorage(t)
+	ctx := context.Background()
+
+	file := &model.FileMetadata{
+		ID:           "id",
+		ScanID:       "scan",
+		Document:     model.Document{"resource": map[string]interface{}{"name": "b"}},
+		OriginalData: "orig_data",
+		Kind:         model.KindTerraform,
+		FilePath:     "main.tf",
+		Commands:     model.CommentsCommands{"ignore": ""},
+	}
+	require.NoError(t, s.SaveFile(ctx, file))
+	require.NoError(t, s.SaveFile(ctx, &model.FileMetadata{ID: "other", ScanID: "other_scan", FilePath: "other.tf"}))
+
+	files, err := s.GetFiles(ctx, "scan")
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	require.Equal(t, file.ID, files[0].ID)
+	require.Equal(t, file.FilePath, files[0].FilePath)
+	require.Equal(t, file.Kind, files[0].Kind)
+	require.Equal(t, file.OriginalData, files[0].OriginalData)
+	require.Equal(t, file.Document, files[0].Document)
+	require.Equal(t, file.Commands, files[0].Commands)
+}
+
+// TestSQLiteStorage_Vulnerabilities tests the functions [SaveVulnerabilities(), GetVulnerabilities(), DeleteScan()]
+func TestSQLiteStorage_Vulnerabilities(t *testing.T) {
+	s := newTestSQLiteStorage(t)
+	ctx := context.Background()
+
+	vulnerability := testVulnerability("scan", "query", model.SeverityHigh, 2)
+	require.NoError(t, s.SaveVulnerabilities(ctx, []model.Vulnerability{vulnerability}))
+	// duplicated results are only kept once
+	require.NoError(t, s.SaveVulnerabilities(ctx, []model.Vulnerability{
+		vulnerability,
+		testVulnerability("other_scan", "query", model.SeverityHigh, 2),
+	}))
+
+	got, err := s.GetVulnerabilities(ctx, "scan")
+	require.NoError(t, err)
+	require.Equal(t, []model.Vulnerability{vulnerability}, got)
+
+	require.NoError(t, s.DeleteScan(ctx, "scan"))
+	got, err = s.GetVulnerabilities(ctx, "scan")
+	require.NoError(t, err)
+	require.Empty(t, got)
+
+	got, err = s.GetVulnerabilities(ctx, "other_scan")
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+}
+
+// TestSQLiteStorage_GetScanSummary tests the function [GetScanSummary()]
+func TestSQLiteStorage_GetScanSummary(t *testing.T) {
+	s := newTestSQLiteStorage(t)
+	ctx := context.Background()
+
+	require.NoError(t, s.SaveVulnerabilities(ctx, []model.Vulnerability{
+		testVulnerability("first", "high", model.SeverityHigh, 1),
+		testVulnerability("first", "high", model.SeverityHigh, 2),
+		testVulnerability("first", "low", model.SeverityLow, 1),
+		testVulnerability("first", "bom", model.SeverityTrace, 1),
+		testVulnerability("second", "critical", model.SeverityCritical, 1),
+	}))
+
+	tests := []struct {
+		name    string
+		scanIDs []string
+		want    []model.SeveritySummary
+	}{
+		{
+			name:    "no scans",
+			scanIDs: []string{},
+			want:    []model.SeveritySummary{},
+		},
+		{
+			name:    "multiple scans",
+			scanIDs: []string{"second", "first", "unknown"},
+			want: []model.SeveritySummary{
+				{
+					ScanID: "second",
+					SeverityCounters: map[model.Severity]int{
+						model.SeverityTrace: 0, model.SeverityInfo: 0, model.SeverityLow: 0,
+						model.SeverityMedium: 0, model.SeverityHigh: 0, model.SeverityCritical: 1,
+					},
+					TotalCounter: 1,
+				},
+				{
+					ScanID: "first",
+					SeverityCounters: map[model.Severity]int{
+						model.SeverityTrace: 1, model.SeverityInfo: 0, model.SeverityLow: 1,
+						model.SeverityMedium: 0, model.SeverityHigh: 2, model.SeverityCritical: 0,
+					},
+					TotalCounter:      3,
+					TotalBOMResources: 1,
+				},
+				{
+					ScanID: "unknown",
+					SeverityCounters: map[model.Severity]int{
+						model.SeverityTrace: 0, model.SeverityInfo: 0, model.SeverityLow: 0,
+						model.SeverityMedium: 0, model.SeverityHigh: 0, model.SeverityCritical: 0,
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.GetScanSummary(ctx, tt.scanIDs)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,241 @@
+// Package cache keeps the parsed files and the results by file of the queries reading one document at a time of
+// previous scans on disk, so scanning a repository again only parses and evaluates the files that changed
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+
+	"github.com/Checkmarx/kics/v2/internal/constants"
+	"github.com/Checkmarx/kics/v2/pkg/model"
+	"github.com/open-policy-agent/opa/util"
+	"github.com/rs/zerolog/log"
+)
+
+// parserVersion should be bumped whenever the parsers change the documents they produce,
+// so the documents cached by older versions are not used
+const parserVersion = "1"
+
+const (
+	filesDir   = "files"
+	resultsDir = "results"
+
+	// dirPermMode keeps the cache readable only by the user running the scans, the documents and results it holds
+	// can include the values of the scanned files, such as secrets
+	dirPermMode = 0700
+)
+
+// FileEntry is a parsed file kept on the cache, with the hashes of the other files its documents were built from.
+// The content of the files is left out of the entry and read again from the scanned files
+type FileEntry struct {
+	Files         []model.FileMetadata `json:"files"`
+	CountLines    int                  `json:"countLines"`
+	IgnoreLines   int                  `json:"ignoreLines"`
+	LinesResolved int                  `json:"linesResolved"`
+	Dependencies  map[string]string    `json:"dependencies,omitempty"`
+}
+
+// Stats counts the files and the query results found on the cache and the ones that were not
+type Stats struct {
+	FileHits     int
+	FileMisses   int
+	ResultHits   int
+	ResultMisses int
+}
+
+// Cache is an on-disk cache of parsed files and query results, all of its methods are safe for concurrent use
+type Cache struct {
+	path         string
+	options      string
+	hashes       sync.Map
+	fileHits     atomic.Int64
+	fileMisses   atomic.Int64
+	resultHits   atomic.Int64
+	resultMisses atomic.Int64
+}
+
+// New returns the cache kept in the directory, creating it when it does not exist. The options are the parser
+// options, such as the Terraform variables, mixed in the keys of the files so the ones parsed differently are kept apart
+func New(path string, options ...string) (*Cache, error) {
+	for _, dir := range []string{filesDir, resultsDir} {
+		if err := os.MkdirAll(filepath.Join(path, dir), dirPermMode); err != nil {
+			return nil, err
+		}
+	}
+
+	return &Cache{
+		path:    path,
+		options: Hash(append([]string{constants.Version, parserVersion}, options...)...),
+	}, nil
+}
+
+// Hash returns the hex encoded SHA-256 of the parts
+func Hash(parts ...string) string {
+	h := sha256.New()
+	for _, part := range parts {
+		// the length keeps ("ab", "c") and ("a", "bc") apart
+		_ = binary.Write(h, binary.BigEndian, uint64(len(part)))
+		h.Write([]byte(part))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// FileKey returns the key of the documents of the file parsed by the parser of the kind
+func (c *Cache) FileKey(kind model.FileKind, path string, content []byte) string {
+	return Hash(c.options, string(kind), path, string(content))
+}
+
+// ResultKey returns the key of the results of the query, identified by the hash of its code and libraries,
+// on the documents of a file, identified by their hash
+func (c *Cache) ResultKey(queryHash, documentsHash string) string {
+	return Hash(c.options, queryHash, documentsHash)
+}
+
+// GetFile returns the parsed file kept with the key, when the files it depends on did not change
+func (c *Cache) GetFile(key string) (*FileEntry, bool) {
+	var entry FileEntry
+	if !c.read(filesDir, key, func(content []byte) error { return json.Unmarshal(content, &entry) }) ||
+		!c.validDependencies(entry.Dependencies) {
+		c.fileMisses.Add(1)
+		return nil, false
+	}
+	c.fileHits.Add(1)
+	return &entry, true
+}
+
+// PutFile keeps the parsed file with the key, along with the hashes of the files it depends on
+func (c *Cache) PutFile(key string, entry *FileEntry, dependencies []string) {
+	entry.Dependencies = make(map[string]string, len(dependencies))
+	for _, dependency := range dependencies {
+		hash, err := c.fileHash(dependency)
+		if err != nil {
+			log.Debug().Msgf("Not caching entry %s, failed to read %s: %s", key, dependency, err)
+			return
+		}
+		entry.Dependencies[dependency] = hash
+	}
+	c.write(filesDir, key, entry)
+}
+
+// GetResults returns the query results kept with the key, decoded the same way OPA decodes them
+func (c *Cache) GetResults(key string) ([]interface{}, bool) {
+	var results []interface{}
+	if !c.read(resultsDir, key, func(content []byte) error { return util.UnmarshalJSON(content, &results) }) {
+		c.resultMisses.Add(1)
+		return nil, false
+	}
+	c.resultHits.Add(1)
+	return results, true
+}
+
+// PutResults keeps the query results with the key
+func (c *Cache) PutResults(key string, results []interface{}) {
+	c.write(resultsDir, key, results)
+}
+
+// Stats returns how many files and query results were found on the cache and how many were not
+func (c *Cache) Stats() Stats {
+	return Stats{
+		FileHits:     int(c.fileHits.Load()),
+		FileMisses:   int(c.fileMisses.Load()),
+		ResultHits:   int(c.resultHits.Load()),
+		ResultMisses: int(c.resultMisses.Load()),
+	}
+}
+
+func (c *Cache) validDependencies(dependencies map[string]string) bool {
+	for path, expected := range dependencies {
+		if hash, err := c.fileHash(path); err != nil || hash != expected {
+			return false
+		}
+	}
+	return true
+}
+
+// fileHash returns the hash of the content of the file, or of the names of the entries of a directory, so added and
+// removed files are noticed. Files that do not exist have an empty hash and each file is read once per scan
+func (c *Cache) fileHash(path string) (string, error) {
+	if hash, ok := c.hashes.Load(path); ok {
+		return hash.(string), nil
+	}
+
+	var hash string
+	info, err := os.Stat(path)
+	switch {
+	case os.IsNotExist(err):
+	case err != nil:
+		return "", err
+	case info.IsDir():
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return "", err
+		}
+		names := make([]string, 0, len(entries))
+		for _, entry := range entries {
+			names = append(names, entry.Name())
+		}
+		hash = Hash(names...)
+	default:
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return "", err
+		}
+		hash = Hash(string(content))
+	}
+
+	c.hashes.Store(path, hash)
+	return hash, nil
+}
+
+func (c *Cache) entryPath(dir, key string) string {
+	return filepath.Join(c.path, dir, key[:2], key+".json")
+}
+
+func (c *Cache) read(dir, key string, decode func(content []byte) error) bool {
+	content, err := os.ReadFile(c.entryPath(dir, key))
+	if err != nil {
+		return false
+	}
+	if err := decode(content); err != nil {
+		log.Debug().Msgf("Ignoring invalid cache entry %s: %s", key, err)
+		return false
+	}
+	return true
+}
+
+// write keeps the value on a temporary file renamed to the entry, so a concurrent scan never reads half an entry
+func (c *Cache) write(dir, key string, value interface{}) {
+	content, err := json.Marshal(value)
+	if err != nil {
+		log.Debug().Msgf("Failed to encode cache entry %s: %s", key, err)
+		return
+	}
+
+	path := c.entryPath(dir, key)
+	if err := os.MkdirAll(filepath.Dir(path), dirPermMode); err != nil {
+		log.Debug().Msgf("Failed to write cache entry %s: %s", key, err)
+		return
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), key+"-*.tmp")
+	if err != nil {
+		log.Debug().Msgf("Failed to write cache entry %s: %s", key, err)
+		return
+	}
+	_, err = tmp.Write(content)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		log.Debug().Msgf("Failed to write cache entry %s: %s", key, err)
+		_ = os.Remove(tmp.Name())
+	}
+}
@@ -0,0 +1,108 @@
+package cache
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Checkmarx/kics/v2/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_FileKey(t *testing.T) {
+	c, err := New(t.TempDir())
+	require.NoError(t, err)
+	withVars, err := New(t.TempDir(), "terraform.tfvars")
+	require.NoError(t, err)
+
+	key := c.FileKey(model.KindTerraform, "main.tf", []byte("resource"))
+	require.Equal(t, key, c.FileKey(model.KindTerraform, "main.tf", []byte("resource")))
+	require.NotEqual(t, key, c.FileKey(model.KindTerraform, "main.tf", []byte("resources")))
+	require.NotEqual(t, key, c.FileKey(model.KindTerraform, "other.tf", []byte("resource")))
+	require.NotEqual(t, key, c.FileKey(model.KindJSON, "main.tf", []byte("resource")))
+	require.NotEqual(t, key, withVars.FileKey(model.KindTerraform, "main.tf", []byte("resource")))
+}
+
+func Test_File(t *testing.T) {
+	dir := t.TempDir()
+	variables := filepath.Join(dir, "variables.tf")
+	require.NoError(t, os.WriteFile(variables, []byte(`variable "name" {}`), os.ModePerm))
+
+	c, err := New(filepath.Join(dir, "cache"))
+	require.NoError(t, err)
+
+	key := c.FileKey(model.KindTerraform, "main.tf", []byte("resource"))
+	_, ok := c.GetFile(key)
+	require.False(t, ok)
+
+	c.PutFile(key, &FileEntry{
+		Files: []model.FileMetadata{
+			{
+				ID:       "1",
+				FilePath: "main.tf",
+				Kind:     model.KindTerraform,
+				Document: model.Document{"resource": map[string]interface{}{"count": float64(2)}},
+			},
+		},
+		CountLines:    10,
+		IgnoreLines:   1,
+		LinesResolved: 3,
+	}, []string{dir, variables})
+
+	entry, ok := c.GetFile(key)
+	require.True(t, ok)
+	require.Len(t, entry.Files, 1)
+	require.Equal(t, model.Document{"resource": map[string]interface{}{"count": float64(2)}}, entry.Files[0].Document)
+	require.Equal(t, 10, entry.CountLines)
+	require.Equal(t, 1, entry.IgnoreLines)
+	require.Equal(t, 3, entry.LinesResolved)
+	require.Equal(t, Stats{FileHits: 1, FileMisses: 1}, c.Stats())
+
+	// a new scan notices the changes of the files the documents depend on
+	require.NoError(t, os.WriteFile(variables, []byte(`variable "other" {}`), os.ModePerm))
+	rescan, err := New(filepath.Join(dir, "cache"))
+	require.NoError(t, err)
+	_, ok = rescan.GetFile(key)
+	require.False(t, ok)
+}
+
+func Test_FileWithAddedDependency(t *testing.T) {
+	dir := t.TempDir()
+
+	c, err := New(filepath.Join(dir, "cache"))
+	require.NoError(t, err)
+
+	key := c.FileKey(model.KindTerraform, "main.tf", []byte("resource"))
+	c.PutFile(key, &FileEntry{}, []string{dir, filepath.Join(dir, "terraform.tfvars")})
+	_, ok := c.GetFile(key)
+	require.True(t, ok)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "terraform.tfvars"), []byte(`name = "kics"`), os.ModePerm))
+	rescan, err := New(filepath.Join(dir, "cache"))
+	require.NoError(t, err)
+	_, ok = rescan.GetFile(key)
+	require.False(t, ok)
+}
+
+func Test_Results(t *testing.T) {
+	c, err := New(t.TempDir())
+	require.NoError(t, err)
+
+	key := c.ResultKey("query", "documents")
+	require.NotEqual(t, key, c.ResultKey("query", "other documents"))
+
+	_, ok := c.GetResults(key)
+	require.False(t, ok)
+
+	c.PutResults(key, []interface{}{
+		map[string]interface{}{"documentId": 0, "searchKey": "resource"},
+	})
+
+	got, ok := c.GetResults(key)
+	require.True(t, ok)
+	require.Equal(t, []interface{}{
+		map[string]interface{}{"documentId": json.Number("0"), "searchKey": "resource"},
+	}, got)
+	require.Equal(t, Stats{ResultHits: 1, ResultMisses: 1}, c.Stats())
+}
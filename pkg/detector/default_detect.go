@@ -1,6 +1,7 @@
 package detector
 
 import (
+	"regexp"
 	"strconv"
 	"strings"
 
@@ -12,6 +13,9 @@ const (
 	undetectedVulnerabilityLine = -1
 )
 
+// terraformInstanceRegex matches the index of expanded Terraform resource instances (e.g. aws_s3_bucket[b[0]])
+var terraformInstanceRegex = regexp.MustCompile(`\[([^\[\]]+)\[(?:\d+|"[^"]*")\]\]`)
+
 type defaultDetectLine struct {
 }
 
@@ -26,6 +30,11 @@ func (d defaultDetectLine) DetectLine(file *model.FileMetadata, searchKey string
 		ResolvedFiles:   d.prepareResolvedFiles(file.ResolvedFiles),
 	}
 
+	if file.Kind == model.KindTerraform {
+		// instances of the same resource are declared in the same block
+		searchKey = terraformInstanceRegex.ReplaceAllString(searchKey, "[$1]")
+	}
+
 	var extractedString [][]string
 	extractedString = GetBracketValues(searchKey, extractedString, "")
 	sanitizedSubstring := searchKey
@@ -72,6 +72,40 @@ func Test_detectLine(t *testing.T) { //nolint
 				LineWithVulnerability: "",
 			},
 		},
+		{
+			name: "detect_line_expanded_instance",
+			args: args{
+				file: &model.FileMetadata{
+					ScanID:            "scanID",
+					ID:                "Test",
+					Kind:              model.KindTerraform,
+					OriginalData:      OriginalData,
+					LinesOriginalData: utils.SplitLines(OriginalData),
+				},
+				searchKey: `aws_s3_bucket[b["logs"]].acl`,
+			},
+			fields: fields{
+				outputLines: 3,
+			},
+			want: model.VulnerabilityLines{
+				Line: 3,
+				VulnLines: &[]model.CodeLine{
+					{
+						Position: 2,
+						Line:     `	bucket = "my-tf-test-bucket"`,
+					},
+					{
+						Position: 3,
+						Line:     `	acl    = "authenticated-read"`,
+					},
+					{
+						Position: 4,
+						Line:     "",
+					},
+				},
+				LineWithVulnerability: "",
+			},
+		},
 		{
 			name: "detect_line_with_curly_brackets",
 			args: args{
@@ -0,0 +1,154 @@
+package diff
+
+import (
+	"sort"
+
+	"github.com/Checkmarx/kics/v2/pkg/model"
+)
+
+// Parameters represents all available diff parameters
+type Parameters struct {
+	BaseResults   string
+	HeadResults   string
+	OutputName    string
+	OutputPath    string
+	ReportFormats []string
+}
+
+// Finding is a result of a report with the information of its query
+type Finding struct {
+	QueryName string         `json:"query_name"`
+	QueryID   string         `json:"query_id"`
+	QueryURI  string         `json:"query_url"`
+	Severity  model.Severity `json:"severity"`
+	Platform  string         `json:"platform"`
+	Category  string         `json:"category"`
+	model.VulnerableFile
+}
+
+// SeverityDelta contains the number of results of a severity on both reports
+type SeverityDelta struct {
+	Base  int `json:"base"`
+	Head  int `json:"head"`
+	Delta int `json:"delta"`
+}
+
+// Report is the comparison of two KICS reports, findings are matched by similarity ID
+type Report struct {
+	Base             string                           `json:"base"`
+	Head             string                           `json:"head"`
+	SeverityCounters map[model.Severity]SeverityDelta `json:"severity_counters"`
+	Total            SeverityDelta                    `json:"total"`
+	Added            []Finding                        `json:"added"`
+	Removed          []Finding                        `json:"removed"`
+	Unchanged        []Finding                        `json:"unchanged"`
+
+	summary model.Summary
+}
+
+// Severities lists the severities of the report counters, from the most to the least severe
+var Severities = []model.Severity{
+	model.SeverityCritical,
+	model.SeverityHigh,
+	model.SeverityMedium,
+	model.SeverityLow,
+	model.SeverityInfo,
+}
+
+// Compare compares the head report with the base report, basePath and headPath identify the reports
+func Compare(base, head *model.Summary, basePath, headPath string) *Report {
+	// head is compared as a scan with the base report as baseline, without changing the given summary
+	summary := *head
+	summary.Queries = make(model.QueryResultSlice, len(head.Queries))
+	for i := range head.Queries {
+		summary.Queries[i] = head.Queries[i]
+		summary.Queries[i].Files = append([]model.VulnerableFile{}, head.Queries[i].Files...)
+	}
+	model.ApplyBaseline(&summary, base, basePath)
+
+	report := &Report{
+		Base:             basePath,
+		Head:             headPath,
+		SeverityCounters: make(map[model.Severity]SeverityDelta, len(Severities)),
+		Added:            make([]Finding, 0),
+		Removed:          make([]Finding, 0),
+		Unchanged:        make([]Finding, 0),
+	}
+	for _, severity := range Severities {
+		report.SeverityCounters[severity] = newSeverityDelta(
+			base.SeveritySummary.SeverityCounters[severity],
+			head.SeveritySummary.SeverityCounters[severity])
+	}
+	report.Total = newSeverityDelta(base.SeveritySummary.TotalCounter, head.SeveritySummary.TotalCounter)
+
+	for i := range summary.Queries {
+		for j := range summary.Queries[i].Files {
+			finding := newFinding(&summary.Queries[i], &summary.Queries[i].Files[j])
+			if finding.BaselineState == model.BaselineStateNew {
+				report.Added = append(report.Added, finding)
+			} else {
+				report.Unchanged = append(report.Unchanged, finding)
+			}
+		}
+	}
+	for i := range summary.Baseline.Fixed {
+		for j := range summary.Baseline.Fixed[i].Files {
+			report.Removed = append(report.Removed, newFinding(&summary.Baseline.Fixed[i], &summary.Baseline.Fixed[i].Files[j]))
+		}
+	}
+	sortFindings(report.Added)
+	sortFindings(report.Removed)
+	sortFindings(report.Unchanged)
+
+	// the summary keeps every finding of both reports with its state, it is used to build the SARIF report
+	summary.Queries = append(summary.Queries, summary.Baseline.Fixed...)
+	summary.Baseline = nil
+	report.summary = summary
+
+	return report
+}
+
+func newSeverityDelta(base, head int) SeverityDelta {
+	return SeverityDelta{
+		Base:  base,
+		Head:  head,
+		Delta: head - base,
+	}
+}
+
+func newFinding(query *model.QueryResult, file *model.VulnerableFile) Finding {
+	return Finding{
+		QueryName:      query.QueryName,
+		QueryID:        query.QueryID,
+		QueryURI:       query.QueryURI,
+		Severity:       query.Severity,
+		Platform:       query.Platform,
+		Category:       query.Category,
+		VulnerableFile: *file,
+	}
+}
+
+var severityOrder = map[model.Severity]int{
+	model.SeverityCritical: 0,
+	model.SeverityHigh:     1,
+	model.SeverityMedium:   2,
+	model.SeverityLow:      3,
+	model.SeverityInfo:     4,
+	model.SeverityTrace:    5,
+}
+
+// sortFindings sorts the findings by severity, query name, file name and line
+func sortFindings(findings []Finding) {
+	sort.SliceStable(findings, func(i, j int) bool {
+		if severityOrder[findings[i].Severity] != severityOrder[findings[j].Severity] {
+			return severityOrder[findings[i].Severity] < severityOrder[findings[j].Severity]
+		}
+		if findings[i].QueryName != findings[j].QueryName {
+			return findings[i].QueryName < findings[j].QueryName
+		}
+		if findings[i].FileName != findings[j].FileName {
+			return findings[i].FileName < findings[j].FileName
+		}
+		return findings[i].Line < findings[j].Line
+	})
+}
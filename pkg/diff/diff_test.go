@@ -0,0 +1,138 @@
+package diff
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/Checkmarx/kics/v2/pkg/model"
+)
+
+func testSummary(total int, counters map[model.Severity]int, queries ...model.QueryResult) *model.Summary {
+	return &model.Summary{
+		SeveritySummary: model.SeveritySummary{
+			SeverityCounters: counters,
+			TotalCounter:     total,
+		},
+		Queries: queries,
+	}
+}
+
+var (
+	baseSummary = testSummary(3, map[model.Severity]int{model.SeverityHigh: 2, model.SeverityLow: 1},
+		model.QueryResult{
+			QueryName: "High Query",
+			QueryID:   "high",
+			Severity:  model.SeverityHigh,
+			Files: []model.VulnerableFile{
+				{FileName: "main.tf", Line: 1, SimilarityID: "unchanged"},
+				{FileName: "main.tf", Line: 10, SimilarityID: "removed"},
+			},
+		},
+		model.QueryResult{
+			QueryName: "Low Query",
+			QueryID:   "low",
+			Severity:  model.SeverityLow,
+			Files: []model.VulnerableFile{
+				{FileName: "main.tf", Line: 5, SimilarityID: "old_id"},
+			},
+		},
+	)
+	headSummary = testSummary(3, map[model.Severity]int{model.SeverityHigh: 1, model.SeverityLow: 1, model.SeverityCritical: 1},
+		model.QueryResult{
+			QueryName: "High Query",
+			QueryID:   "high",
+			Severity:  model.SeverityHigh,
+			Files: []model.VulnerableFile{
+				{FileName: "main.tf", Line: 1, SimilarityID: "unchanged"},
+			},
+		},
+		model.QueryResult{
+			QueryName: "Low Query",
+			QueryID:   "low",
+			Severity:  model.SeverityLow,
+			Files: []model.VulnerableFile{
+				{FileName: "main.tf", Line: 6, SimilarityID: "new_id", OldSimilarityID: "old_id"},
+			},
+		},
+		model.QueryResult{
+			QueryName: "Critical | Query",
+			QueryID:   "critical",
+			QueryURI:  "https://docs.kics.io",
+			Severity:  model.SeverityCritical,
+			Files: []model.VulnerableFile{
+				{FileName: "other.tf", Line: 2, SimilarityID: "added"},
+			},
+		},
+	)
+)
+
+func similarityIDs(findings []Finding) []string {
+	ids := make([]string, 0, len(findings))
+	for i := range findings {
+		ids = append(ids, findings[i].SimilarityID)
+	}
+	return ids
+}
+
+func TestCompare(t *testing.T) {
+	report := Compare(baseSummary, headSummary, "base.json", "head.json")
+
+	require.Equal(t, "base.json", report.Base)
+	require.Equal(t, "head.json", report.Head)
+	require.Equal(t, []string{"added"}, similarityIDs(report.Added))
+	require.Equal(t, []string{"removed"}, similarityIDs(report.Removed))
+	require.Equal(t, []string{"unchanged", "new_id"}, similarityIDs(report.Unchanged))
+	require.Equal(t, "Critical | Query", report.Added[0].QueryName)
+	require.Equal(t, model.Severity(model.SeverityHigh), report.Removed[0].Severity)
+
+	require.Equal(t, SeverityDelta{Base: 0, Head: 1, Delta: 1}, report.SeverityCounters[model.SeverityCritical])
+	require.Equal(t, SeverityDelta{Base: 2, Head: 1, Delta: -1}, report.SeverityCounters[model.SeverityHigh])
+	require.Equal(t, SeverityDelta{Base: 1, Head: 1, Delta: 0}, report.SeverityCounters[model.SeverityLow])
+	require.Equal(t, SeverityDelta{Base: 3, Head: 3, Delta: 0}, report.Total)
+
+	// the compared summaries are not modified
+	require.Empty(t, headSummary.Queries[0].Files[0].BaselineState)
+	require.Nil(t, headSummary.Baseline)
+}
+
+func TestReport_Markdown(t *testing.T) {
+	markdown := Compare(baseSummary, headSummary, "base.json", "head.json").Markdown()
+
+	require.Contains(t, markdown, "Comparing `head.json` with `base.json`: **1 added**, **1 removed**, 2 unchanged.")
+	require.Contains(t, markdown, "| CRITICAL | 0 | 1 | +1 |")
+	require.Contains(t, markdown, "| HIGH | 2 | 1 | -1 |")
+	require.Contains(t, markdown, "| CRITICAL | [Critical \\| Query](https://docs.kics.io) | `other.tf` | 2 |")
+	require.Contains(t, markdown, "<summary>Removed findings (1)</summary>")
+	require.NotContains(t, markdown, "unchanged findings")
+}
+
+func TestExport(t *testing.T) {
+	report := Compare(baseSummary, headSummary, "base.json", "head.json")
+	dir := t.TempDir()
+
+	require.NoError(t, Export(report, dir, "diff", ReportFormats))
+	require.FileExists(t, filepath.Join(dir, "diff.json"))
+	require.FileExists(t, filepath.Join(dir, "diff.md"))
+
+	content, err := os.ReadFile(filepath.Join(dir, "diff.sarif"))
+	require.NoError(t, err)
+	var sarif struct {
+		Runs []struct {
+			Results []struct {
+				BaselineState string `json:"baselineState"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	require.NoError(t, json.Unmarshal(content, &sarif))
+	states := make([]string, 0)
+	for _, result := range sarif.Runs[0].Results {
+		states = append(states, result.BaselineState)
+	}
+	require.ElementsMatch(t, []string{"new", "unchanged", "unchanged", "absent"}, states)
+
+	require.Error(t, Export(report, dir, "diff", []string{"pdf"}))
+}
@@ -0,0 +1,73 @@
+package diff
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const markdownExtension = ".md"
+
+// Markdown renders the report as markdown, to be posted as a pull request comment
+func (r *Report) Markdown() string {
+	var sb strings.Builder
+
+	sb.WriteString("## KICS Diff\n\n")
+	fmt.Fprintf(&sb, "Comparing `%s` with `%s`: **%d added**, **%d removed**, %d unchanged.\n\n",
+		r.Head, r.Base, len(r.Added), len(r.Removed), len(r.Unchanged))
+
+	sb.WriteString("| Severity | Base | Head | Delta |\n")
+	sb.WriteString("|:---|---:|---:|---:|\n")
+	for _, severity := range Severities {
+		counter := r.SeverityCounters[severity]
+		fmt.Fprintf(&sb, "| %s | %d | %d | %s |\n", severity, counter.Base, counter.Head, FormatDelta(counter.Delta))
+	}
+	fmt.Fprintf(&sb, "| **TOTAL** | %d | %d | %s |\n\n", r.Total.Base, r.Total.Head, FormatDelta(r.Total.Delta))
+
+	writeMarkdownFindings(&sb, "Added", r.Added, true)
+	writeMarkdownFindings(&sb, "Removed", r.Removed, false)
+
+	return sb.String()
+}
+
+func writeMarkdownFindings(sb *strings.Builder, title string, findings []Finding, open bool) {
+	if len(findings) == 0 {
+		return
+	}
+	if open {
+		fmt.Fprintf(sb, "<details open>\n<summary>%s findings (%d)</summary>\n\n", title, len(findings))
+	} else {
+		fmt.Fprintf(sb, "<details>\n<summary>%s findings (%d)</summary>\n\n", title, len(findings))
+	}
+	sb.WriteString("| Severity | Query | File | Line |\n")
+	sb.WriteString("|:---|:---|:---|---:|\n")
+	for i := range findings {
+		query := escapeMarkdownCell(findings[i].QueryName)
+		if findings[i].QueryURI != "" {
+			query = fmt.Sprintf("[%s](%s)", query, findings[i].QueryURI)
+		}
+		fmt.Fprintf(sb, "| %s | %s | `%s` | %d |\n",
+			findings[i].Severity, query, escapeMarkdownCell(filepath.ToSlash(findings[i].FileName)), findings[i].Line)
+	}
+	sb.WriteString("\n</details>\n\n")
+}
+
+func escapeMarkdownCell(content string) string {
+	return strings.ReplaceAll(content, "|", "\\|")
+}
+
+// FormatDelta formats a counter delta with its sign
+func FormatDelta(delta int) string {
+	if delta > 0 {
+		return fmt.Sprintf("+%d", delta)
+	}
+	return fmt.Sprintf("%d", delta)
+}
+
+func exportMarkdownReport(path, filename string, r *Report) error {
+	if !strings.HasSuffix(filename, markdownExtension) {
+		filename += markdownExtension
+	}
+	return os.WriteFile(filepath.Join(path, filename), []byte(r.Markdown()), os.ModePerm)
+}
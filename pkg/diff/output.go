@@ -0,0 +1,41 @@
+package diff
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/Checkmarx/kics/v2/pkg/report"
+	"github.com/rs/zerolog/log"
+)
+
+// ReportFormats lists the formats in which the diff can be exported
+var ReportFormats = []string{"json", "markdown", "sarif"}
+
+// Export writes the report on outputPath in each one of the formats
+func Export(r *Report, outputPath, outputName string, formats []string) error {
+	if outputPath == "" {
+		return nil
+	}
+	if err := os.MkdirAll(outputPath, os.ModePerm); err != nil {
+		return err
+	}
+	for _, format := range formats {
+		log.Debug().Msgf("Exporting diff report as %s", format)
+		var err error
+		switch strings.ToLower(format) {
+		case "json":
+			err = report.ExportJSONReport(outputPath, outputName, r)
+		case "sarif":
+			err = report.PrintSarifReport(outputPath, outputName, &r.summary)
+		case "markdown":
+			err = exportMarkdownReport(outputPath, outputName, r)
+		default:
+			err = fmt.Errorf("unsupported diff report format: %s", format)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
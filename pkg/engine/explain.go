@@ -0,0 +1,126 @@
+package engine
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/Checkmarx/kics/v2/pkg/model"
+	"github.com/open-policy-agent/opa/ast"
+	"github.com/open-policy-agent/opa/rego"
+	"github.com/open-policy-agent/opa/topdown"
+	"github.com/pkg/errors"
+)
+
+// Explanation is the evaluation of a query against one of its inputs, with the input the query saw, the trace of
+// the rules and expressions of the query and the number of results it returned
+type Explanation struct {
+	Files   []string
+	Input   interface{}
+	Trace   string
+	Results int
+}
+
+// Explain evaluates the query with the given id against the documents of the files with OPA tracing enabled,
+// building its input the same way a scan does, so once for each file when the query is evaluated document by
+// document. The trace only keeps the events of the query itself, leaving out the ones of the libraries it calls
+func (c *Inspector) Explain(ctx context.Context, queryID string, files model.FileMetadatas) (
+	*model.QueryMetadata, []Explanation, error) {
+	var query *model.QueryMetadata
+	for i := range c.QueryLoader.QueriesMetadata {
+		if id, ok := c.QueryLoader.QueriesMetadata[i].Metadata["id"].(string); ok && id == queryID {
+			query = &c.QueryLoader.QueriesMetadata[i]
+			break
+		}
+	}
+	if query == nil {
+		return nil, nil, fmt.Errorf("query %s not found", queryID)
+	}
+
+	opaQuery, err := c.QueryLoader.LoadQuery(ctx, query)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to load query %s", queryID)
+	}
+	prepared := &PreparedQuery{OpaQuery: *opaQuery, Metadata: *query}
+
+	partition := partitionQueries([]model.QueryMetadata{*query})[0]
+	queryFiles := partition.filterFiles(files)
+	if len(queryFiles) == 0 {
+		return query, []Explanation{}, nil
+	}
+
+	if !query.SingleDocument {
+		input, err := buildPayload(queryFiles)
+		if err != nil {
+			return nil, nil, err
+		}
+		explanation, err := c.explain(ctx, prepared, input)
+		if err != nil {
+			return nil, nil, err
+		}
+		explanation.Files = filePaths(queryFiles)
+		return query, []Explanation{explanation}, nil
+	}
+
+	payloads, err := buildFilePayloads(queryFiles, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	explanations := make([]Explanation, 0, len(payloads))
+	for i := range payloads {
+		explanation, err := c.explain(ctx, prepared, payloads[i].value)
+		if err != nil {
+			return nil, nil, err
+		}
+		explanation.Files = []string{payloads[i].path}
+		explanations = append(explanations, explanation)
+	}
+	return query, explanations, nil
+}
+
+// explain evaluates the query against the input with a tracer
+func (c *Inspector) explain(ctx context.Context, query *PreparedQuery, input ast.Value) (Explanation, error) {
+	timeoutCtx, cancel := context.WithTimeout(ctx, c.queryExecTimeout)
+	defer cancel()
+
+	tracer := topdown.NewBufferTracer()
+	results, err := query.OpaQuery.Eval(timeoutCtx, rego.EvalParsedInput(input), rego.EvalQueryTracer(tracer))
+	if err != nil {
+		return Explanation{}, errors.Wrap(err, "failed to evaluate query")
+	}
+
+	var trace bytes.Buffer
+	topdown.PrettyTraceWithOpts(&trace, queryEvents(*tracer, query.Metadata.Query),
+		topdown.PrettyTraceOptions{Locations: true, ExprVariables: true})
+
+	document, err := ast.JSON(input)
+	if err != nil {
+		return Explanation{}, err
+	}
+
+	items, _ := resultItems(results)
+	return Explanation{Input: document, Trace: trace.String(), Results: len(items)}, nil
+}
+
+// queryEvents returns the events of the trace on the module of the query and on the query that evaluates it
+func queryEvents(events []*topdown.Event, module string) []*topdown.Event {
+	filtered := make([]*topdown.Event, 0, len(events))
+	for _, event := range events {
+		if event.Location == nil || event.Location.File == "" || event.Location.File == module {
+			filtered = append(filtered, event)
+		}
+	}
+	return filtered
+}
+
+func filePaths(files model.FileMetadatas) []string {
+	paths := make([]string, 0, len(files))
+	seen := make(map[string]bool, len(files))
+	for i := range files {
+		if !seen[files[i].FilePath] {
+			seen[files[i].FilePath] = true
+			paths = append(paths, files[i].FilePath)
+		}
+	}
+	return paths
+}
@@ -0,0 +1,82 @@
+package engine
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Checkmarx/kics/v2/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInspector_Explain(t *testing.T) {
+	query := model.QueryMetadata{
+		Query:     "from_alpine",
+		Platform:  "dockerfile",
+		InputData: "{}",
+		Metadata:  map[string]interface{}{"id": "from-alpine"},
+		Content: `package Cx
+
+CxPolicy[result] {
+	document := input.document[i]
+	document.command.alpine
+	result := {"documentId": document.id}
+}`,
+	}
+
+	files := model.FileMetadatas{
+		{ID: "1", FilePath: "Dockerfile", Kind: model.KindDOCKER, Document: model.Document{"command": map[string]interface{}{}}},
+		{ID: "2", FilePath: "main.tf", Kind: model.KindTerraform, Document: model.Document{"resource": map[string]interface{}{}}},
+	}
+	other := model.FileMetadata{
+		ID: "3", FilePath: "other.dockerfile", Kind: model.KindDOCKER, Document: model.Document{"command": map[string]interface{}{}},
+	}
+
+	tests := []struct {
+		name           string
+		singleDocument bool
+		files          model.FileMetadatas
+		want           [][]string
+	}{
+		{
+			name:  "evaluate the documents of the kinds of the query together",
+			files: append(files, other),
+			want:  [][]string{{"Dockerfile", "other.dockerfile"}},
+		},
+		{
+			name:           "evaluate each file when the query is evaluated document by document",
+			singleDocument: true,
+			files:          append(files, other),
+			want:           [][]string{{"Dockerfile"}, {"other.dockerfile"}},
+		},
+		{
+			name:  "skip the evaluation without documents of the kinds of the query",
+			files: files[1:],
+			want:  [][]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := query
+			q.SingleDocument = tt.singleDocument
+			c := newPayloadTestInspector([]model.QueryMetadata{q})
+
+			got, explanations, err := c.Explain(context.Background(), "from-alpine", tt.files)
+			require.NoError(t, err)
+			require.Equal(t, "from_alpine", got.Query)
+
+			gotFiles := make([][]string, 0, len(explanations))
+			for i := range explanations {
+				gotFiles = append(gotFiles, explanations[i].Files)
+				require.Zero(t, explanations[i].Results)
+				require.Contains(t, explanations[i].Trace, "Fail document.command.alpine")
+				require.NotContains(t, explanations[i].Trace, "data.generic.common")
+				require.Contains(t, explanations[i].Input, "document")
+			}
+			require.Equal(t, tt.want, gotFiles)
+		})
+	}
+
+	_, _, err := newPayloadTestInspector([]model.QueryMetadata{query}).Explain(context.Background(), "unknown", files)
+	require.EqualError(t, err, "query unknown not found")
+}
@@ -0,0 +1,265 @@
+package engine
+
+import (
+	"strings"
+
+	"github.com/Checkmarx/kics/v2/pkg/model"
+	"github.com/open-policy-agent/opa/ast"
+	"github.com/rs/zerolog/log"
+)
+
+const policyRule = "CxPolicy"
+
+// fileLocal returns true when the results of the query on the documents of a file depend only on those documents,
+// so they can be kept on the cache by file: every CxPolicy rule reads a single document, through input.document[i],
+// and neither the rules it calls nor the other rules of the query read the input
+func (c *Inspector) fileLocal(query *model.QueryMetadata) bool {
+	if local, ok := c.fileLocalQueries.Load(query.Query); ok {
+		return local.(bool)
+	}
+
+	local := false
+	libraries, err := c.getLibraryInputRules(query.Platform)
+	if err == nil {
+		var module *ast.Module
+		if module, err = ast.ParseModule(query.Query, query.Content); err == nil {
+			local = isFileLocal(module, inputRules([]*ast.Module{module}, libraries))
+		}
+	}
+	if err != nil {
+		log.Debug().Msgf("Failed to parse query %s, its results are not cached by file: %s", query.Query, err)
+	}
+
+	c.fileLocalQueries.Store(query.Query, local)
+	return local
+}
+
+// hasFileLocalQueries returns true when a query of the partition reads one document at a time
+func (c *Inspector) hasFileLocalQueries(partition *queryPartition, queries []model.QueryMetadata) bool {
+	for _, i := range partition.queries {
+		if !queries[i].SingleDocument && c.fileLocal(&queries[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+// getLibraryInputRules returns the paths of the rules of the common and platform libraries reading the input
+func (c *Inspector) getLibraryInputRules(platform string) (map[string]bool, error) {
+	if rules, ok := c.libraryInputRules.Load(platform); ok {
+		return rules.(map[string]bool), nil
+	}
+
+	modules := make([]*ast.Module, 0, 2)
+	for name, code := range map[string]string{
+		"Common":  c.QueryLoader.commonLibrary.LibraryCode,
+		"Generic": c.QueryLoader.platformLibraries[platform].LibraryCode,
+	} {
+		if code == "" {
+			continue
+		}
+		module, err := ast.ParseModule(name, code)
+		if err != nil {
+			return nil, err
+		}
+		modules = append(modules, module)
+	}
+
+	rules := inputRules(modules, nil)
+	c.libraryInputRules.Store(platform, rules)
+	return rules, nil
+}
+
+// isFileLocal checks the rules of the query module, given the paths of the rules reading the input
+func isFileLocal(module *ast.Module, readsInput map[string]bool) bool {
+	for _, rule := range module.Rules {
+		name := ruleName(rule)
+		if name != policyRule {
+			if readsInput[rulePath(module, name)] {
+				return false
+			}
+			continue
+		}
+
+		document := ""
+		local := true
+		ast.WalkRefs(rule, func(ref ast.Ref) bool {
+			path, input := resolveRef(module, ref)
+			switch {
+			case input:
+				index, ok := documentIndex(ref)
+				if !ok || (document != "" && document != index) {
+					local = false
+				}
+				document = index
+			case dependsOn(path, readsInput):
+				local = false
+			}
+			return !local
+		})
+		if !local || document == "" || !readsInputOutsideComprehensions(rule) {
+			return false
+		}
+	}
+	return true
+}
+
+// readsInputOutsideComprehensions returns true when the rule reads the input out of the comprehensions, which bind
+// the variable indexing input.document for the comprehensions reading it too
+func readsInputOutsideComprehensions(rule *ast.Rule) bool {
+	found := false
+	ast.NewGenericVisitor(func(x interface{}) bool {
+		switch value := x.(type) {
+		case *ast.ArrayComprehension, *ast.SetComprehension, *ast.ObjectComprehension:
+			return true
+		case ast.Ref:
+			if value[0].Equal(ast.InputRootDocument) {
+				found = true
+			}
+		}
+		return found
+	}).Walk(rule.Body)
+	return found
+}
+
+// documentIndex returns the variable indexing input.document in the reference, false when the reference reads
+// anything else of the input. Each wildcard is a different variable, so two of them index two documents
+func documentIndex(ref ast.Ref) (string, bool) {
+	if len(ref) < 3 || !ref[1].Equal(ast.StringTerm("document")) {
+		return "", false
+	}
+	index, ok := ref[2].Value.(ast.Var)
+	if !ok {
+		return "", false
+	}
+	return string(index), true
+}
+
+// inputRules returns the paths of the rules of the modules reading the input, directly or through other rules,
+// along with the rules of the other modules given in readsInputBefore
+func inputRules(modules []*ast.Module, readsInputBefore map[string]bool) map[string]bool {
+	readsInput := make(map[string]bool, len(readsInputBefore))
+	for path := range readsInputBefore {
+		readsInput[path] = true
+	}
+	dependencies := make(map[string][]string)
+	for _, module := range modules {
+		for _, rule := range module.Rules {
+			path := rulePath(module, ruleName(rule))
+			ast.WalkRefs(rule, func(ref ast.Ref) bool {
+				refPath, input := resolveRef(module, ref)
+				if input {
+					readsInput[path] = true
+				} else if refPath != "" {
+					dependencies[path] = append(dependencies[path], refPath)
+				}
+				return false
+			})
+		}
+	}
+
+	for changed := true; changed; {
+		changed = false
+		for path, refPaths := range dependencies {
+			if readsInput[path] {
+				continue
+			}
+			for _, refPath := range refPaths {
+				if dependsOn(refPath, readsInput) {
+					readsInput[path] = true
+					changed = true
+					break
+				}
+			}
+		}
+	}
+	return readsInput
+}
+
+// dependsOn returns true when the data path is, contains or is inside one of the rules reading the input
+func dependsOn(path string, readsInput map[string]bool) bool {
+	if path == "" {
+		return false
+	}
+	for rule := range readsInput {
+		if path == rule || strings.HasPrefix(path, rule+".") || strings.HasPrefix(rule, path+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveRef returns the data path of the ground prefix of the reference, resolving the imports and the rules of the
+// module, or true when the reference reads the input. The references to local variables have no path
+func resolveRef(module *ast.Module, ref ast.Ref) (path string, input bool) {
+	head, ok := ref[0].Value.(ast.Var)
+	if !ok {
+		return "", false
+	}
+
+	var full ast.Ref
+	switch {
+	case head.Equal(ast.InputRootDocument.Value):
+		return "", true
+	case head.Equal(ast.DefaultRootDocument.Value):
+		full = ref
+	default:
+		if imported, found := importPath(module, head); found {
+			if imported[0].Equal(ast.InputRootDocument) {
+				return "", true
+			}
+			full = imported.Concat(ref[1:])
+		} else if hasRule(module, string(head)) {
+			full = module.Package.Path.Append(ast.StringTerm(string(head))).Concat(ref[1:])
+		} else {
+			return "", false
+		}
+	}
+	return refPath(full.GroundPrefix()), false
+}
+
+func importPath(module *ast.Module, name ast.Var) (ast.Ref, bool) {
+	for _, imp := range module.Imports {
+		path, ok := imp.Path.Value.(ast.Ref)
+		if !ok {
+			continue
+		}
+		alias := string(imp.Alias)
+		if alias == "" {
+			alias = strings.Trim(path[len(path)-1].String(), `"`)
+		}
+		if alias == string(name) {
+			return path, true
+		}
+	}
+	return nil, false
+}
+
+func hasRule(module *ast.Module, name string) bool {
+	for _, rule := range module.Rules {
+		if ruleName(rule) == name {
+			return true
+		}
+	}
+	return false
+}
+
+func ruleName(rule *ast.Rule) string {
+	return rule.Head.Ref()[0].Value.String()
+}
+
+func rulePath(module *ast.Module, name string) string {
+	return refPath(module.Package.Path.Append(ast.StringTerm(name)))
+}
+
+func refPath(ref ast.Ref) string {
+	parts := make([]string, 0, len(ref))
+	for _, term := range ref {
+		if str, ok := term.Value.(ast.String); ok {
+			parts = append(parts, string(str))
+		} else {
+			parts = append(parts, term.String())
+		}
+	}
+	return strings.Join(parts, ".")
+}
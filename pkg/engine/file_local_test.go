@@ -0,0 +1,129 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/Checkmarx/kics/v2/pkg/engine/source"
+	"github.com/Checkmarx/kics/v2/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInspector_fileLocal(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    bool
+	}{
+		{
+			name: "single document",
+			content: `CxPolicy[result] {
+	resource := input.document[i].resource.aws_s3_bucket[name]
+	terraLib.public(resource)
+	result := {"documentId": input.document[i].id}
+}`,
+			want: true,
+		},
+		{
+			name: "helper called with the document",
+			content: `CxPolicy[result] {
+	document := input.document[i]
+	encrypted(document.resource)
+	not common_lib.valid_key(document, "provider")
+	result := {"documentId": document.id}
+}
+
+encrypted(resource) {
+	resource.encrypted == true
+}`,
+			want: true,
+		},
+		{
+			name: "two documents",
+			content: `CxPolicy[result] {
+	input.document[i].resource
+	input.document[j].variable
+	result := {"documentId": input.document[i].id}
+}`,
+		},
+		{
+			name: "wildcards",
+			content: `CxPolicy[result] {
+	input.document[_].resource
+	result := {"documentId": input.document[_].id}
+}`,
+		},
+		{
+			name: "every document",
+			content: `CxPolicy[result] {
+	documents := input.document
+	result := {"documentId": documents[0].id}
+}`,
+		},
+		{
+			name: "comprehension over the documents",
+			content: `CxPolicy[result] {
+	ids := [id | id := input.document[i].id]
+	result := {"documentId": ids[0]}
+}`,
+		},
+		{
+			name: "helper reading the input",
+			content: `CxPolicy[result] {
+	document := input.document[i]
+	not has_variables
+	result := {"documentId": document.id}
+}
+
+has_variables {
+	input.document[_].variable
+}`,
+		},
+		{
+			name: "library reading the input",
+			content: `CxPolicy[result] {
+	document := input.document[i]
+	terraLib.has_provider("aws")
+	result := {"documentId": document.id}
+}`,
+		},
+		{
+			name: "no document",
+			content: `CxPolicy[result] {
+	result := {"documentId": "main"}
+}`,
+		},
+		{
+			name:    "invalid",
+			content: `CxPolicy[result] {`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newPayloadTestInspector(nil)
+			c.QueryLoader.commonLibrary = source.RegoLibraries{LibraryCode: `package generic.common
+
+valid_key(obj, key) {
+	_ = obj[key]
+}`}
+			c.QueryLoader.platformLibraries["terraform"] = source.RegoLibraries{LibraryCode: `package generic.terraform
+
+public(resource) {
+	resource.acl == "public-read"
+}
+
+has_provider(name) {
+	input.document[_].provider[name]
+}`}
+
+			query := model.QueryMetadata{
+				Query:    tt.name,
+				Platform: "terraform",
+				Content: "package Cx\n\nimport data.generic.common as common_lib\nimport data.generic.terraform as terraLib\n\n" +
+					tt.content,
+			}
+			require.Equal(t, tt.want, c.fileLocal(&query))
+			require.Equal(t, tt.want, c.fileLocal(&query), "the classification should be kept")
+		})
+	}
+}
@@ -3,7 +3,6 @@ package engine
 import (
 	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
 
 	"runtime"
@@ -13,6 +12,7 @@ import (
 
 	"github.com/Checkmarx/kics/v2/internal/metrics"
 	sentryReport "github.com/Checkmarx/kics/v2/internal/sentry"
+	"github.com/Checkmarx/kics/v2/pkg/cache"
 	"github.com/Checkmarx/kics/v2/pkg/detector"
 	"github.com/Checkmarx/kics/v2/pkg/detector/docker"
 	"github.com/Checkmarx/kics/v2/pkg/detector/helm"
@@ -23,7 +23,6 @@ import (
 	"github.com/open-policy-agent/opa/rego"
 	"github.com/open-policy-agent/opa/storage/inmem"
 	"github.com/open-policy-agent/opa/topdown"
-	"github.com/open-policy-agent/opa/util"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog/log"
 )
@@ -75,6 +74,8 @@ type Inspector struct {
 	failedQueries  map[string]error
 	excludeResults map[string]bool
 	detector       *detector.DetectLine
+	suppressed     []model.Vulnerability
+	suppressedMu   sync.Mutex
 
 	enableCoverageReport bool
 	coverageReport       cover.Report
@@ -82,6 +83,11 @@ type Inspector struct {
 	useOldSeverities     bool
 	numWorkers           int
 	kicsComputeNewSimID  bool
+	cache                *cache.Cache
+	queryProfile         map[string]*model.QueryProfileEntry
+	queryProfileMu       sync.Mutex
+	fileLocalQueries     sync.Map
+	libraryInputRules    sync.Map
 }
 
 // QueryContext contains the context where the query is executed, which scan it belongs, basic information of query,
@@ -93,6 +99,7 @@ type QueryContext struct {
 	Query         *PreparedQuery
 	payload       *ast.Value
 	BaseScanPaths []string
+	evaluated     bool
 }
 
 var (
@@ -213,16 +220,16 @@ type QueryResult struct {
 }
 
 // This function creates an inspection task and sends it to the jobs channel
-func (c *Inspector) createInspectionJobs(jobs chan<- InspectionJob, queries []model.QueryMetadata) {
+func (c *Inspector) createInspectionJobs(jobs chan<- InspectionJob, queries []int) {
 	defer close(jobs)
-	for i := range queries {
+	for _, i := range queries {
 		jobs <- InspectionJob{queryID: i}
 	}
 }
 
 // This function performs an inspection job and sends the result to the results channel
-func (c *Inspector) performInspection(ctx context.Context, scanID string, files model.FileMetadatas,
-	astPayload ast.Value, baseScanPaths []string, currentQuery chan<- int64,
+func (c *Inspector) performInspection(ctx context.Context, scanID string, files map[string]model.FileMetadata,
+	payload *partitionPayload, baseScanPaths []string, currentQuery chan<- int64,
 	jobs <-chan InspectionJob, results chan<- QueryResult, queries []model.QueryMetadata) {
 	for job := range jobs {
 		currentQuery <- 1
@@ -240,16 +247,47 @@ func (c *Inspector) performInspection(ctx context.Context, scanID string, files
 			Metadata: queries[job.queryID],
 		}
 
-		queryContext := &QueryContext{
-			Ctx:           ctx,
-			scanID:        scanID,
-			Files:         files.ToMap(),
-			Query:         query,
-			payload:       &astPayload,
-			BaseScanPaths: baseScanPaths,
+		newQueryContext := func(input *ast.Value) *QueryContext {
+			return &QueryContext{
+				Ctx:           ctx,
+				scanID:        scanID,
+				Files:         files,
+				Query:         query,
+				payload:       input,
+				BaseScanPaths: baseScanPaths,
+			}
 		}
 
-		vuls, err := c.doRun(queryContext)
+		var vuls []model.Vulnerability
+		evaluations := 0
+		if query.Metadata.SingleDocument {
+			queryHash := c.hashQuery(&query.Metadata)
+			for i := range payload.files {
+				var fileVuls []model.Vulnerability
+				fileCtx := newQueryContext(&payload.files[i].value)
+				fileVuls, err = c.doRunFile(fileCtx, &payload.files[i], queryHash)
+				if fileCtx.evaluated {
+					evaluations++
+				}
+				if err != nil {
+					break
+				}
+				vuls = append(vuls, fileVuls...)
+			}
+		} else if c.cacheResults() && c.fileLocal(&query.Metadata) {
+			filesCtx := newQueryContext(nil)
+			vuls, err = c.doRunFiles(filesCtx, payload.files, c.hashQuery(&query.Metadata))
+			if filesCtx.evaluated {
+				evaluations++
+			}
+		} else {
+			queryCtx := newQueryContext(&payload.combined)
+			vuls, err = c.doRun(queryCtx)
+			if queryCtx.evaluated {
+				evaluations++
+			}
+		}
+		c.profileQuery(&query.Metadata, time.Since(queryStartTime), evaluations, len(vuls), err)
 		if err == nil {
 			log.Debug().Msgf("Finished to run query %s after %v", queries[job.queryID].Query, time.Since(queryStartTime))
 			c.tracker.TrackQueryExecution(query.Metadata.Aggregation)
@@ -266,34 +304,115 @@ func (c *Inspector) Inspect(
 	platforms []string,
 	currentQuery chan<- int64) ([]model.Vulnerability, error) {
 	log.Debug().Msg("engine.Inspect()")
-	combinedFiles := files.Combine(false)
 
-	var vulnerabilities []model.Vulnerability
-	vulnerabilities = make([]model.Vulnerability, 0)
-	var p interface{}
+	vulnerabilities := make([]model.Vulnerability, 0)
+	err := c.InspectStream(ctx, scanID, files, baseScanPaths, platforms, currentQuery,
+		func(queryVulnerabilities []model.Vulnerability) error {
+			vulnerabilities = append(vulnerabilities, queryVulnerabilities...)
+			return nil
+		})
+	return vulnerabilities, err
+}
 
-	payload, err := json.Marshal(combinedFiles)
-	if err != nil {
-		return vulnerabilities, err
+// InspectStream runs the queries of the platforms over the files like Inspect, but hands the vulnerabilities of each
+// query to sink as soon as the query finishes instead of collecting them. The first error returned by sink is
+// returned once all the queries finished
+func (c *Inspector) InspectStream(
+	ctx context.Context,
+	scanID string,
+	files model.FileMetadatas,
+	baseScanPaths []string,
+	platforms []string,
+	currentQuery chan<- int64,
+	sink func(vulnerabilities []model.Vulnerability) error) error {
+	log.Debug().Msg("engine.InspectStream()")
+
+	queries := c.getQueriesByPlat(platforms)
+	filesMap := files.ToMap()
+
+	// Create a channel to collect the results
+	results := make(chan QueryResult, len(queries))
+
+	// Hand the results to the sink as the queries finish
+	var sinkErr error
+	sinkDone := make(chan struct{})
+	go func() {
+		defer close(sinkDone)
+		for result := range results {
+			if result.err != nil {
+				fmt.Println()
+				sentryReport.ReportSentry(&sentryReport.Report{
+					Message:  fmt.Sprintf("Inspector. query executed with error, query=%s", queries[result.queryID].Query),
+					Err:      result.err,
+					Location: "func Inspect()",
+					Platform: queries[result.queryID].Platform,
+					Metadata: queries[result.queryID].Metadata,
+					Query:    queries[result.queryID].Query,
+				}, true)
+
+				c.failedQueries[queries[result.queryID].Query] = result.err
+
+				continue
+			}
+			if sinkErr == nil {
+				sinkErr = sink(result.vulnerabilities)
+			}
+		}
+	}()
+
+	// Each partition is evaluated in turn, so only the payload of one partition is kept in memory
+	var err error
+	for _, partition := range partitionQueries(queries) {
+		if err = c.inspectPartition(ctx, scanID, files, filesMap, baseScanPaths, currentQuery, results, queries,
+			&partition); err != nil {
+			break
+		}
 	}
 
-	err = util.UnmarshalJSON(payload, &p)
+	close(results)
+	<-sinkDone
+
 	if err != nil {
-		return vulnerabilities, err
+		return err
 	}
+	return sinkErr
+}
 
-	astPayload, err := ast.InterfaceToValue(p)
-	if err != nil {
-		return vulnerabilities, err
+// inspectPartition runs the queries of the partition against the documents of its kinds, skipping the queries
+// when the scan has no document of those kinds
+func (c *Inspector) inspectPartition(
+	ctx context.Context,
+	scanID string,
+	files model.FileMetadatas,
+	filesMap map[string]model.FileMetadata,
+	baseScanPaths []string,
+	currentQuery chan<- int64,
+	results chan<- QueryResult,
+	queries []model.QueryMetadata,
+	partition *queryPartition) error {
+	partitionFiles := partition.filterFiles(files)
+	if len(partitionFiles) == 0 {
+		for _, i := range partition.queries {
+			currentQuery <- 1
+			c.tracker.TrackQueryExecution(queries[i].Aggregation)
+		}
+		return nil
 	}
 
-	queries := c.getQueriesByPlat(platforms)
+	combined, err := buildPayload(partitionFiles)
+	if err != nil {
+		return err
+	}
+	payload := &partitionPayload{combined: combined}
 
-	// Create a channel to collect the results
-	results := make(chan QueryResult, len(queries))
+	if partition.hasSingleDocumentQueries(queries) || (c.cacheResults() && c.hasFileLocalQueries(partition, queries)) {
+		if payload.files, err = buildFilePayloads(partitionFiles, c.cacheResults()); err != nil {
+			return err
+		}
+	}
 
 	// Create a channel for inspection jobs
-	jobs := make(chan InspectionJob, len(queries))
+	jobs := make(chan InspectionJob, len(partition.queries))
 
 	var wg sync.WaitGroup
 
@@ -304,39 +423,16 @@ func (c *Inspector) Inspect(
 		go func() {
 			// Decrement the counter when the goroutine completes
 			defer wg.Done()
-			c.performInspection(ctx, scanID, files, astPayload, baseScanPaths, currentQuery, jobs, results, queries)
+			c.performInspection(ctx, scanID, filesMap, payload, baseScanPaths, currentQuery, jobs, results, queries)
 		}()
 	}
 	// Start a goroutine to create inspection jobs
-	go c.createInspectionJobs(jobs, queries)
-
-	go func() {
-		// Wait for all jobs to finish
-		wg.Wait()
-		// Then close the results channel
-		close(results)
-	}()
+	go c.createInspectionJobs(jobs, partition.queries)
 
-	// Collect all the results
-	for result := range results {
-		if result.err != nil {
-			fmt.Println()
-			sentryReport.ReportSentry(&sentryReport.Report{
-				Message:  fmt.Sprintf("Inspector. query executed with error, query=%s", queries[result.queryID].Query),
-				Err:      result.err,
-				Location: "func Inspect()",
-				Platform: queries[result.queryID].Platform,
-				Metadata: queries[result.queryID].Metadata,
-				Query:    queries[result.queryID].Query,
-			}, true)
-
-			c.failedQueries[queries[result.queryID].Query] = result.err
+	// Wait for all jobs to finish
+	wg.Wait()
 
-			continue
-		}
-		vulnerabilities = append(vulnerabilities, result.vulnerabilities...)
-	}
-	return vulnerabilities, nil
+	return nil
 }
 
 // LenQueriesByPlat returns the number of queries by platforms
@@ -376,17 +472,112 @@ func (c *Inspector) GetFailedQueries() map[string]error {
 	return c.failedQueries
 }
 
+// GetSuppressedResults returns the vulnerabilities excluded by their similarity ID or ignored by comments
+func (c *Inspector) GetSuppressedResults() []model.Vulnerability {
+	c.suppressedMu.Lock()
+	defer c.suppressedMu.Unlock()
+	return c.suppressed
+}
+
+// recoverPanic turns a panic while running the query into its error
+func recoverPanic(ctx *QueryContext, err *error) {
+	if r := recover(); r != nil {
+		errMessage := fmt.Sprintf("Recovered from panic during query '%s' run. ", ctx.Query.Metadata.Query)
+		*err = fmt.Errorf("panic: %v", r)
+		fmt.Println()
+		log.Err(*err).Msg(errMessage)
+	}
+}
+
 func (c *Inspector) doRun(ctx *QueryContext) (vulns []model.Vulnerability, err error) {
+	defer recoverPanic(ctx, &err)
+
+	results, err := c.evaluate(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	timeoutCtxToDecode, cancelDecode := context.WithTimeout(ctx.Ctx, c.queryExecTimeout)
+	defer cancelDecode()
+	return c.DecodeQueryResults(ctx, timeoutCtxToDecode, results)
+}
+
+// doRunFile runs a single document query on the documents of a file, reusing the results kept on the cache for the
+// same query and documents. The results are kept with the position of their document, since the ids change on
+// every scan
+func (c *Inspector) doRunFile(ctx *QueryContext, file *filePayload, queryHash string) (
+	vulns []model.Vulnerability, err error) {
+	if !c.cacheResults() {
+		return c.doRun(ctx)
+	}
+	defer recoverPanic(ctx, &err)
+
+	key := c.cache.ResultKey(queryHash, file.hash)
+	items, cached := c.cache.GetResults(key)
+	if cached {
+		setResultDocuments(items, file.ids)
+	}
+
+	results := rego.ResultSet{{Bindings: rego.Vars{"result": items}}}
+	if !cached {
+		if results, err = c.evaluate(ctx); err != nil {
+			return nil, err
+		}
+		if items, ok := resultItems(results); ok {
+			c.cache.PutResults(key, positionalResults(items, file.ids))
+		}
+	}
+
+	timeoutCtxToDecode, cancelDecode := context.WithTimeout(ctx.Ctx, c.queryExecTimeout)
+	defer cancelDecode()
+	return c.DecodeQueryResults(ctx, timeoutCtxToDecode, results)
+}
+
+// doRunFiles runs a query reading one document at a time over the documents of the files, reusing the results kept
+// on the cache for the files that did not change. The files missing on the cache are evaluated together and their
+// results kept on the cache by file
+func (c *Inspector) doRunFiles(ctx *QueryContext, files []filePayload, queryHash string) (
+	vulns []model.Vulnerability, err error) {
+	defer recoverPanic(ctx, &err)
+
+	items := make([]interface{}, 0)
+	missed := make([]*filePayload, 0)
+	for i := range files {
+		cached, ok := c.cache.GetResults(c.cache.ResultKey(queryHash, files[i].hash))
+		if !ok {
+			missed = append(missed, &files[i])
+			continue
+		}
+		setResultDocuments(cached, files[i].ids)
+		items = append(items, cached...)
+	}
+
+	results := rego.ResultSet{{Bindings: rego.Vars{"result": items}}}
+	if len(missed) > 0 {
+		payload := combineFilePayloads(missed)
+		ctx.payload = &payload
+		evaluated, evalErr := c.evaluate(ctx)
+		if evalErr != nil {
+			return nil, evalErr
+		}
+		if evaluatedItems, ok := resultItems(evaluated); ok {
+			c.putFileResults(queryHash, missed, evaluatedItems)
+			results[0].Bindings["result"] = append(items, evaluatedItems...)
+		} else {
+			results = evaluated
+		}
+	}
+
+	timeoutCtxToDecode, cancelDecode := context.WithTimeout(ctx.Ctx, c.queryExecTimeout)
+	defer cancelDecode()
+	return c.DecodeQueryResults(ctx, timeoutCtxToDecode, results)
+}
+
+// evaluate runs the query over its payload
+func (c *Inspector) evaluate(ctx *QueryContext) (rego.ResultSet, error) {
+	ctx.evaluated = true
 	timeoutCtx, cancel := context.WithTimeout(ctx.Ctx, c.queryExecTimeout)
 	defer cancel()
-	defer func() {
-		if r := recover(); r != nil {
-			errMessage := fmt.Sprintf("Recovered from panic during query '%s' run. ", ctx.Query.Metadata.Query)
-			err = fmt.Errorf("panic: %v", r)
-			fmt.Println()
-			log.Err(err).Msg(errMessage)
-		}
-	}()
 	options := []rego.EvalOption{rego.EvalParsedInput(*ctx.payload)}
 
 	var cov *cover.Cover
@@ -419,9 +610,7 @@ func (c *Inspector) doRun(ctx *QueryContext) (vulns []model.Vulnerability, err e
 		Str("scanID", ctx.scanID).
 		Msgf("Inspector executed with result %+v, query=%s", results, ctx.Query.Metadata.Query)
 
-	timeoutCtxToDecode, cancelDecode := context.WithTimeout(ctx.Ctx, c.queryExecTimeout)
-	defer cancelDecode()
-	return c.DecodeQueryResults(ctx, timeoutCtxToDecode, results)
+	return results, nil
 }
 
 // DecodeQueryResults decodes the results into []model.Vulnerability
@@ -514,16 +703,26 @@ func getVulnerabilitiesFromQuery(ctx *QueryContext, c *Inspector, queryResultIte
 	if _, ok := c.excludeResults[vulnerability.SimilarityID]; ok {
 		log.Debug().
 			Msgf("Excluding result SimilarityID: %s", vulnerability.SimilarityID)
+		c.suppress(vulnerability, model.SuppressionExternal)
 		return nil, false
 	} else if checkComment(vulnerability.Line, file.LinesIgnore) {
 		log.Debug().
 			Msgf("Excluding result Comment: %s", vulnerability.SimilarityID)
+		c.suppress(vulnerability, model.SuppressionInSource)
 		return nil, false
 	}
 
 	return vulnerability, false
 }
 
+// suppress keeps a vulnerability that is not reported, so it can still be listed as suppressed
+func (c *Inspector) suppress(vulnerability *model.Vulnerability, suppression string) {
+	vulnerability.Suppression = suppression
+	c.suppressedMu.Lock()
+	defer c.suppressedMu.Unlock()
+	c.suppressed = append(c.suppressed, *vulnerability)
+}
+
 // checkComment checks if the vulnerability should be skipped from comment
 func checkComment(line int, ignoreLines []int) bool {
 	for _, ignoreLine := range ignoreLines {
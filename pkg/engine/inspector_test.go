@@ -192,11 +192,12 @@ func TestInspect(t *testing.T) { //nolint
 		kicsComputeNewSimID bool
 	}
 	tests := []struct {
-		name    string
-		fields  fields
-		args    args
-		want    []model.Vulnerability
-		wantErr bool
+		name           string
+		fields         fields
+		args           args
+		want           []model.Vulnerability
+		wantSuppressed []string
+		wantErr        bool
 	}{
 		{
 			name: "TestInspect",
@@ -301,8 +302,9 @@ func TestInspect(t *testing.T) { //nolint
 				},
 				kicsComputeNewSimID: true,
 			},
-			want:    []model.Vulnerability{},
-			wantErr: false,
+			want:           []model.Vulnerability{},
+			wantSuppressed: []string{model.SuppressionExternal},
+			wantErr:        false,
 		},
 	}
 
@@ -341,6 +343,11 @@ func TestInspect(t *testing.T) { //nolint
 				require.Nil(t, err)
 				t.Errorf("Inspector.Inspect() got %v,\nwant %v", gotStrVulnerabilities, wantStrVulnerabilities)
 			}
+			suppressed := c.GetSuppressedResults()
+			require.Len(t, suppressed, len(tt.wantSuppressed))
+			for idx := range suppressed {
+				require.Equal(t, tt.wantSuppressed[idx], suppressed[idx].Suppression)
+			}
 		})
 
 		defer func() {
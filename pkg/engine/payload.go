@@ -0,0 +1,221 @@
+package engine
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Checkmarx/kics/v2/pkg/cache"
+	"github.com/Checkmarx/kics/v2/pkg/model"
+	"github.com/open-policy-agent/opa/ast"
+	"github.com/open-policy-agent/opa/util"
+)
+
+// platformKinds lists the kinds of the documents the queries of each platform can match,
+// the queries of the platforms not listed, like the common ones, are evaluated against every document
+var platformKinds = map[string][]model.FileKind{
+	"ansible":                 {model.KindYAML, model.KindJSON, model.KindCFG, model.KindINI},
+	"azureResourceManager":    {model.KindJSON, model.KindBICEP},
+	"buildah":                 {model.KindBUILDAH},
+	"cicd":                    {model.KindYAML, model.KindJSON},
+	"cloudFormation":          {model.KindYAML, model.KindJSON},
+	"crossplane":              {model.KindYAML, model.KindJSON, model.KindHELM},
+	"dockerCompose":           {model.KindYAML, model.KindJSON},
+	"dockerfile":              {model.KindDOCKER},
+	"googleDeploymentManager": {model.KindYAML, model.KindJSON},
+	"grpc":                    {model.KindPROTO},
+	"k8s":                     {model.KindYAML, model.KindJSON, model.KindHELM},
+	"knative":                 {model.KindYAML, model.KindJSON, model.KindHELM},
+	"openAPI":                 {model.KindYAML, model.KindJSON},
+	"pulumi":                  {model.KindYAML, model.KindJSON},
+	"serverlessFW":            {model.KindYAML, model.KindJSON},
+	"terraform":               {model.KindTerraform, model.KindJSON},
+}
+
+// queryPartition is a group of queries evaluated against the same documents, the ones of its kinds
+type queryPartition struct {
+	kinds   map[model.FileKind]bool
+	queries []int
+}
+
+// partitionPayload is the input of the queries of a partition, the documents of its kinds combined in a single
+// payload and, when a query of the partition is evaluated document by document, a payload for each file
+type partitionPayload struct {
+	combined ast.Value
+	files    []filePayload
+}
+
+// filePayload is the input of the queries evaluated document by document for a file, with the ids of its documents
+// in the order of the payload and, when the query results are cached, the hash of the documents
+type filePayload struct {
+	path  string
+	value ast.Value
+	ids   []string
+	hash  string
+}
+
+// partitionQueries groups the queries by the kinds of the documents they can match, sorted so the queries
+// sharing the same documents are evaluated together
+func partitionQueries(queries []model.QueryMetadata) []queryPartition {
+	partitions := make(map[string]*queryPartition)
+	keys := make([]string, 0)
+
+	for i := range queries {
+		kinds, ok := platformKinds[queries[i].Platform]
+
+		key := "*"
+		if ok {
+			names := make([]string, 0, len(kinds))
+			for _, kind := range kinds {
+				names = append(names, string(kind))
+			}
+			sort.Strings(names)
+			key = strings.Join(names, ",")
+		}
+
+		partition, exists := partitions[key]
+		if !exists {
+			partition = &queryPartition{}
+			if ok {
+				partition.kinds = make(map[model.FileKind]bool, len(kinds))
+				for _, kind := range kinds {
+					partition.kinds[kind] = true
+				}
+			}
+			partitions[key] = partition
+			keys = append(keys, key)
+		}
+		partition.queries = append(partition.queries, i)
+	}
+
+	sort.Strings(keys)
+	result := make([]queryPartition, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, *partitions[key])
+	}
+
+	return result
+}
+
+// filterFiles returns the files with the documents of the kinds of the partition
+func (p *queryPartition) filterFiles(files model.FileMetadatas) model.FileMetadatas {
+	if p.kinds == nil {
+		return files
+	}
+
+	filtered := make(model.FileMetadatas, 0, len(files))
+	for i := range files {
+		if p.kinds[files[i].Kind] {
+			filtered = append(filtered, files[i])
+		}
+	}
+
+	return filtered
+}
+
+// hasSingleDocumentQueries returns true when a query of the partition is evaluated document by document
+func (p *queryPartition) hasSingleDocumentQueries(queries []model.QueryMetadata) bool {
+	for _, i := range p.queries {
+		if queries[i].SingleDocument {
+			return true
+		}
+	}
+	return false
+}
+
+// buildPayload combines the documents of the files in the input of the queries
+func buildPayload(files model.FileMetadatas) (ast.Value, error) {
+	return documentsValue(files.Combine(false))
+}
+
+func documentsValue(documents model.Documents) (ast.Value, error) {
+	var p interface{}
+
+	payload, err := json.Marshal(documents)
+	if err != nil {
+		return nil, err
+	}
+
+	err = util.UnmarshalJSON(payload, &p)
+	if err != nil {
+		return nil, err
+	}
+
+	return ast.InterfaceToValue(p)
+}
+
+// buildFilePayloads builds the input of the queries for each file, with the documents of the file, hashing the
+// documents when hashDocuments is set
+func buildFilePayloads(files model.FileMetadatas, hashDocuments bool) ([]filePayload, error) {
+	paths := make([]string, 0)
+	filesByPath := make(map[string]model.FileMetadatas)
+	for i := range files {
+		if _, ok := filesByPath[files[i].FilePath]; !ok {
+			paths = append(paths, files[i].FilePath)
+		}
+		filesByPath[files[i].FilePath] = append(filesByPath[files[i].FilePath], files[i])
+	}
+
+	payloads := make([]filePayload, 0, len(paths))
+	for _, path := range paths {
+		documents := filesByPath[path].Combine(false)
+
+		value, err := documentsValue(documents)
+		if err != nil {
+			return nil, err
+		}
+
+		payload := filePayload{path: path, value: value, ids: make([]string, 0, len(documents.Documents))}
+		for _, document := range documents.Documents {
+			payload.ids = append(payload.ids, document["id"].(string))
+		}
+		if hashDocuments {
+			if payload.hash, err = hashFileDocuments(documents); err != nil {
+				return nil, err
+			}
+		}
+		payloads = append(payloads, payload)
+	}
+
+	return payloads, nil
+}
+
+// combineFilePayloads combines the documents of the files in a single input, like buildPayload
+func combineFilePayloads(files []*filePayload) ast.Value {
+	documents := make([]*ast.Term, 0, len(files))
+	for _, file := range files {
+		object, ok := file.value.(ast.Object)
+		if !ok {
+			continue
+		}
+		if term := object.Get(ast.StringTerm("document")); term != nil {
+			if array, ok := term.Value.(*ast.Array); ok {
+				array.Foreach(func(document *ast.Term) {
+					documents = append(documents, document)
+				})
+			}
+		}
+	}
+	return ast.NewObject(ast.Item(ast.StringTerm("document"), ast.ArrayTerm(documents...)))
+}
+
+// hashFileDocuments hashes the documents of a file with their position in place of their id,
+// which changes on every scan
+func hashFileDocuments(documents model.Documents) (string, error) {
+	positional := model.Documents{Documents: make([]model.Document, 0, len(documents.Documents))}
+	for i, document := range documents.Documents {
+		doc := make(model.Document, len(document))
+		for key, value := range document {
+			doc[key] = value
+		}
+		doc["id"] = strconv.Itoa(i)
+		positional.Documents = append(positional.Documents, doc)
+	}
+
+	content, err := json.Marshal(positional)
+	if err != nil {
+		return "", err
+	}
+	return cache.Hash(string(content)), nil
+}
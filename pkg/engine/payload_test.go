@@ -0,0 +1,308 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/Checkmarx/kics/v2/internal/tracker"
+	"github.com/Checkmarx/kics/v2/pkg/detector"
+	"github.com/Checkmarx/kics/v2/pkg/detector/docker"
+	"github.com/Checkmarx/kics/v2/pkg/engine/source"
+	"github.com/Checkmarx/kics/v2/pkg/model"
+	"github.com/Checkmarx/kics/v2/pkg/utils"
+	"github.com/open-policy-agent/opa/ast"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_partitionQueries(t *testing.T) {
+	queries := []model.QueryMetadata{
+		{Query: "dockerfile_query", Platform: "dockerfile"},
+		{Query: "k8s_query", Platform: "k8s"},
+		{Query: "common_query", Platform: "common"},
+		{Query: "knative_query", Platform: "knative"},
+		{Query: "terraform_query", Platform: "terraform"},
+		{Query: "another_dockerfile_query", Platform: "dockerfile"},
+	}
+
+	got := partitionQueries(queries)
+
+	require.Equal(t, []queryPartition{
+		{queries: []int{2}},
+		{kinds: map[model.FileKind]bool{model.KindDOCKER: true}, queries: []int{0, 5}},
+		{
+			kinds:   map[model.FileKind]bool{model.KindHELM: true, model.KindJSON: true, model.KindYAML: true},
+			queries: []int{1, 3},
+		},
+		{kinds: map[model.FileKind]bool{model.KindJSON: true, model.KindTerraform: true}, queries: []int{4}},
+	}, got)
+}
+
+func Test_queryPartition_filterFiles(t *testing.T) {
+	files := model.FileMetadatas{
+		{ID: "1", Kind: model.KindTerraform},
+		{ID: "2", Kind: model.KindDOCKER},
+		{ID: "3", Kind: model.KindJSON},
+	}
+
+	tests := []struct {
+		name      string
+		partition queryPartition
+		want      []string
+	}{
+		{
+			name:      "keep the files of the kinds of the partition",
+			partition: queryPartition{kinds: map[model.FileKind]bool{model.KindJSON: true, model.KindTerraform: true}},
+			want:      []string{"1", "3"},
+		},
+		{
+			name:      "keep every file when the partition has no kinds",
+			partition: queryPartition{},
+			want:      []string{"1", "2", "3"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := make([]string, 0)
+			for _, file := range tt.partition.filterFiles(files) {
+				got = append(got, file.ID)
+			}
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_buildFilePayloads(t *testing.T) {
+	files := model.FileMetadatas{
+		{ID: "1", FilePath: "deployment.yaml", Document: model.Document{"kind": "Deployment"}},
+		{ID: "2", FilePath: "service.yaml", Document: model.Document{"kind": "Service"}},
+		{ID: "3", FilePath: "deployment.yaml", Document: model.Document{"kind": "ConfigMap"}},
+	}
+
+	got, err := buildFilePayloads(files, false)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+
+	documents, err := ast.JSON(got[0].value)
+	require.NoError(t, err)
+	require.Equal(t, map[string]interface{}{
+		"document": []interface{}{
+			map[string]interface{}{"id": "1", "file": "deployment.yaml", "kind": "Deployment"},
+			map[string]interface{}{"id": "3", "file": "deployment.yaml", "kind": "ConfigMap"},
+		},
+	}, documents)
+	require.Equal(t, "deployment.yaml", got[0].path)
+	require.Equal(t, []string{"1", "3"}, got[0].ids)
+	require.Empty(t, got[0].hash)
+
+	documents, err = ast.JSON(got[1].value)
+	require.NoError(t, err)
+	require.Equal(t, map[string]interface{}{
+		"document": []interface{}{
+			map[string]interface{}{"id": "2", "file": "service.yaml", "kind": "Service"},
+		},
+	}, documents)
+	require.Equal(t, []string{"2"}, got[1].ids)
+}
+
+func Test_buildFilePayloadsHash(t *testing.T) {
+	newFiles := func(ids ...string) model.FileMetadatas {
+		return model.FileMetadatas{
+			{ID: ids[0], FilePath: "deployment.yaml", Document: model.Document{"kind": "Deployment"}},
+			{ID: ids[1], FilePath: "service.yaml", Document: model.Document{"kind": "Service"}},
+		}
+	}
+
+	got, err := buildFilePayloads(newFiles("1", "2"), true)
+	require.NoError(t, err)
+	rescanned, err := buildFilePayloads(newFiles("3", "4"), true)
+	require.NoError(t, err)
+
+	require.NotEmpty(t, got[0].hash)
+	require.NotEqual(t, got[0].hash, got[1].hash)
+	require.Equal(t, got[0].hash, rescanned[0].hash, "the hash should not depend on the ids of the documents")
+	require.Equal(t, got[1].hash, rescanned[1].hash, "the hash should not depend on the ids of the documents")
+}
+
+func TestInspect_PartitionedPayload(t *testing.T) {
+	// both queries report every document they get, the terraform one only when its input has a single document
+	queries := []model.QueryMetadata{
+		{
+			Query:     "every_document",
+			Platform:  "dockerfile",
+			InputData: "{}",
+			Content: `package Cx
+
+CxPolicy[result] {
+	document := input.document[i]
+	result := {
+		"documentId": document.id,
+		"searchKey": "FROM",
+		"issueType": "IncorrectValue",
+		"keyExpectedValue": "expected",
+		"keyActualValue": "actual",
+	}
+}`,
+		},
+		{
+			Query:          "single_document",
+			Platform:       "terraform",
+			InputData:      "{}",
+			SingleDocument: true,
+			Content: `package Cx
+
+CxPolicy[result] {
+	count(input.document) == 1
+	document := input.document[i]
+	result := {
+		"documentId": document.id,
+		"searchKey": "resource",
+		"issueType": "IncorrectValue",
+		"keyExpectedValue": "expected",
+		"keyActualValue": "actual",
+	}
+}`,
+		},
+	}
+
+	files := model.FileMetadatas{
+		{ID: "dockerfile", Document: model.Document{"command": map[string]interface{}{}}, Kind: model.KindDOCKER},
+		{ID: "main", Document: model.Document{"resource": map[string]interface{}{}}, Kind: model.KindTerraform},
+		{ID: "variables", Document: model.Document{"variable": map[string]interface{}{}}, Kind: model.KindTerraform},
+		{ID: "deployment", Document: model.Document{"kind": "Deployment"}, Kind: model.KindYAML},
+	}
+	for i := range files {
+		files[i].FilePath = files[i].ID
+		files[i].OriginalData = "FROM alpine\nresource"
+		files[i].LinesOriginalData = utils.SplitLines(files[i].OriginalData)
+	}
+
+	c := newPayloadTestInspector(queries)
+
+	currentQuery := make(chan int64, len(queries))
+	got, err := c.Inspect(context.Background(), "scanID", files, []string{"."}, []string{"Dockerfile", "Terraform"},
+		currentQuery)
+	require.NoError(t, err)
+	require.Empty(t, c.GetFailedQueries())
+	require.Len(t, currentQuery, len(queries))
+
+	results := make([]string, 0, len(got))
+	for i := range got {
+		results = append(results, got[i].FileID)
+	}
+	sort.Strings(results)
+	require.Equal(t, []string{"dockerfile", "main", "variables"}, results)
+}
+
+// BenchmarkInspectPartition compares the queries run against the payload of their partition with the queries
+// run against a single payload combining every document, on a scan mixing Terraform, Kubernetes and Dockerfiles
+func BenchmarkInspectPartition(b *testing.B) {
+	content := `package Cx
+
+CxPolicy[result] {
+	document := input.document[i]
+	document.%s
+	result := {
+		"documentId": document.id,
+		"searchKey": "%s",
+		"issueType": "IncorrectValue",
+		"keyExpectedValue": "expected",
+		"keyActualValue": "actual",
+	}
+}`
+	queries := []model.QueryMetadata{
+		{Query: "dockerfile_query", Platform: "dockerfile", Content: fmt.Sprintf(content, "command", "FROM")},
+		{Query: "k8s_query", Platform: "k8s", Content: fmt.Sprintf(content, "kind", "kind")},
+		{Query: "terraform_query", Platform: "terraform", Content: fmt.Sprintf(content, "resource", "resource")},
+	}
+	for i := range queries {
+		queries[i].InputData = "{}"
+		queries[i].Metadata = map[string]interface{}{
+			"id":              queries[i].Query,
+			"queryName":       queries[i].Query,
+			"severity":        model.SeverityHigh,
+			"category":        "Insecure Configurations",
+			"descriptionText": "description",
+			"descriptionUrl":  "https://docs.kics.io",
+			"descriptionID":   queries[i].Query,
+			"platform":        queries[i].Platform,
+			"cwe":             "",
+		}
+	}
+
+	files := make(model.FileMetadatas, 0, 300)
+	for i := 0; i < 100; i++ {
+		files = append(files,
+			model.FileMetadata{Kind: model.KindDOCKER, Document: model.Document{"command": map[string]interface{}{}}},
+			model.FileMetadata{Kind: model.KindYAML, Document: model.Document{"kind": "Deployment"}},
+			model.FileMetadata{Kind: model.KindTerraform, Document: model.Document{"resource": map[string]interface{}{}}},
+		)
+	}
+	for i := range files {
+		files[i].ID = fmt.Sprintf("%s-%d", files[i].Kind, i)
+		files[i].FilePath = files[i].ID
+		files[i].OriginalData = "FROM alpine\nkind\nresource"
+		files[i].LinesOriginalData = utils.SplitLines(files[i].OriginalData)
+	}
+
+	c := newPayloadTestInspector(queries)
+	c.QueryLoader.platformLibraries["k8s"] = source.RegoLibraries{LibraryCode: "package generic.k8s"}
+
+	all := queryPartition{}
+	for i := range queries {
+		all.queries = append(all.queries, i)
+	}
+
+	benchmarks := []struct {
+		name       string
+		partitions []queryPartition
+	}{
+		{name: "partitioned", partitions: partitionQueries(queries)},
+		{name: "combined", partitions: []queryPartition{all}},
+	}
+
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			filesMap := files.ToMap()
+			for n := 0; n < b.N; n++ {
+				currentQuery := make(chan int64, len(queries))
+				results := make(chan QueryResult, len(queries))
+				for i := range bm.partitions {
+					if err := c.inspectPartition(context.Background(), "scanID", files, filesMap, []string{"."},
+						currentQuery, results, queries, &bm.partitions[i]); err != nil {
+						b.Fatal(err)
+					}
+				}
+				close(results)
+				for result := range results {
+					if result.err != nil {
+						b.Fatal(result.err)
+					}
+				}
+			}
+		})
+	}
+}
+
+func newPayloadTestInspector(queries []model.QueryMetadata) *Inspector {
+	return &Inspector{
+		QueryLoader: &QueryLoader{
+			QueriesMetadata: queries,
+			commonLibrary:   source.RegoLibraries{LibraryCode: "package generic.common"},
+			platformLibraries: map[string]source.RegoLibraries{
+				"dockerfile": {LibraryCode: "package generic.dockerfile"},
+				"terraform":  {LibraryCode: "package generic.terraform"},
+			},
+		},
+		vb:               DefaultVulnerabilityBuilder,
+		tracker:          &tracker.CITracker{},
+		failedQueries:    map[string]error{},
+		excludeResults:   map[string]bool{},
+		detector:         detector.NewDetectLine(3).Add(docker.DetectKindLine{}, model.KindDOCKER),
+		queryExecTimeout: 60 * time.Second,
+		numWorkers:       2,
+	}
+}
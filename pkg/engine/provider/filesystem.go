@@ -24,6 +24,7 @@ import (
 type FileSystemSourceProvider struct {
 	paths    []string
 	excludes map[string][]os.FileInfo
+	includes map[string]bool
 	mu       sync.RWMutex
 }
 
@@ -83,6 +84,46 @@ func (s *FileSystemSourceProvider) AddExcluded(excludePaths []string) error {
 	return nil
 }
 
+// AddIncluded restricts the File System Source Provider to the given files and directories,
+// the other files of the scan paths will not be scanned
+func (s *FileSystemSourceProvider) AddIncluded(includePaths []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.includes == nil {
+		s.includes = make(map[string]bool, len(includePaths))
+	}
+	for _, includePath := range includePaths {
+		absPath, err := filepath.Abs(includePath)
+		if err != nil {
+			return errors.Wrap(err, "failed to get included path")
+		}
+		s.includes[absPath] = true
+	}
+	return nil
+}
+
+// isIncluded checks if the path, or one of its parent directories, was included
+// all paths are included when no path was included
+func (s *FileSystemSourceProvider) isIncluded(path string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.includes == nil {
+		return true
+	}
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return false
+	}
+	for dir := absPath; ; dir = filepath.Dir(dir) {
+		if s.includes[dir] {
+			return true
+		}
+		if dir == filepath.Dir(dir) {
+			return false
+		}
+	}
+}
+
 // GetExcludePaths gets all the files that should be excluded
 func GetExcludePaths(pathExpressions string) ([]string, error) {
 	if strings.ContainsAny(pathExpressions, "*?[") {
@@ -130,6 +171,9 @@ func (s *FileSystemSourceProvider) GetSources(ctx context.Context,
 		}
 
 		if !fileInfo.IsDir() {
+			if !s.isIncluded(scanPath) {
+				continue
+			}
 			c, openFileErr := openScanFile(scanPath, extensions)
 			if openFileErr != nil {
 				if openFileErr == ErrNotSupportedFile || ignoreDamagedFiles(scanPath) {
@@ -163,6 +207,10 @@ func (s *FileSystemSourceProvider) walkDir(ctx context.Context, scanPath string,
 			return skipFolder
 		}
 
+		if !s.isIncluded(path) {
+			return nil
+		}
+
 		// ------------------ Helm resolver --------------------------------
 		if info.IsDir() {
 			excluded, errRes := resolverSink(ctx, strings.ReplaceAll(path, "\\", "/"))
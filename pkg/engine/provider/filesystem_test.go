@@ -693,6 +693,33 @@ func TestFileSystemSourceProvider_AddExcluded(t *testing.T) {
 	}
 }
 
+func TestFileSystemSourceProvider_AddIncluded(t *testing.T) {
+	dir := t.TempDir()
+	for _, file := range []string{"main.tf", "other.tf", filepath.Join("chart", "templates", "pod.yaml")} {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, file)), os.ModePerm))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, file), []byte{}, os.ModePerm))
+	}
+
+	fsystem, err := NewFileSystemSourceProvider([]string{dir}, []string{})
+	require.NoError(t, err)
+	require.True(t, fsystem.isIncluded(filepath.Join(dir, "other.tf")))
+
+	require.NoError(t, fsystem.AddIncluded([]string{filepath.Join(dir, "main.tf"), filepath.Join(dir, "chart")}))
+
+	sinked := make([]string, 0)
+	sink := func(ctx context.Context, filename string, content io.ReadCloser) error {
+		rel, errRel := filepath.Rel(dir, filename)
+		require.NoError(t, errRel)
+		sinked = append(sinked, filepath.ToSlash(rel))
+		return nil
+	}
+	resolverSink := func(ctx context.Context, filename string) ([]string, error) {
+		return []string{}, nil
+	}
+	require.NoError(t, fsystem.GetSources(context.Background(), model.Extensions{".tf": {}, ".yaml": {}}, sink, resolverSink))
+	require.ElementsMatch(t, []string{"main.tf", "chart/templates/pod.yaml"}, sinked)
+}
+
 var mockSink = func(ctx context.Context, filename string, content io.ReadCloser) error {
 	return nil
 }
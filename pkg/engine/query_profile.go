@@ -0,0 +1,73 @@
+package engine
+
+import (
+	"context"
+	"time"
+
+	"github.com/Checkmarx/kics/v2/pkg/model"
+	"github.com/open-policy-agent/opa/topdown"
+	"github.com/pkg/errors"
+)
+
+// EnableQueryProfile records the time, evaluations, results and status of each query the inspector runs
+func (c *Inspector) EnableQueryProfile() {
+	c.queryProfile = make(map[string]*model.QueryProfileEntry)
+}
+
+// GetQueryProfile returns the profile of the queries run so far, nil when it is not enabled
+func (c *Inspector) GetQueryProfile() *model.QueryProfile {
+	if c.queryProfile == nil {
+		return nil
+	}
+
+	c.queryProfileMu.Lock()
+	defer c.queryProfileMu.Unlock()
+	queries := make([]model.QueryProfileEntry, 0, len(c.queryProfile))
+	for _, entry := range c.queryProfile {
+		queries = append(queries, *entry)
+	}
+	return model.NewQueryProfile(queries)
+}
+
+// profileQuery adds a run of the query to its profile entry, the query runs once for each group of scanned files
+func (c *Inspector) profileQuery(query *model.QueryMetadata, duration time.Duration, evaluations, results int,
+	err error) {
+	if c.queryProfile == nil {
+		return
+	}
+
+	id, ok := query.Metadata["id"].(string)
+	if !ok {
+		id = query.Query
+	}
+
+	c.queryProfileMu.Lock()
+	defer c.queryProfileMu.Unlock()
+	entry, ok := c.queryProfile[id]
+	if !ok {
+		name, _ := query.Metadata["queryName"].(string)
+		entry = &model.QueryProfileEntry{
+			QueryID:   id,
+			QueryName: name,
+			Platform:  query.Platform,
+			Status:    model.QueryProfileSuccess,
+		}
+		c.queryProfile[id] = entry
+	}
+
+	entry.Duration += duration
+	entry.Evaluations += evaluations
+	entry.Results += results
+	switch {
+	case err == nil:
+	case isTimeout(err):
+		entry.Status = model.QueryProfileTimeout
+	case entry.Status != model.QueryProfileTimeout:
+		entry.Status = model.QueryProfileFailed
+	}
+}
+
+func isTimeout(err error) bool {
+	cause := errors.Cause(err)
+	return topdown.IsCancel(cause) || cause == context.DeadlineExceeded
+}
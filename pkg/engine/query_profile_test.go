@@ -0,0 +1,108 @@
+package engine
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Checkmarx/kics/v2/pkg/model"
+	"github.com/Checkmarx/kics/v2/pkg/utils"
+	"github.com/open-policy-agent/opa/topdown"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInspect_QueryProfile(t *testing.T) {
+	queries := []model.QueryMetadata{
+		{
+			Query:          "single_document",
+			Platform:       "terraform",
+			InputData:      "{}",
+			SingleDocument: true,
+			Metadata:       map[string]interface{}{"id": "single-document", "queryName": "Single Document"},
+			Content: `package Cx
+
+CxPolicy[result] {
+	document := input.document[i]
+	result := {
+		"documentId": document.id,
+		"searchKey": "resource",
+		"issueType": "IncorrectValue",
+		"keyExpectedValue": "expected",
+		"keyActualValue": "actual",
+	}
+}`,
+		},
+		{
+			Query:     "conflict",
+			Platform:  "dockerfile",
+			InputData: "{}",
+			Metadata:  map[string]interface{}{"id": "conflict", "queryName": "Conflict"},
+			Content: `package Cx
+
+value(x) = 1
+value(x) = 2
+
+CxPolicy[result] {
+	result := {"documentId": input.document[_].id, "value": value(1)}
+}`,
+		},
+		{
+			Query:     "not_evaluated",
+			Platform:  "k8s",
+			InputData: "{}",
+			Metadata:  map[string]interface{}{"id": "not-evaluated"},
+			Content:   "package Cx\n\nCxPolicy[result] {\n\tresult := input.document[_]\n}",
+		},
+	}
+
+	files := model.FileMetadatas{
+		{ID: "dockerfile", Document: model.Document{"command": map[string]interface{}{}}, Kind: model.KindDOCKER},
+		{ID: "main", Document: model.Document{"resource": map[string]interface{}{}}, Kind: model.KindTerraform},
+		{ID: "variables", Document: model.Document{"variable": map[string]interface{}{}}, Kind: model.KindTerraform},
+	}
+	for i := range files {
+		files[i].FilePath = files[i].ID
+		files[i].OriginalData = "resource"
+		files[i].LinesOriginalData = utils.SplitLines(files[i].OriginalData)
+	}
+
+	c := newPayloadTestInspector(queries)
+	require.Nil(t, c.GetQueryProfile())
+	c.EnableQueryProfile()
+
+	currentQuery := make(chan int64, len(queries))
+	_, err := c.Inspect(context.Background(), "scanID", files, []string{"."}, []string{"Dockerfile", "Terraform"},
+		currentQuery)
+	require.NoError(t, err)
+
+	profile := c.GetQueryProfile()
+	require.Len(t, profile.Queries, 2)
+	entries := make(map[string]model.QueryProfileEntry)
+	for _, entry := range profile.Queries {
+		require.Positive(t, entry.Duration)
+		entry.Duration = 0
+		entries[entry.QueryID] = entry
+	}
+
+	require.Equal(t, model.QueryProfileEntry{
+		QueryID:     "single-document",
+		QueryName:   "Single Document",
+		Platform:    "terraform",
+		Evaluations: 2,
+		Results:     2,
+		Status:      model.QueryProfileSuccess,
+	}, entries["single-document"])
+	require.Equal(t, model.QueryProfileEntry{
+		QueryID:     "conflict",
+		QueryName:   "Conflict",
+		Platform:    "dockerfile",
+		Evaluations: 1,
+		Status:      model.QueryProfileFailed,
+	}, entries["conflict"])
+}
+
+func Test_isTimeout(t *testing.T) {
+	require.True(t, isTimeout(errors.Wrap(&topdown.Error{Code: topdown.CancelErr}, "query executing timeout exited")))
+	require.True(t, isTimeout(errors.Wrap(context.DeadlineExceeded, "failed to decode")))
+	require.False(t, isTimeout(errors.Wrap(&topdown.Error{Code: topdown.ConflictErr}, "failed to evaluate query")))
+}
@@ -0,0 +1,124 @@
+package engine
+
+import (
+	"encoding/json"
+
+	"github.com/Checkmarx/kics/v2/pkg/cache"
+	"github.com/Checkmarx/kics/v2/pkg/model"
+	"github.com/open-policy-agent/opa/rego"
+)
+
+const documentIDKey = "documentId"
+
+// SetCache keeps the results of the queries reading one document at a time on the cache, so they are not evaluated again
+// on the files that did not change
+func (c *Inspector) SetCache(resultsCache *cache.Cache) {
+	c.cache = resultsCache
+}
+
+// cacheResults returns true when the results of the queries reading one document at a time are kept on the cache,
+// the coverage report needs every query to be evaluated
+func (c *Inspector) cacheResults() bool {
+	return c.cache != nil && !c.enableCoverageReport
+}
+
+// hashQuery hashes what the results of the query depend on, its code and data and the libraries it uses
+func (c *Inspector) hashQuery(query *model.QueryMetadata) string {
+	if !c.cacheResults() {
+		return ""
+	}
+
+	platformLibrary := c.QueryLoader.platformLibraries[query.Platform]
+	return cache.Hash(
+		query.Query,
+		query.Content,
+		query.InputData,
+		c.QueryLoader.commonLibrary.LibraryCode,
+		c.QueryLoader.commonLibrary.LibraryInputData,
+		platformLibrary.LibraryCode,
+		platformLibrary.LibraryInputData,
+	)
+}
+
+// resultItems returns the results of the query of the result set
+func resultItems(results rego.ResultSet) ([]interface{}, bool) {
+	if len(results) == 0 {
+		return nil, false
+	}
+	items, ok := results[0].Bindings["result"].([]interface{})
+	return items, ok
+}
+
+// positionalResults returns a copy of the results with the position of their document in place of its id
+func positionalResults(items []interface{}, ids []string) []interface{} {
+	positional := make([]interface{}, 0, len(items))
+	for _, item := range items {
+		result, ok := item.(map[string]interface{})
+		if !ok {
+			positional = append(positional, item)
+			continue
+		}
+
+		copied := make(map[string]interface{}, len(result))
+		for key, value := range result {
+			copied[key] = value
+		}
+		for i, id := range ids {
+			if result[documentIDKey] == id {
+				copied[documentIDKey] = i
+				break
+			}
+		}
+		positional = append(positional, copied)
+	}
+	return positional
+}
+
+// setResultDocuments replaces the position of the document of the cached results with its id
+func setResultDocuments(items []interface{}, ids []string) {
+	for _, item := range items {
+		result, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		position, ok := result[documentIDKey].(json.Number)
+		if !ok {
+			continue
+		}
+		if i, err := position.Int64(); err == nil && i >= 0 && int(i) < len(ids) {
+			result[documentIDKey] = ids[i]
+		}
+	}
+}
+
+// putFileResults keeps the results of the query evaluated over the documents of the files on the cache by file,
+// keeping none when a result does not belong to the documents of one of the files
+func (c *Inspector) putFileResults(queryHash string, files []*filePayload, items []interface{}) {
+	owners := make(map[string]int)
+	for i, file := range files {
+		for _, id := range file.ids {
+			owners[id] = i
+		}
+	}
+
+	fileItems := make([][]interface{}, len(files))
+	for _, item := range items {
+		result, ok := item.(map[string]interface{})
+		if !ok {
+			return
+		}
+		id, ok := result[documentIDKey].(string)
+		if !ok {
+			return
+		}
+		owner, ok := owners[id]
+		if !ok {
+			return
+		}
+		fileItems[owner] = append(fileItems[owner], item)
+	}
+
+	for i, file := range files {
+		c.cache.PutResults(c.cache.ResultKey(queryHash, file.hash), positionalResults(fileItems[i], file.ids))
+	}
+}
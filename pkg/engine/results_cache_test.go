@@ -0,0 +1,160 @@
+package engine
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/Checkmarx/kics/v2/pkg/cache"
+	"github.com/Checkmarx/kics/v2/pkg/model"
+	"github.com/Checkmarx/kics/v2/pkg/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInspect_CachedResults(t *testing.T) {
+	queries := []model.QueryMetadata{
+		{
+			Query:          "single_document",
+			Platform:       "terraform",
+			InputData:      "{}",
+			SingleDocument: true,
+			Content: `package Cx
+
+CxPolicy[result] {
+	document := input.document[i]
+	document.resource
+	result := {
+		"documentId": document.id,
+		"searchKey": "resource",
+		"issueType": "IncorrectValue",
+		"keyExpectedValue": "expected",
+		"keyActualValue": "actual",
+	}
+}`,
+		},
+	}
+
+	resultsCache, err := cache.New(t.TempDir())
+	require.NoError(t, err)
+
+	scan := func(ids ...string) []string {
+		files := model.FileMetadatas{
+			{ID: ids[0], FilePath: "main.tf", Document: model.Document{"resource": map[string]interface{}{}}},
+			{ID: ids[1], FilePath: "variables.tf", Document: model.Document{"variable": map[string]interface{}{}}},
+			{ID: ids[2], FilePath: "modules.tf", Document: model.Document{"resource": map[string]interface{}{}}},
+		}
+		for i := range files {
+			files[i].Kind = model.KindTerraform
+			files[i].OriginalData = "resource"
+			files[i].LinesOriginalData = utils.SplitLines(files[i].OriginalData)
+		}
+
+		c := newPayloadTestInspector(queries)
+		c.SetCache(resultsCache)
+
+		got, err := c.Inspect(context.Background(), "scanID", files, []string{"."}, []string{"Terraform"},
+			make(chan int64, len(queries)))
+		require.NoError(t, err)
+		require.Empty(t, c.GetFailedQueries())
+
+		fileIDs := make([]string, 0, len(got))
+		for i := range got {
+			fileIDs = append(fileIDs, got[i].FileID)
+		}
+		sort.Strings(fileIDs)
+		return fileIDs
+	}
+
+	require.Equal(t, []string{"1", "3"}, scan("1", "2", "3"))
+	require.Equal(t, cache.Stats{ResultMisses: 3}, resultsCache.Stats())
+
+	require.Equal(t, []string{"4", "6"}, scan("4", "5", "6"), "the cached results should point to the new documents")
+	require.Equal(t, cache.Stats{ResultHits: 3, ResultMisses: 3}, resultsCache.Stats())
+}
+
+func TestInspect_CachedFileResults(t *testing.T) {
+	queries := []model.QueryMetadata{
+		{
+			Query:     "file_local",
+			Platform:  "terraform",
+			InputData: "{}",
+			Metadata:  map[string]interface{}{"id": "file-local", "queryName": "file_local"},
+			Content: `package Cx
+
+CxPolicy[result] {
+	document := input.document[i]
+	document.resource
+	result := {
+		"documentId": document.id,
+		"searchKey": "resource",
+		"issueType": "IncorrectValue",
+		"keyExpectedValue": "expected",
+		"keyActualValue": "actual",
+	}
+}`,
+		},
+		{
+			Query:     "cross_file",
+			Platform:  "terraform",
+			InputData: "{}",
+			Metadata:  map[string]interface{}{"id": "cross-file", "queryName": "cross_file"},
+			Content: `package Cx
+
+CxPolicy[result] {
+	document := input.document[i]
+	document.variable
+	count([d | d := input.document[_]; d.resource]) > 1
+	result := {
+		"documentId": document.id,
+		"searchKey": "variable",
+		"issueType": "IncorrectValue",
+		"keyExpectedValue": "expected",
+		"keyActualValue": "actual",
+	}
+}`,
+		},
+	}
+
+	resultsCache, err := cache.New(t.TempDir())
+	require.NoError(t, err)
+
+	scan := func(documents map[string]model.Document) []string {
+		files := make(model.FileMetadatas, 0, len(documents))
+		for _, path := range []string{"main.tf", "variables.tf", "modules.tf"} {
+			files = append(files, model.FileMetadata{ID: documents[path]["id"].(string), FilePath: path,
+				Document: documents[path], Kind: model.KindTerraform, OriginalData: "resource"})
+		}
+		for i := range files {
+			files[i].LinesOriginalData = utils.SplitLines(files[i].OriginalData)
+		}
+
+		c := newPayloadTestInspector(queries)
+		c.SetCache(resultsCache)
+
+		got, err := c.Inspect(context.Background(), "scanID", files, []string{"."}, []string{"Terraform"},
+			make(chan int64, len(queries)))
+		require.NoError(t, err)
+		require.Empty(t, c.GetFailedQueries())
+
+		fileIDs := make([]string, 0, len(got))
+		for i := range got {
+			fileIDs = append(fileIDs, got[i].QueryName+":"+got[i].FileID)
+		}
+		sort.Strings(fileIDs)
+		return fileIDs
+	}
+
+	require.Equal(t, []string{"cross_file:2", "file_local:1", "file_local:3"}, scan(map[string]model.Document{
+		"main.tf":      {"id": "1", "resource": map[string]interface{}{}},
+		"variables.tf": {"id": "2", "variable": map[string]interface{}{}},
+		"modules.tf":   {"id": "3", "resource": map[string]interface{}{}},
+	}))
+	require.Equal(t, cache.Stats{ResultMisses: 3}, resultsCache.Stats(), "only the file local query should be cached")
+
+	require.Equal(t, []string{"file_local:4"}, scan(map[string]model.Document{
+		"main.tf":      {"id": "4", "resource": map[string]interface{}{}},
+		"variables.tf": {"id": "5", "variable": map[string]interface{}{}},
+		"modules.tf":   {"id": "6", "module": map[string]interface{}{}},
+	}), "only the changed file should be evaluated again")
+	require.Equal(t, cache.Stats{ResultHits: 2, ResultMisses: 4}, resultsCache.Stats())
+}
@@ -48,6 +48,7 @@ type Inspector struct {
 	regexQueries          []RegexQuery
 	allowRules            []AllowRule
 	vulnerabilities       []model.Vulnerability
+	suppressed            []model.Vulnerability
 	queryExecutionTimeout time.Duration
 	foundLines            []int
 	mu                    sync.RWMutex
@@ -294,6 +295,13 @@ func CompileRegex(allowRules []AllowRule) ([]AllowRule, error) {
 	return allowRules, nil
 }
 
+// GetSuppressedResults returns the secrets excluded by their similarity ID or ignored by comments
+func (c *Inspector) GetSuppressedResults() []model.Vulnerability {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.suppressed
+}
+
 func (c *Inspector) GetQueriesLength() int {
 	return len(c.regexQueries)
 }
@@ -514,33 +522,38 @@ func (c *Inspector) addVulnerability(basePaths []string, file *model.FileMetadat
 	}
 
 	c.mu.Lock()
-	if _, ok := c.excludeResults[engine.PtrStringToString(simID)]; !ok {
-		linesVuln := c.detector.GetAdjacent(file, lineNumber+1)
-		if !ignoreLine(linesVuln.Line, file.LinesIgnore) {
-			vuln := model.Vulnerability{
-				QueryID:          query.ID,
-				QueryName:        SecretsQueryMetadata["queryName"] + " - " + query.Name,
-				SimilarityID:     engine.PtrStringToString(simID),
-				FileID:           file.ID,
-				FileName:         file.FilePath,
-				Line:             linesVuln.Line,
-				VulnLines:        hideSecret(&linesVuln, issueLine, query, &c.SecretTracker),
-				IssueType:        "RedundantAttribute",
-				Platform:         SecretsQueryMetadata["platform"],
-				CWE:              SecretsQueryMetadata["cwe"],
-				Severity:         model.SeverityHigh,
-				QueryURI:         SecretsQueryMetadata["descriptionUrl"],
-				Category:         SecretsQueryMetadata["category"],
-				Description:      SecretsQueryMetadata["descriptionText"],
-				DescriptionID:    SecretsQueryMetadata["descriptionID"],
-				KeyExpectedValue: "Hardcoded secret key should not appear in source",
-				KeyActualValue:   "Hardcoded secret key appears in source",
-				CloudProvider:    SecretsQueryMetadata["cloudProvider"],
-			}
-			c.vulnerabilities = append(c.vulnerabilities, vuln)
-		}
+	defer c.mu.Unlock()
+	linesVuln := c.detector.GetAdjacent(file, lineNumber+1)
+	vuln := model.Vulnerability{
+		QueryID:          query.ID,
+		QueryName:        SecretsQueryMetadata["queryName"] + " - " + query.Name,
+		SimilarityID:     engine.PtrStringToString(simID),
+		FileID:           file.ID,
+		FileName:         file.FilePath,
+		Line:             linesVuln.Line,
+		VulnLines:        hideSecret(&linesVuln, issueLine, query, &c.SecretTracker),
+		IssueType:        "RedundantAttribute",
+		Platform:         SecretsQueryMetadata["platform"],
+		CWE:              SecretsQueryMetadata["cwe"],
+		Severity:         model.SeverityHigh,
+		QueryURI:         SecretsQueryMetadata["descriptionUrl"],
+		Category:         SecretsQueryMetadata["category"],
+		Description:      SecretsQueryMetadata["descriptionText"],
+		DescriptionID:    SecretsQueryMetadata["descriptionID"],
+		KeyExpectedValue: "Hardcoded secret key should not appear in source",
+		KeyActualValue:   "Hardcoded secret key appears in source",
+		CloudProvider:    SecretsQueryMetadata["cloudProvider"],
+	}
+	// suppressed secrets are kept apart, they are still hidden from the lines of the other results
+	if _, ok := c.excludeResults[vuln.SimilarityID]; ok {
+		vuln.Suppression = model.SuppressionExternal
+		c.suppressed = append(c.suppressed, vuln)
+	} else if ignoreLine(linesVuln.Line, file.LinesIgnore) {
+		vuln.Suppression = model.SuppressionInSource
+		c.suppressed = append(c.suppressed, vuln)
+	} else {
+		c.vulnerabilities = append(c.vulnerabilities, vuln)
 	}
-	c.mu.Unlock()
 }
 
 // CheckEntropyInterval - verifies if a given token's entropy is within expected bounds
@@ -397,13 +397,14 @@ func ReadQuery(queryDir string) (model.QueryMetadata, error) {
 	experimental := getExperimental(metadata["experimental"])
 
 	return model.QueryMetadata{
-		Query:        path.Base(filepath.ToSlash(queryDir)),
-		Content:      string(queryContent),
-		Metadata:     metadata,
-		Platform:     platform,
-		InputData:    inputData,
-		Aggregation:  aggregation,
-		Experimental: experimental,
+		Query:          path.Base(filepath.ToSlash(queryDir)),
+		Content:        string(queryContent),
+		Metadata:       metadata,
+		Platform:       platform,
+		InputData:      inputData,
+		Aggregation:    aggregation,
+		Experimental:   experimental,
+		SingleDocument: getSingleDocument(metadata["singleDocument"]),
 	}, nil
 }
 
@@ -480,6 +481,17 @@ func getExperimental(experimental interface{}) bool {
 	}
 }
 
+func getSingleDocument(singleDocument interface{}) bool {
+	switch value := singleDocument.(type) {
+	case bool:
+		return value
+	case string:
+		return value == "true"
+	default:
+		return false
+	}
+}
+
 func readInputData(inputDataPath string) (string, error) {
 	inputData, err := os.ReadFile(filepath.Clean(inputDataPath))
 	if err != nil {
@@ -197,6 +197,8 @@ var DefaultVulnerabilityBuilder = func(ctx *QueryContext,
 		CloudProvider:    getCloudProvider(overrideKey, vObj, &logWithFields),
 		Remediation:      PtrStringToString(mustMapKeyToString(vObj, "remediation")),
 		RemediationType:  PtrStringToString(mustMapKeyToString(vObj, "remediationType")),
+		ModuleCall:       file.ModuleCall,
+		ChangeAction:     getChangeAction(&file, searchKey),
 	}, nil
 }
 
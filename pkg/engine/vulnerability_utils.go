@@ -3,7 +3,9 @@ package engine
 import (
 	"encoding/json"
 	"fmt"
+	"regexp"
 	"strconv"
+	"strings"
 
 	dec "github.com/Checkmarx/kics/v2/pkg/detector"
 	"github.com/Checkmarx/kics/v2/pkg/model"
@@ -12,6 +14,9 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
+// planResourceRegex matches the resource type and name at the start of a Terraform search key
+var planResourceRegex = regexp.MustCompile(`^(?:resource\.)?([^.\[\]]+)\[([^\]]+)\]`)
+
 type searchLineCalculator struct {
 	lineNr               int
 	vObj                 map[string]interface{}
@@ -159,3 +164,31 @@ func getSeverity(severity string) model.Severity {
 	}
 	return ""
 }
+
+// getChangeAction returns the action planned for the resource of the search key when the file is a Terraform plan,
+// the change is only kept in the line information document since the document of the queries leaves it out
+func getChangeAction(file *model.FileMetadata, searchKey string) string {
+	resources, ok := file.LineInfoDocument["resource"].(map[string]interface{})
+	if !ok || file.Kind != model.KindJSON {
+		return ""
+	}
+	match := planResourceRegex.FindStringSubmatch(searchKey)
+	if match == nil {
+		return ""
+	}
+	resourceName := strings.TrimSuffix(strings.TrimPrefix(match[2], "{{"), "}}")
+	resourceType, ok := resources[match[1]].(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	resource, ok := resourceType[resourceName].(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	change, ok := resource[model.KicsChangeKey].(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	action, _ := change["action"].(string)
+	return action
+}
@@ -5,6 +5,7 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/Checkmarx/kics/v2/pkg/model"
 	"github.com/stretchr/testify/require"
 )
 
@@ -170,3 +171,57 @@ func Test_PtrStringToString(t *testing.T) {
 		})
 	}
 }
+
+func Test_getChangeAction(t *testing.T) {
+	planDocument := model.Document{
+		"resource": map[string]interface{}{
+			"aws_s3_bucket": map[string]interface{}{
+				"logs": map[string]interface{}{
+					"acl": "private",
+					model.KicsChangeKey: map[string]interface{}{
+						"action": model.ChangeActionUpdate,
+					},
+				},
+				"assets": map[string]interface{}{
+					"acl": "public-read",
+				},
+			},
+		},
+	}
+	tests := []struct {
+		name      string
+		file      model.FileMetadata
+		searchKey string
+		want      string
+	}{
+		{
+			name:      "plan resource with change",
+			file:      model.FileMetadata{Kind: model.KindJSON, LineInfoDocument: planDocument},
+			searchKey: "aws_s3_bucket[logs].acl",
+			want:      model.ChangeActionUpdate,
+		},
+		{
+			name:      "plan resource with placeholder name",
+			file:      model.FileMetadata{Kind: model.KindJSON, LineInfoDocument: planDocument},
+			searchKey: "resource.aws_s3_bucket[{{logs}}]",
+			want:      model.ChangeActionUpdate,
+		},
+		{
+			name:      "plan resource without change",
+			file:      model.FileMetadata{Kind: model.KindJSON, LineInfoDocument: planDocument},
+			searchKey: "aws_s3_bucket[assets].acl",
+			want:      "",
+		},
+		{
+			name:      "terraform file",
+			file:      model.FileMetadata{Kind: model.KindTerraform, LineInfoDocument: planDocument},
+			searchKey: "aws_s3_bucket[logs].acl",
+			want:      "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, getChangeAction(&tt.file, tt.searchKey))
+		})
+	}
+}
@@ -0,0 +1,121 @@
+// Package git reads the changes of a local git repository, no remote is ever contacted
+package git
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+// Changes contains the files of a repository that changed since a git reference
+type Changes struct {
+	// Root is the absolute path of the repository root
+	Root string
+	// Ref is the git reference the changes were computed against
+	Ref string
+	// Base is the commit the working tree is compared with, the merge base of Ref and HEAD
+	Base string
+	// Files are the absolute paths of the changed files, deleted files included
+	Files []string
+}
+
+// ChangedFiles returns the files of the repository containing path that changed since ref. Committed, staged,
+// unstaged and untracked (not ignored) files are considered, compared with the merge base of ref and HEAD
+func ChangedFiles(ctx context.Context, path, ref string) (*Changes, error) {
+	root, base, err := repository(ctx, path, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := &Changes{
+		Root:  root,
+		Ref:   ref,
+		Base:  base,
+		Files: make([]string, 0),
+	}
+
+	diff, err := run(ctx, root, "diff", "--name-only", "--no-renames", "-z", changes.Base, "--")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list changed files")
+	}
+	untracked, err := run(ctx, root, "ls-files", "--others", "--exclude-standard", "-z")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list untracked files")
+	}
+
+	seen := make(map[string]bool)
+	for _, name := range strings.Split(diff+untracked, "\x00") {
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		changes.Files = append(changes.Files, filepath.Join(root, filepath.FromSlash(name)))
+	}
+
+	log.Debug().Msgf("Found %d files changed since %s (%s) in %s", len(changes.Files), ref, changes.Base, root)
+
+	return changes, nil
+}
+
+// repository returns the root of the repository containing path and the merge base of ref and HEAD
+func repository(ctx context.Context, path, ref string) (root, base string, err error) {
+	dir, err := directory(path)
+	if err != nil {
+		return "", "", err
+	}
+	if root, err = repositoryRoot(ctx, dir); err != nil {
+		return "", "", errors.Wrapf(err, "path %s is not inside a git repository", path)
+	}
+
+	base, err = run(ctx, root, "merge-base", ref, "HEAD")
+	if err != nil {
+		return "", "", errors.Wrapf(err, "failed to find the merge base of '%s' and HEAD", ref)
+	}
+	return root, strings.TrimSpace(base), nil
+}
+
+// directory returns the absolute path of the directory of path, path itself when it is a directory
+func directory(path string) (string, error) {
+	dir, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	info, err := os.Stat(dir)
+	if err != nil {
+		return "", err
+	}
+	if !info.IsDir() {
+		dir = filepath.Dir(dir)
+	}
+	return dir, nil
+}
+
+// repositoryRoot returns the root of the repository containing the directory
+func repositoryRoot(ctx context.Context, dir string) (string, error) {
+	root, err := run(ctx, dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return "", err
+	}
+	return filepath.Clean(strings.TrimSpace(root)), nil
+}
+
+func run(ctx context.Context, dir string, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "git", args...) //#nosec
+	cmd.Dir = dir
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", errors.New(msg)
+		}
+		return "", err
+	}
+	return stdout.String(), nil
+}
@@ -0,0 +1,81 @@
+package git
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// initTestRepository creates a git repository in dir with a first commit tagged "base"
+func initTestRepository(t *testing.T, dir string, files map[string]string) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	writeFiles(t, dir, files)
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"add", "-A"},
+		{"-c", "user.name=kics", "-c", "user.email=kics@checkmarx.com", "commit", "-q", "-m", "base"},
+		{"tag", "base"},
+	} {
+		_, err := run(context.Background(), dir, args...)
+		require.NoError(t, err)
+	}
+}
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), os.ModePerm))
+		require.NoError(t, os.WriteFile(path, []byte(content), os.ModePerm))
+	}
+}
+
+func TestChangedFiles(t *testing.T) {
+	dir := t.TempDir()
+	initTestRepository(t, dir, map[string]string{
+		"main.tf":         "resource \"aws_s3_bucket\" \"b\" {}",
+		"deleted.tf":      "",
+		"docs/README.md":  "",
+		"sub/values.yaml": "a: 1",
+	})
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".gitignore"), []byte("ignored.tf\n"), os.ModePerm))
+	_, err := run(context.Background(), dir, "add", ".gitignore")
+	require.NoError(t, err)
+	_, err = run(context.Background(), dir,
+		"-c", "user.name=kics", "-c", "user.email=kics@checkmarx.com", "commit", "-q", "-m", "ignore")
+	require.NoError(t, err)
+
+	writeFiles(t, dir, map[string]string{
+		"main.tf":         "resource \"aws_s3_bucket\" \"c\" {}",
+		"sub/new.yaml":    "b: 2",
+		"sub/ignored.tf":  "",
+		"sub/values.yaml": "a: 1",
+	})
+	require.NoError(t, os.Remove(filepath.Join(dir, "deleted.tf")))
+
+	changes, err := ChangedFiles(context.Background(), filepath.Join(dir, "sub"), "base")
+	require.NoError(t, err)
+
+	root, err := filepath.EvalSymlinks(dir)
+	require.NoError(t, err)
+	require.Equal(t, root, changes.Root)
+	require.Equal(t, "base", changes.Ref)
+	require.Len(t, changes.Base, 40)
+	require.ElementsMatch(t, []string{
+		filepath.Join(root, ".gitignore"),
+		filepath.Join(root, "deleted.tf"),
+		filepath.Join(root, "main.tf"),
+		filepath.Join(root, "sub", "new.yaml"),
+	}, changes.Files)
+
+	_, err = ChangedFiles(context.Background(), dir, "unknown-ref")
+	require.Error(t, err)
+
+	_, err = ChangedFiles(context.Background(), t.TempDir(), "base")
+	require.Error(t, err)
+}
@@ -0,0 +1,173 @@
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+var hunkHeaderRegex = regexp.MustCompile(`^@@ -\d+(?:,\d+)? \+(\d+)(?:,(\d+))? @@`)
+
+// LineRange is a range of lines, both ends included
+type LineRange struct {
+	Start int
+	End   int
+}
+
+// ChangedLines maps the files of a diff to their added or modified lines. Files are the paths of the diff as given by
+// ParseDiff, usually relative to the repository root, and the absolute paths of the files once resolved
+type ChangedLines map[string][]LineRange
+
+// Contains checks if the line of the file was added or modified, the absolute path of the file has to be one of the
+// resolved paths of the diff
+func (c ChangedLines) Contains(file string, line int) bool {
+	absFile, err := filepath.Abs(file)
+	if err != nil {
+		absFile = file
+	}
+	if realFile, errEval := filepath.EvalSymlinks(absFile); errEval == nil {
+		absFile = realFile
+	}
+	for _, r := range c[filepath.ToSlash(filepath.Clean(absFile))] {
+		if line >= r.Start && line <= r.End {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseDiff reads the added or modified lines of a unified diff, deleted files are ignored
+func ParseDiff(reader io.Reader) (ChangedLines, error) {
+	lines := make(ChangedLines)
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), math.MaxInt32)
+
+	file := ""
+	newLine, remaining := 0, 0
+	for scanner.Scan() {
+		text := scanner.Text()
+		switch {
+		case remaining > 0 && (strings.HasPrefix(text, "+") || strings.HasPrefix(text, " ") || text == ""):
+			if strings.HasPrefix(text, "+") && file != "" {
+				lines.add(file, newLine, newLine)
+			}
+			newLine++
+			remaining--
+		case remaining > 0 && (strings.HasPrefix(text, "-") || strings.HasPrefix(text, "\\")):
+			// removed lines and "\ No newline at end of file" do not exist in the new file
+		case strings.HasPrefix(text, "+++ "):
+			file = diffFileName(strings.TrimPrefix(text, "+++ "))
+		case strings.HasPrefix(text, "@@ "):
+			match := hunkHeaderRegex.FindStringSubmatch(text)
+			if match == nil {
+				return nil, errors.Errorf("invalid hunk header: %s", text)
+			}
+			newLine, _ = strconv.Atoi(match[1])
+			remaining = 1
+			if match[2] != "" {
+				remaining, _ = strconv.Atoi(match[2])
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to read diff")
+	}
+	return lines, nil
+}
+
+// ReadDiffFile reads the added or modified lines of the unified diff file, resolving its paths against the root of the
+// repository containing repositoryPath or, when it is not inside a git repository, against repositoryPath itself
+func ReadDiffFile(ctx context.Context, path, repositoryPath string) (ChangedLines, error) {
+	content, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+	lines, err := ParseDiff(bytes.NewReader(content))
+	if err != nil {
+		return nil, err
+	}
+
+	dir, err := directory(repositoryPath)
+	if err != nil {
+		return nil, err
+	}
+	root, err := repositoryRoot(ctx, dir)
+	if err != nil {
+		log.Debug().Msgf("Resolving the paths of the diff against %s: %s", dir, err)
+		root = dir
+		if realDir, errEval := filepath.EvalSymlinks(dir); errEval == nil {
+			root = realDir
+		}
+	}
+	return lines.resolve(root), nil
+}
+
+// ChangedLinesSince returns the lines added or modified in the repository containing path since ref, compared with
+// the merge base of ref and HEAD. Untracked (not ignored) files are entirely added
+func ChangedLinesSince(ctx context.Context, path, ref string) (ChangedLines, error) {
+	root, base, err := repository(ctx, path, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	diff, err := run(ctx, root, "diff", "--no-renames", "--no-color", "--no-ext-diff", "-U0", base, "--")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get the diff")
+	}
+	lines, err := ParseDiff(strings.NewReader(diff))
+	if err != nil {
+		return nil, err
+	}
+
+	untracked, err := run(ctx, root, "ls-files", "--others", "--exclude-standard", "-z")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list untracked files")
+	}
+	for _, name := range strings.Split(untracked, "\x00") {
+		if name != "" {
+			lines.add(name, 1, math.MaxInt32)
+		}
+	}
+
+	return lines.resolve(root), nil
+}
+
+// resolve returns the lines with the paths of the diff made absolute, so they only match the files of the repository
+func (c ChangedLines) resolve(root string) ChangedLines {
+	resolved := make(ChangedLines, len(c))
+	for name, ranges := range c {
+		resolved[filepath.ToSlash(filepath.Join(root, filepath.FromSlash(name)))] = ranges
+	}
+	return resolved
+}
+
+func (c ChangedLines) add(file string, start, end int) {
+	ranges := c[file]
+	if len(ranges) > 0 && ranges[len(ranges)-1].End+1 == start {
+		ranges[len(ranges)-1].End = end
+		return
+	}
+	c[file] = append(ranges, LineRange{Start: start, End: end})
+}
+
+// diffFileName returns the path of the new file of a diff header, without the "b/" prefix used by git
+func diffFileName(name string) string {
+	if idx := strings.Index(name, "\t"); idx >= 0 {
+		name = name[:idx]
+	}
+	if name == "/dev/null" {
+		return ""
+	}
+	name = strings.Trim(name, "\"")
+	return strings.TrimPrefix(name, "b/")
+}
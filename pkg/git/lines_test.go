@@ -0,0 +1,121 @@
+package git
+
+import (
+	"context"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testDiff = `diff --git a/terraform/main.tf b/terraform/main.tf
+index 3b18e51..a5c1966 100644
+--- a/terraform/main.tf
++++ b/terraform/main.tf
+@@ -1,4 +1,5 @@
+ resource "aws_s3_bucket" "b" {
+-  bucket = "old"
++  bucket = "new"
++  acl    = "public-read"
+ }
+ 
+@@ -10,0 +12,2 @@ resource "aws_s3_bucket" "c" {
++++ tags = {}
++}
+diff --git a/removed.yaml b/removed.yaml
+deleted file mode 100644
+--- a/removed.yaml
++++ /dev/null
+@@ -1,2 +0,0 @@
+-a: 1
+-b: 2
+diff --git a/new.yaml b/new.yaml
+new file mode 100644
+--- /dev/null
++++ b/new.yaml
+@@ -0,0 +1 @@
++a: 1
+\ No newline at end of file
+`
+
+func TestParseDiff(t *testing.T) {
+	lines, err := ParseDiff(strings.NewReader(testDiff))
+	require.NoError(t, err)
+	require.Equal(t, ChangedLines{
+		"terraform/main.tf": {{Start: 2, End: 3}, {Start: 12, End: 13}},
+		"new.yaml":          {{Start: 1, End: 1}},
+	}, lines)
+
+	_, err = ParseDiff(strings.NewReader("+++ b/main.tf\n@@ invalid @@\n"))
+	require.Error(t, err)
+}
+
+func TestChangedLines_Contains(t *testing.T) {
+	root, err := filepath.Abs("project")
+	require.NoError(t, err)
+	lines := ChangedLines{"terraform/main.tf": {{Start: 2, End: 3}}}.resolve(root)
+
+	require.True(t, lines.Contains(filepath.Join(root, "terraform", "main.tf"), 2))
+	require.True(t, lines.Contains(filepath.Join("project", "terraform", "..", "terraform", "main.tf"), 3))
+	require.False(t, lines.Contains(filepath.Join(root, "terraform", "main.tf"), 4))
+	require.False(t, lines.Contains(filepath.Join(root, "other", "terraform", "main.tf"), 2))
+	require.False(t, lines.Contains(filepath.Join("terraform", "main.tf"), 2))
+	require.False(t, lines.Contains("main.tf", 2))
+}
+
+func TestReadDiffFile(t *testing.T) {
+	dir := t.TempDir()
+	initTestRepository(t, dir, map[string]string{
+		"main.tf":           "a\n",
+		"modules/x/main.tf": "a\nb\nc\n",
+	})
+	diffFile := filepath.Join(t.TempDir(), "changes.diff")
+	require.NoError(t, os.WriteFile(diffFile, []byte("--- a/main.tf\n+++ b/main.tf\n@@ -1 +1,3 @@\n a\n+b\n+c\n"),
+		os.ModePerm))
+
+	lines, err := ReadDiffFile(context.Background(), diffFile, filepath.Join(dir, "modules"))
+	require.NoError(t, err)
+	require.True(t, lines.Contains(filepath.Join(dir, "main.tf"), 3))
+	require.False(t, lines.Contains(filepath.Join(dir, "modules", "x", "main.tf"), 3),
+		"a file sharing the name of a changed file should not match")
+
+	outside := t.TempDir()
+	writeFiles(t, outside, map[string]string{"main.tf": "a\nb\nc\n"})
+	lines, err = ReadDiffFile(context.Background(), diffFile, outside)
+	require.NoError(t, err)
+	require.True(t, lines.Contains(filepath.Join(outside, "main.tf"), 3),
+		"the paths should be relative to the scan path outside of a git repository")
+
+	_, err = ReadDiffFile(context.Background(), filepath.Join(dir, "missing.diff"), dir)
+	require.Error(t, err)
+}
+
+func TestChangedLinesSince(t *testing.T) {
+	dir := t.TempDir()
+	initTestRepository(t, dir, map[string]string{
+		"main.tf": "line1\nline2\nline3\n",
+	})
+	writeFiles(t, dir, map[string]string{
+		"main.tf":     "line1\nchanged\nline3\nadded\n",
+		"new/main.tf": "new\n",
+	})
+
+	lines, err := ChangedLinesSince(context.Background(), dir, "base")
+	require.NoError(t, err)
+
+	root, err := filepath.EvalSymlinks(dir)
+	require.NoError(t, err)
+	require.Equal(t, ChangedLines{
+		filepath.ToSlash(filepath.Join(root, "main.tf")):        {{Start: 2, End: 2}, {Start: 4, End: 4}},
+		filepath.ToSlash(filepath.Join(root, "new", "main.tf")): {{Start: 1, End: math.MaxInt32}},
+	}, lines)
+	require.True(t, lines.Contains(filepath.Join(dir, "new", "main.tf"), 1))
+	require.False(t, lines.Contains(filepath.Join(dir, "main.tf"), 3))
+
+	require.NoError(t, os.Remove(filepath.Join(dir, "new", "main.tf")))
+	_, err = ChangedLinesSince(context.Background(), dir, "unknown-ref")
+	require.Error(t, err)
+}
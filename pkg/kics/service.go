@@ -7,6 +7,7 @@ import (
 	"io"
 	"sync"
 
+	"github.com/Checkmarx/kics/v2/pkg/cache"
 	"github.com/Checkmarx/kics/v2/pkg/engine"
 	"github.com/Checkmarx/kics/v2/pkg/engine/provider"
 	"github.com/Checkmarx/kics/v2/pkg/engine/secrets"
@@ -36,6 +37,12 @@ type Storage interface {
 	GetScanSummary(ctx context.Context, scanIDs []string) ([]model.SeveritySummary, error)
 }
 
+// ResultSink is the interface that wraps the Write method
+// Write should receive the vulnerabilities found by a query as soon as the query finishes
+type ResultSink interface {
+	Write(ctx context.Context, vulnerabilities []model.Vulnerability) error
+}
+
 // Tracker is the interface that wraps the basic methods: TrackFileFound and TrackFileParse
 // TrackFileFound should increment the number of files to be scanned
 // TrackFileParse should increment the number of files parsed successfully to be scanned
@@ -49,15 +56,18 @@ type Tracker interface {
 
 // Service is a struct that contains a SourceProvider to receive sources, a storage to save and retrieve scanning informations
 // a parser to parse and provide files in format that KICS understand, a inspector that runs the scanning and a tracker to
-// update scanning numbers
+// update scanning numbers. When a sink is set, the vulnerabilities are written to it as they are found instead of being
+// saved on the storage. When a cache is set, the files found on it are not parsed again
 type Service struct {
 	SourceProvider   provider.SourceProvider
 	Storage          Storage
+	Sink             ResultSink
 	Parser           *parser.Parser
 	Inspector        *engine.Inspector
 	SecretsInspector *secrets.Inspector
 	Tracker          Tracker
 	Resolver         *resolver.Resolver
+	Cache            *cache.Cache
 	files            model.FileMetadatas
 	MaxFileSize      int
 }
@@ -105,6 +115,11 @@ func (s *Service) StartScan(
 		errCh <- errors.Wrap(err, "failed to inspect secrets")
 	}
 
+	if s.Sink != nil {
+		s.streamVulnerabilities(ctx, scanID, secretsVulnerabilities, errCh, currentQuery)
+		return
+	}
+
 	vulnerabilities, err := s.Inspector.Inspect(
 		ctx,
 		scanID,
@@ -126,6 +141,37 @@ func (s *Service) StartScan(
 	}
 }
 
+// streamVulnerabilities writes the secrets vulnerabilities and then the vulnerabilities of each query to the sink as
+// soon as the query finishes, so they are never held together in memory
+func (s *Service) streamVulnerabilities(
+	ctx context.Context,
+	scanID string,
+	secretsVulnerabilities []model.Vulnerability,
+	errCh chan<- error,
+	currentQuery chan<- int64) {
+	write := func(vulnerabilities []model.Vulnerability) error {
+		updateMaskedSecrets(&vulnerabilities, s.SecretsInspector.SecretTracker)
+		return s.Sink.Write(ctx, vulnerabilities)
+	}
+
+	if err := write(secretsVulnerabilities); err != nil {
+		errCh <- errors.Wrap(err, "failed to write vulnerabilities")
+		return
+	}
+
+	if err := s.Inspector.InspectStream(
+		ctx,
+		scanID,
+		s.files,
+		s.SourceProvider.GetBasePaths(),
+		s.Parser.Platform,
+		currentQuery,
+		write,
+	); err != nil {
+		errCh <- errors.Wrap(err, "failed to inspect files")
+	}
+}
+
 // Content keeps the content of the file and the number of lines
 type Content struct {
 	Content    *[]byte
@@ -215,6 +261,8 @@ func prepareScanDocumentRoot(body interface{}, kind model.FileKind) {
 
 func prepareScanDocumentValue(bodyType map[string]interface{}, kind model.FileKind) {
 	delete(bodyType, "_kics_lines")
+	// the change planned for a Terraform plan resource holds its previous values, which the queries should not see
+	delete(bodyType, model.KicsChangeKey)
 	for key, v := range bodyType {
 		switch value := v.(type) {
 		case map[string]interface{}:
@@ -231,6 +279,12 @@ func prepareScanDocumentValue(bodyType map[string]interface{}, kind model.FileKi
 	}
 }
 
+// MaskSecrets hides the secrets found by the secrets inspector in the lines of vulnerabilities that are not saved by
+// the services, such as the suppressed ones
+func MaskSecrets(vulnerabilities []model.Vulnerability, secretsInspector *secrets.Inspector) {
+	updateMaskedSecrets(&vulnerabilities, secretsInspector.SecretTracker)
+}
+
 func updateMaskedSecrets(vulnerabilities *[]model.Vulnerability, maskedSecretsTracked []secrets.SecretTracker) {
 	for idx := range *vulnerabilities {
 		for _, secretT := range maskedSecretsTracked {
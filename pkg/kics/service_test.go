@@ -20,6 +20,7 @@ import (
 	yamlParser "github.com/Checkmarx/kics/v2/pkg/parser/yaml"
 	"github.com/Checkmarx/kics/v2/pkg/resolver"
 	"github.com/Checkmarx/kics/v2/pkg/resolver/helm"
+	"github.com/stretchr/testify/require"
 )
 
 // TestService tests the functions [GetVulnerabilities(), GetScanSummary(),StartScan()] and all the methods called by them
@@ -143,6 +144,58 @@ func TestService(t *testing.T) { //nolint
 	}
 }
 
+type mockSink struct {
+	mu      sync.Mutex
+	batches int
+}
+
+func (m *mockSink) Write(_ context.Context, _ []model.Vulnerability) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.batches++
+	return nil
+}
+
+// TestService_StartScanWithSink tests that the vulnerabilities are written to the sink instead of the storage
+func TestService_StartScanWithSink(t *testing.T) {
+	mockParser, mockFilesSource, mockResolver := createParserSourceProvider("../../test/fixtures/test_helm")
+	store := storage.NewMemoryStorage()
+	sink := &mockSink{}
+
+	var wg sync.WaitGroup
+	errCh := make(chan error, len(mockParser))
+	currentQuery := make(chan int64)
+	for _, p := range mockParser {
+		serv := &Service{
+			SourceProvider: mockFilesSource,
+			Storage:        store,
+			Sink:           sink,
+			Parser:         p,
+			Inspector: &engine.Inspector{
+				QueryLoader: &engine.QueryLoader{
+					QueriesMetadata: make([]model.QueryMetadata, 0),
+				},
+			},
+			SecretsInspector: &secrets.Inspector{},
+			Tracker:          &tracker.CITracker{},
+			Resolver:         mockResolver,
+		}
+		wg.Add(1)
+		serv.StartScan(context.Background(), "scanID", errCh, &wg, currentQuery)
+	}
+	wg.Wait()
+	close(errCh)
+
+	for err := range errCh {
+		require.NoError(t, err)
+	}
+	// the secrets vulnerabilities are written once per service, there are no queries
+	require.Equal(t, len(mockParser), sink.batches)
+	vulnerabilities, err := store.GetVulnerabilities(context.Background(), "scanID")
+	require.NoError(t, err)
+	require.Empty(t, vulnerabilities)
+}
+
 func createParserSourceProvider(path string) ([]*parser.Parser,
 	*provider.FileSystemSourceProvider, *resolver.Resolver) {
 	mockParser, _ := parser.NewBuilder().
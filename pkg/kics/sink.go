@@ -1,16 +1,22 @@
 package kics
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"os"
+	"path/filepath"
 	"regexp"
 	"sort"
 
 	sentryReport "github.com/Checkmarx/kics/v2/internal/sentry"
+	"github.com/Checkmarx/kics/v2/pkg/cache"
 	"github.com/Checkmarx/kics/v2/pkg/model"
-	"github.com/Checkmarx/kics/v2/pkg/parser/jsonfilter/parser"
+	"github.com/Checkmarx/kics/v2/pkg/parser"
+	jsonFilterParser "github.com/Checkmarx/kics/v2/pkg/parser/jsonfilter/parser"
+	terraformParser "github.com/Checkmarx/kics/v2/pkg/parser/terraform"
 	"github.com/Checkmarx/kics/v2/pkg/utils"
 	"github.com/antlr4-go/antlr/v4"
 	"github.com/google/uuid"
@@ -43,6 +49,15 @@ func (s *Service) sink(ctx context.Context, filename, scanID string,
 	if err != nil {
 		return errors.Wrapf(err, "failed to get file content: %s", filename)
 	}
+
+	var cacheKey string
+	if s.Cache != nil {
+		cacheKey = s.Cache.FileKey(s.Parser.GetKind(), filename, *content)
+		if entry, ok := s.Cache.GetFile(cacheKey); ok && s.sinkCachedFile(ctx, filename, scanID, *content, entry) {
+			return nil
+		}
+	}
+
 	documents, err := s.Parser.Parse(filename, *content, openAPIResolveReferences, c.IsMinified, maxResolverDepth)
 	if err != nil {
 		log.Err(err).Msgf("failed to parse file content: %s", filename)
@@ -59,6 +74,7 @@ func (s *Service) sink(ctx context.Context, filename, scanID string,
 
 	fileCommands := s.Parser.CommentsCommands(filename, *content)
 
+	files := make([]model.FileMetadata, 0, len(documents.Docs))
 	for _, document := range documents.Docs {
 		_, err = json.Marshal(document)
 		if err != nil {
@@ -91,7 +107,12 @@ func (s *Service) sink(ctx context.Context, filename, scanID string,
 			IsMinified:        documents.IsMinified,
 		}
 
+		if documents.Kind == model.KindTerraform {
+			setModuleFile(&file, document)
+		}
+
 		s.saveToFile(ctx, &file)
+		files = append(files, file)
 	}
 	s.Tracker.TrackFileParse(filename)
 	log.Debug().Msgf("Finished to process file %s", filename)
@@ -99,9 +120,173 @@ func (s *Service) sink(ctx context.Context, filename, scanID string,
 	s.Tracker.TrackFileParseCountLines(documents.CountLines - len(documents.IgnoreLines))
 	s.Tracker.TrackFileIgnoreCountLines(len(documents.IgnoreLines))
 
+	if s.Cache != nil {
+		if cachedFiles, ok := withoutContent(filename, *content, files); ok {
+			s.Cache.PutFile(cacheKey, &cache.FileEntry{
+				Files:         cachedFiles,
+				CountLines:    documents.CountLines,
+				IgnoreLines:   len(documents.IgnoreLines),
+				LinesResolved: linesResolved,
+			}, fileDependencies(filename, &documents, files))
+		}
+	}
+
 	return errors.Wrap(err, "failed to save file content")
 }
 
+// sinkCachedFile saves the documents of a file found on the cache with new identifiers and their content restored,
+// tracking the file as if it was parsed. It returns false when the content can not be restored and the file has to
+// be parsed
+func (s *Service) sinkCachedFile(ctx context.Context, filename, scanID string, content []byte,
+	entry *cache.FileEntry) bool {
+	for i := range entry.Files {
+		if err := restoreContent(filename, content, &entry.Files[i]); err != nil {
+			log.Debug().Msgf("Failed to restore the content of file %s from cache: %s", filename, err)
+			return false
+		}
+	}
+
+	s.Tracker.TrackFileFoundCountLines(entry.LinesResolved)
+
+	for i := range entry.Files {
+		file := entry.Files[i]
+		file.ID = uuid.New().String()
+		file.ScanID = scanID
+		s.saveToFile(ctx, &file)
+	}
+	s.Tracker.TrackFileParse(filename)
+	log.Debug().Msgf("Finished to process file %s from cache", filename)
+
+	s.Tracker.TrackFileParseCountLines(entry.CountLines - entry.IgnoreLines)
+	s.Tracker.TrackFileIgnoreCountLines(entry.IgnoreLines)
+	return true
+}
+
+// withoutContent returns the files with the content of the scanned file and of the files it references left out,
+// so the cache does not keep a copy of them. It returns false when restoreContent would not restore the same content,
+// such as the indented Terraform plans, and the file should not be cached
+func withoutContent(filename string, content []byte, files []model.FileMetadata) ([]model.FileMetadata, bool) {
+	stripped := make([]model.FileMetadata, 0, len(files))
+	for i := range files {
+		file := files[i]
+		file.OriginalData = ""
+		file.LinesOriginalData = nil
+		if files[i].ResolvedFiles != nil {
+			file.ResolvedFiles = make(map[string]model.ResolvedFile, len(files[i].ResolvedFiles))
+			for key, ref := range files[i].ResolvedFiles {
+				file.ResolvedFiles[key] = model.ResolvedFile{Path: ref.Path}
+			}
+		}
+
+		restored := file
+		if err := restoreContent(filename, content, &restored); err != nil ||
+			restored.OriginalData != files[i].OriginalData {
+			return nil, false
+		}
+		for key, ref := range files[i].ResolvedFiles {
+			if !bytes.Equal(restored.ResolvedFiles[key].Content, ref.Content) {
+				return nil, false
+			}
+		}
+		stripped = append(stripped, file)
+	}
+	return stripped, true
+}
+
+// restoreContent sets the content left out of a cached file by withoutContent: the content of the scanned file, or
+// of the module file for the documents of module calls, and the content of the files it references
+func restoreContent(filename string, content []byte, file *model.FileMetadata) error {
+	original := content
+	if file.ModuleCall != nil {
+		moduleContent, err := os.ReadFile(file.FilePath)
+		if err != nil {
+			return err
+		}
+		original = resolveCRLFFile(moduleContent)
+	}
+	file.OriginalData = string(original)
+	file.LinesOriginalData = utils.SplitLines(file.OriginalData)
+
+	if file.ResolvedFiles == nil {
+		return nil
+	}
+	resolvedFiles := make(map[string]model.ResolvedFile, len(file.ResolvedFiles))
+	for key, ref := range file.ResolvedFiles {
+		refContent := content
+		if ref.Path != filename {
+			var err error
+			if refContent, err = os.ReadFile(ref.Path); err != nil {
+				return err
+			}
+		}
+		resolvedFiles[key] = model.ResolvedFile{
+			Path:         ref.Path,
+			Content:      refContent,
+			LinesContent: utils.SplitLines(string(refContent)),
+		}
+	}
+	file.ResolvedFiles = resolvedFiles
+	return nil
+}
+
+// fileDependencies returns the other files the documents of the file were built from: the references it resolved,
+// the files the parser read, such as the ones read by the Terraform filesystem functions, and, for Terraform, the
+// directories of the file and of its modules, where variables, locals, data sources and modules come from
+func fileDependencies(filename string, documents *parser.ParsedDocument, files []model.FileMetadata) []string {
+	dependencies := make([]string, 0)
+	for _, ref := range documents.ResolvedFiles {
+		if ref.Path != filename {
+			dependencies = append(dependencies, ref.Path)
+		}
+	}
+	dependencies = append(dependencies, documents.ReadFiles...)
+
+	if documents.Kind != model.KindTerraform {
+		return dependencies
+	}
+
+	dirs := []string{filepath.Dir(filename)}
+	for i := range files {
+		if files[i].ModuleCall != nil && !utils.Contains(filepath.Dir(files[i].FilePath), dirs) {
+			dirs = append(dirs, filepath.Dir(files[i].FilePath))
+		}
+	}
+	for _, dir := range dirs {
+		dependencies = append(dependencies, dir, filepath.Join(dir, filepath.FromSlash(terraformParser.ModulesManifestPath)))
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			if entry.Type().IsRegular() {
+				dependencies = append(dependencies, filepath.Join(dir, entry.Name()))
+			}
+		}
+	}
+
+	return dependencies
+}
+
+// setModuleFile points the file metadata of a document generated from a Terraform module call
+// to the module file, so results are reported on the module file lines and ignored by its comments
+func setModuleFile(file *model.FileMetadata, document model.Document) {
+	moduleCall, modulePath, ok := terraformParser.GetModuleCall(document)
+	if !ok {
+		return
+	}
+	content, err := os.ReadFile(modulePath)
+	if err != nil {
+		log.Err(err).Msgf("failed to read module file: %s", modulePath)
+		return
+	}
+	content = resolveCRLFFile(content)
+	file.FilePath = modulePath
+	file.OriginalData = string(content)
+	file.LinesOriginalData = utils.SplitLines(file.OriginalData)
+	file.LinesIgnore = terraformParser.GetIgnoreLines(modulePath, content)
+	file.ModuleCall = moduleCall
+}
+
 func resolveCRLFFile(fileContent []byte) []byte {
 	regex := regexp.MustCompile(`\r\n`)
 	contentSTR := regex.ReplaceAllString(string(fileContent), "\n")
@@ -112,22 +297,22 @@ func resolveJSONFilter(jsonFilter string) string {
 	is := antlr.NewInputStream(jsonFilter)
 
 	// lexer build
-	lexer := parser.NewJSONFilterLexer(is)
+	lexer := jsonFilterParser.NewJSONFilterLexer(is)
 	lexer.RemoveErrorListeners()
 	stream := antlr.NewCommonTokenStream(lexer, antlr.TokenDefaultChannel)
-	errorListener := parser.NewCustomErrorListener()
+	errorListener := jsonFilterParser.NewCustomErrorListener()
 	lexer.RemoveErrorListeners()
 	lexer.AddErrorListener(errorListener)
 
 	// parser build
-	p := parser.NewJSONFilterParser(stream)
+	p := jsonFilterParser.NewJSONFilterParser(stream)
 	p.RemoveErrorListeners()
 	p.AddErrorListener(errorListener)
 	p.BuildParseTrees = true
 	tree := p.Awsjsonfilter()
 
 	// parse
-	visitor := parser.NewJSONFilterPrinterVisitor()
+	visitor := jsonFilterParser.NewJSONFilterPrinterVisitor()
 	if errorListener.HasErrors() {
 		return jsonFilter
 	}
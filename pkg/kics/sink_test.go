@@ -2,10 +2,13 @@ package kics
 
 import (
 	"encoding/json"
+	"os"
+	"path/filepath"
 	"regexp"
 	"testing"
 
 	"github.com/Checkmarx/kics/v2/pkg/model"
+	"github.com/Checkmarx/kics/v2/pkg/utils"
 	"github.com/stretchr/testify/require"
 )
 
@@ -142,6 +145,78 @@ func TestKics_resolveCRLFFile(t *testing.T) {
 	}
 }
 
+func TestKics_withoutContent(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "main.yaml")
+	content := []byte("Resources:\n  Ref: !Ref ref.yaml\n")
+	refPath := filepath.Join(dir, "ref.yaml")
+	require.NoError(t, os.WriteFile(refPath, []byte("Type: AWS::S3::Bucket\n"), os.ModePerm))
+	modulePath := filepath.Join(dir, "module.tf")
+	require.NoError(t, os.WriteFile(modulePath, []byte("variable \"password\" {}\r\n"), os.ModePerm))
+
+	files := []model.FileMetadata{
+		{
+			FilePath:          filename,
+			OriginalData:      string(content),
+			LinesOriginalData: utils.SplitLines(string(content)),
+			ResolvedFiles: map[string]model.ResolvedFile{
+				"ref.yaml": {
+					Path:         refPath,
+					Content:      []byte("Type: AWS::S3::Bucket\n"),
+					LinesContent: utils.SplitLines("Type: AWS::S3::Bucket\n"),
+				},
+			},
+		},
+		{
+			FilePath:          modulePath,
+			OriginalData:      "variable \"password\" {}\n",
+			LinesOriginalData: utils.SplitLines("variable \"password\" {}\n"),
+			ModuleCall:        &model.ModuleCall{Name: "module", FileName: filename},
+		},
+	}
+
+	stripped, ok := withoutContent(filename, content, files)
+	require.True(t, ok)
+	require.Len(t, stripped, 2)
+	for i := range stripped {
+		require.Empty(t, stripped[i].OriginalData)
+		require.Nil(t, stripped[i].LinesOriginalData)
+		for _, ref := range stripped[i].ResolvedFiles {
+			require.Nil(t, ref.Content)
+		}
+
+		require.NoError(t, restoreContent(filename, content, &stripped[i]))
+		require.Equal(t, files[i], stripped[i])
+	}
+	require.Equal(t, string(content), files[0].OriginalData, "the files should not be changed")
+
+	indented := []model.FileMetadata{{FilePath: filename, OriginalData: "Resources:\n    Ref: !Ref ref.yaml\n"}}
+	_, ok = withoutContent(filename, content, indented)
+	require.False(t, ok, "the files whose content can not be restored should not be cached")
+}
+
+func TestKics_setModuleFile(t *testing.T) {
+	modulePath := filepath.Join(t.TempDir(), "main.tf")
+	moduleContent := "resource \"aws_s3_bucket\" \"b\" {\r\n  # kics-scan ignore-line\r\n  acl = \"public-read\"\r\n}\r\n"
+	require.NoError(t, os.WriteFile(modulePath, []byte(moduleContent), os.ModePerm))
+
+	file := model.FileMetadata{FilePath: "main.tf", OriginalData: "module \"m\" {}\n", LinesIgnore: []int{1}}
+	setModuleFile(&file, model.Document{
+		model.KicsModuleKey: map[string]interface{}{
+			"path":      modulePath,
+			"name":      "m",
+			"source":    "./modules/m",
+			"file_name": "main.tf",
+			"line":      1,
+		},
+	})
+
+	require.Equal(t, modulePath, file.FilePath)
+	require.Equal(t, string(resolveCRLFFile([]byte(moduleContent))), file.OriginalData)
+	require.Equal(t, &model.ModuleCall{Name: "m", Source: "./modules/m", FileName: "main.tf", Line: 1}, file.ModuleCall)
+	require.ElementsMatch(t, []int{2, 3}, file.LinesIgnore, "the comments of the module file should be applied")
+}
+
 func compareJSONLine(t *testing.T, test1 interface{}, test2 string) {
 	stringefiedJSON, err := json.Marshal(&test1)
 	require.NoError(t, err)
@@ -0,0 +1,84 @@
+package model
+
+// Baseline states of a result
+const (
+	BaselineStateNew      = "new"
+	BaselineStateExisting = "existing"
+	BaselineStateFixed    = "fixed"
+)
+
+// BaselineSummary contains the comparison of a scan with a baseline report, SeverityCounters only count new results
+type BaselineSummary struct {
+	Path             string           `json:"path"`
+	NewCounter       int              `json:"new_counter"`
+	ExistingCounter  int              `json:"existing_counter"`
+	FixedCounter     int              `json:"fixed_counter"`
+	SeverityCounters map[Severity]int `json:"new_severity_counters"`
+	Fixed            QueryResultSlice `json:"fixed_queries"`
+}
+
+// ApplyBaseline marks every result of the summary as new or existing in the baseline and collects the baseline
+// results that are not present anymore as fixed. Results are matched by similarity ID, falling back
+// to the old similarity ID so baselines created with a different similarity ID algorithm keep matching
+func ApplyBaseline(summary *Summary, baseline *Summary, path string) {
+	baselineIDs := baseline.Queries.similarityIDs()
+	currentIDs := summary.Queries.similarityIDs()
+
+	result := &BaselineSummary{
+		Path: path,
+		SeverityCounters: map[Severity]int{
+			SeverityTrace: 0, SeverityInfo: 0, SeverityLow: 0, SeverityMedium: 0, SeverityHigh: 0, SeverityCritical: 0,
+		},
+		Fixed: make(QueryResultSlice, 0),
+	}
+
+	for i := range summary.Queries {
+		for j := range summary.Queries[i].Files {
+			file := &summary.Queries[i].Files[j]
+			if file.matches(baselineIDs) {
+				file.BaselineState = BaselineStateExisting
+				result.ExistingCounter++
+				continue
+			}
+			file.BaselineState = BaselineStateNew
+			result.NewCounter++
+			result.SeverityCounters[summary.Queries[i].Severity]++
+		}
+	}
+
+	for i := range baseline.Queries {
+		fixed := baseline.Queries[i]
+		fixed.Files = make([]VulnerableFile, 0)
+		for j := range baseline.Queries[i].Files {
+			if !baseline.Queries[i].Files[j].matches(currentIDs) {
+				file := baseline.Queries[i].Files[j]
+				file.BaselineState = BaselineStateFixed
+				fixed.Files = append(fixed.Files, file)
+			}
+		}
+		if len(fixed.Files) > 0 {
+			result.FixedCounter += len(fixed.Files)
+			result.Fixed = append(result.Fixed, fixed)
+		}
+	}
+
+	summary.Baseline = result
+}
+
+// similarityIDs returns every similarity ID and old similarity ID of the results
+func (q QueryResultSlice) similarityIDs() map[string]bool {
+	ids := make(map[string]bool)
+	for i := range q {
+		for j := range q[i].Files {
+			ids[q[i].Files[j].SimilarityID] = true
+			if q[i].Files[j].OldSimilarityID != "" {
+				ids[q[i].Files[j].OldSimilarityID] = true
+			}
+		}
+	}
+	return ids
+}
+
+func (v *VulnerableFile) matches(ids map[string]bool) bool {
+	return ids[v.SimilarityID] || (v.OldSimilarityID != "" && ids[v.OldSimilarityID])
+}
@@ -0,0 +1,80 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyBaseline(t *testing.T) {
+	current := Summary{
+		Queries: QueryResultSlice{
+			{
+				QueryID:  "high",
+				Severity: SeverityHigh,
+				Files: []VulnerableFile{
+					{SimilarityID: "existing"},
+					{SimilarityID: "new"},
+					// the baseline was created with the old similarity ID
+					{SimilarityID: "new_algorithm", OldSimilarityID: "old_algorithm"},
+				},
+			},
+			{
+				QueryID:  "low",
+				Severity: SeverityLow,
+				Files: []VulnerableFile{
+					{SimilarityID: "new_low"},
+				},
+			},
+		},
+	}
+	baseline := Summary{
+		Queries: QueryResultSlice{
+			{
+				QueryID:  "high",
+				Severity: SeverityHigh,
+				Files: []VulnerableFile{
+					{SimilarityID: "existing"},
+					{SimilarityID: "old_algorithm"},
+					{SimilarityID: "fixed"},
+				},
+			},
+			{
+				QueryID:  "medium",
+				Severity: SeverityMedium,
+				Files: []VulnerableFile{
+					{SimilarityID: "fixed_medium", OldSimilarityID: "fixed_medium_old"},
+				},
+			},
+		},
+	}
+
+	ApplyBaseline(&current, &baseline, "baseline.json")
+
+	states := make(map[string]string)
+	for i := range current.Queries {
+		for _, file := range current.Queries[i].Files {
+			states[file.SimilarityID] = file.BaselineState
+		}
+	}
+	require.Equal(t, map[string]string{
+		"existing":      BaselineStateExisting,
+		"new":           BaselineStateNew,
+		"new_algorithm": BaselineStateExisting,
+		"new_low":       BaselineStateNew,
+	}, states)
+
+	require.Equal(t, "baseline.json", current.Baseline.Path)
+	require.Equal(t, 2, current.Baseline.NewCounter)
+	require.Equal(t, 2, current.Baseline.ExistingCounter)
+	require.Equal(t, 2, current.Baseline.FixedCounter)
+	require.Equal(t, 1, current.Baseline.SeverityCounters[SeverityHigh])
+	require.Equal(t, 1, current.Baseline.SeverityCounters[SeverityLow])
+	require.Equal(t, 0, current.Baseline.SeverityCounters[SeverityMedium])
+
+	require.Len(t, current.Baseline.Fixed, 2)
+	require.Equal(t, []VulnerableFile{{SimilarityID: "fixed", BaselineState: BaselineStateFixed}}, current.Baseline.Fixed[0].Files)
+	require.Equal(t, "medium", current.Baseline.Fixed[1].QueryID)
+	// the baseline is not modified
+	require.Len(t, baseline.Queries[0].Files, 3)
+}
@@ -0,0 +1,10 @@
+package model
+
+// ChangedSince describes the changes of the local git repository a scan was restricted to, paths are relative to the
+// repository root
+type ChangedSince struct {
+	Ref          string   `json:"ref"`
+	Base         string   `json:"base"`
+	ChangedFiles []string `json:"changed_files"`
+	ScannedPaths []string `json:"scanned_paths"`
+}
@@ -31,6 +31,31 @@ const (
 	IgnoreComment CommentCommand = "ignore-comment"
 )
 
+// KicsModuleKey is the document key holding the module call of documents generated from a Terraform module
+const KicsModuleKey = "_kics_module"
+
+// KicsChangeKey is the resource key holding the change planned for the resource in a Terraform plan, left out of the
+// documents evaluated by the queries
+const KicsChangeKey = "_kics_change"
+
+// Constants to describe the change planned for a resource in a Terraform plan
+const (
+	ChangeActionCreate  = "create"
+	ChangeActionUpdate  = "update"
+	ChangeActionReplace = "replace"
+	ChangeActionDelete  = "delete"
+	ChangeActionRead    = "read"
+	ChangeActionNoOp    = "no-op"
+)
+
+// Constants to describe why a vulnerability was suppressed, matching the kinds of SARIF suppressions
+const (
+	// SuppressionInSource is a vulnerability ignored by a kics-scan ignore-line or ignore-block comment
+	SuppressionInSource = "inSource"
+	// SuppressionExternal is a vulnerability excluded by its similarity ID with --exclude-results
+	SuppressionExternal = "external"
+)
+
 // Constants to describe vulnerability's severity
 const (
 	SeverityCritical = "CRITICAL"
@@ -119,21 +144,30 @@ type CommentsCommands map[string]string
 
 // FileMetadata is a representation of basic information and content of a file
 type FileMetadata struct {
-	ID                string `db:"id"`
-	ScanID            string `db:"scan_id"`
-	Document          Document
-	LineInfoDocument  map[string]interface{}
-	OriginalData      string   `db:"orig_data"`
-	Kind              FileKind `db:"kind"`
-	FilePath          string   `db:"file_path"`
-	Content           string
-	HelmID            string
-	IDInfo            map[int]interface{}
-	Commands          CommentsCommands
-	LinesIgnore       []int
-	ResolvedFiles     map[string]ResolvedFile
-	LinesOriginalData *[]string
-	IsMinified        bool
+	ID                string                  `db:"id"`
+	ScanID            string                  `db:"scan_id"`
+	Document          Document                `db:"-"`
+	LineInfoDocument  map[string]interface{}  `db:"-"`
+	OriginalData      string                  `db:"orig_data"`
+	Kind              FileKind                `db:"kind"`
+	FilePath          string                  `db:"file_path"`
+	Content           string                  `db:"-"`
+	HelmID            string                  `db:"helm_id"`
+	IDInfo            map[int]interface{}     `db:"-"`
+	Commands          CommentsCommands        `db:"-"`
+	LinesIgnore       []int                   `db:"-"`
+	ResolvedFiles     map[string]ResolvedFile `db:"-"`
+	LinesOriginalData *[]string               `db:"-"`
+	IsMinified        bool                    `db:"is_minified"`
+	ModuleCall        *ModuleCall             `db:"-"`
+}
+
+// ModuleCall is the reference to the module block that originated a document
+type ModuleCall struct {
+	Name     string `json:"name"`
+	Source   string `json:"source"`
+	FileName string `json:"file_name"`
+	Line     int    `json:"line"`
 }
 
 // QueryMetadata is a representation of general information about a query
@@ -148,12 +182,14 @@ type QueryMetadata struct {
 	// represents how many queries are aggregated into a single rego file
 	Aggregation  int
 	Experimental bool
+	// SingleDocument queries only look at one file at a time, so they are evaluated against each file on its own
+	SingleDocument bool
 }
 
 // Vulnerability is a representation of a detected vulnerability in scanned files
 // after running a query
 type Vulnerability struct {
-	ID               int         `json:"id"`
+	ID               int         `db:"id" json:"id"`
 	ScanID           string      `db:"scan_id" json:"-"`
 	SimilarityID     string      `db:"similarity_id" json:"similarityID"`
 	OldSimilarityID  string      `db:"old_similarity_id" json:"oldSimilarityID"`
@@ -161,16 +197,16 @@ type Vulnerability struct {
 	FileName         string      `db:"file_name" json:"fileName"`
 	QueryID          string      `db:"query_id" json:"queryID"`
 	QueryName        string      `db:"query_name" json:"queryName"`
-	QueryURI         string      `json:"-"`
-	Category         string      `json:"category"`
-	Experimental     bool        `json:"experimental"`
-	Description      string      `json:"description"`
-	DescriptionID    string      `json:"descriptionID"`
+	QueryURI         string      `db:"query_uri" json:"-"`
+	Category         string      `db:"category" json:"category"`
+	Experimental     bool        `db:"experimental" json:"experimental"`
+	Description      string      `db:"description" json:"description"`
+	DescriptionID    string      `db:"description_id" json:"descriptionID"`
 	Platform         string      `db:"platform" json:"platform"`
 	CWE              string      `db:"cwe" json:"cwe"`
-	Severity         Severity    `json:"severity"`
-	Line             int         `json:"line"`
-	VulnLines        *[]CodeLine `json:"vulnLines"`
+	Severity         Severity    `db:"severity" json:"severity"`
+	Line             int         `db:"line" json:"line"`
+	VulnLines        *[]CodeLine `db:"-" json:"vulnLines"`
 	ResourceType     string      `db:"resource_type" json:"resourceType"`
 	ResourceName     string      `db:"resource_name" json:"resourceName"`
 	IssueType        IssueType   `db:"issue_type" json:"issueType"`
@@ -180,10 +216,13 @@ type Vulnerability struct {
 	KeyExpectedValue string      `db:"key_expected_value" json:"expectedValue"`
 	KeyActualValue   string      `db:"key_actual_value" json:"actualValue"`
 	Value            *string     `db:"value" json:"value"`
-	Output           string      `json:"-"`
-	CloudProvider    string      `json:"cloud_provider"`
+	Output           string      `db:"output" json:"-"`
+	CloudProvider    string      `db:"cloud_provider" json:"cloud_provider"`
 	Remediation      string      `db:"remediation" json:"remediation"`
 	RemediationType  string      `db:"remediation_type" json:"remediation_type"`
+	ModuleCall       *ModuleCall `db:"-" json:"module_call,omitempty"`
+	ChangeAction     string      `db:"change_action" json:"changeAction,omitempty"`
+	Suppression      string      `db:"-" json:"suppression,omitempty"`
 }
 
 // QueryConfig is a struct that contains the fileKind and platform of the rego query
@@ -1,6 +1,7 @@
 package model
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -95,3 +96,18 @@ func TestFileMetadatas(t *testing.T) {
 		require.Equal(t, Documents{Documents: []Document{}}, result)
 	})
 }
+
+func TestVulnerability_ModuleCallJSON(t *testing.T) {
+	moduleCall := &ModuleCall{Name: "bucket", Source: "./modules/bucket", FileName: "main.tf", Line: 3}
+
+	vulnerability, err := json.Marshal(Vulnerability{ModuleCall: moduleCall})
+	require.NoError(t, err)
+	file, err := json.Marshal(VulnerableFile{ModuleCall: moduleCall})
+	require.NoError(t, err)
+
+	var vulnerabilityFields, fileFields map[string]interface{}
+	require.NoError(t, json.Unmarshal(vulnerability, &vulnerabilityFields))
+	require.NoError(t, json.Unmarshal(file, &fileFields))
+	require.Contains(t, vulnerabilityFields, "module_call")
+	require.Equal(t, fileFields["module_call"], vulnerabilityFields["module_call"])
+}
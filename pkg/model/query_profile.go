@@ -0,0 +1,83 @@
+package model
+
+import (
+	"sort"
+	"time"
+)
+
+// Statuses of the queries of a query profile
+const (
+	QueryProfileSuccess = "success"
+	QueryProfileFailed  = "failed"
+	QueryProfileTimeout = "timeout"
+)
+
+// QueryProfile is the time the queries of a scan took to evaluate, slowest first, and the totals of each platform
+type QueryProfile struct {
+	Queries   []QueryProfileEntry    `json:"queries"`
+	Platforms []PlatformProfileEntry `json:"platforms"`
+}
+
+// QueryProfileEntry is the time a query took to evaluate on a scan, how many times it was evaluated, the number of
+// results it returned and whether it failed or timed out
+type QueryProfileEntry struct {
+	QueryID     string        `json:"query_id"`
+	QueryName   string        `json:"query_name"`
+	Platform    string        `json:"platform"`
+	Duration    time.Duration `json:"duration_ns"`
+	Evaluations int           `json:"evaluations"`
+	Results     int           `json:"results"`
+	Status      string        `json:"status"`
+}
+
+// PlatformProfileEntry is the total of the query profile entries of a platform
+type PlatformProfileEntry struct {
+	Platform    string        `json:"platform"`
+	Queries     int           `json:"queries"`
+	Duration    time.Duration `json:"duration_ns"`
+	Evaluations int           `json:"evaluations"`
+	Results     int           `json:"results"`
+	Failed      int           `json:"failed"`
+}
+
+// NewQueryProfile sorts the entries from the slowest to the fastest and sums them by platform
+func NewQueryProfile(queries []QueryProfileEntry) *QueryProfile {
+	sort.SliceStable(queries, func(i, j int) bool {
+		if queries[i].Duration != queries[j].Duration {
+			return queries[i].Duration > queries[j].Duration
+		}
+		return queries[i].QueryID < queries[j].QueryID
+	})
+
+	platforms := make(map[string]*PlatformProfileEntry)
+	for i := range queries {
+		platform, ok := platforms[queries[i].Platform]
+		if !ok {
+			platform = &PlatformProfileEntry{Platform: queries[i].Platform}
+			platforms[queries[i].Platform] = platform
+		}
+		platform.Queries++
+		platform.Duration += queries[i].Duration
+		platform.Evaluations += queries[i].Evaluations
+		platform.Results += queries[i].Results
+		if queries[i].Status != QueryProfileSuccess {
+			platform.Failed++
+		}
+	}
+
+	profile := &QueryProfile{
+		Queries:   queries,
+		Platforms: make([]PlatformProfileEntry, 0, len(platforms)),
+	}
+	for _, platform := range platforms {
+		profile.Platforms = append(profile.Platforms, *platform)
+	}
+	sort.Slice(profile.Platforms, func(i, j int) bool {
+		if profile.Platforms[i].Duration != profile.Platforms[j].Duration {
+			return profile.Platforms[i].Duration > profile.Platforms[j].Duration
+		}
+		return profile.Platforms[i].Platform < profile.Platforms[j].Platform
+	})
+
+	return profile
+}
@@ -0,0 +1,28 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewQueryProfile(t *testing.T) {
+	got := NewQueryProfile([]QueryProfileEntry{
+		{QueryID: "a", Platform: "terraform", Duration: time.Second, Evaluations: 1, Results: 2, Status: QueryProfileSuccess},
+		{QueryID: "b", Platform: "k8s", Duration: 3 * time.Second, Evaluations: 4, Results: 0, Status: QueryProfileTimeout},
+		{QueryID: "c", Platform: "terraform", Duration: 2 * time.Second, Evaluations: 1, Results: 1, Status: QueryProfileFailed},
+		{QueryID: "d", Platform: "terraform", Duration: time.Second, Evaluations: 1, Results: 0, Status: QueryProfileSuccess},
+	})
+
+	ids := make([]string, 0, len(got.Queries))
+	for i := range got.Queries {
+		ids = append(ids, got.Queries[i].QueryID)
+	}
+	require.Equal(t, []string{"b", "c", "a", "d"}, ids)
+
+	require.Equal(t, []PlatformProfileEntry{
+		{Platform: "terraform", Queries: 3, Duration: 4 * time.Second, Evaluations: 3, Results: 3, Failed: 1},
+		{Platform: "k8s", Queries: 1, Duration: 3 * time.Second, Evaluations: 4, Results: 0, Failed: 1},
+	}, got.Platforms)
+}
@@ -37,6 +37,10 @@ type VulnerableFile struct {
 	Value            *string     `json:"value,omitempty"`
 	Remediation      string      `json:"remediation,omitempty"`
 	RemediationType  string      `json:"remediation_type,omitempty"`
+	ModuleCall       *ModuleCall `json:"module_call,omitempty"`
+	ChangeAction     string      `json:"change_action,omitempty"`
+	BaselineState    string      `json:"baseline_state,omitempty"`
+	Suppression      string      `json:"suppression,omitempty"`
 }
 
 // QueryResult contains a query that tested positive ID, name, severity and a list of files that tested vulnerable
@@ -78,6 +82,12 @@ type Counters struct {
 	TotalQueries           int `json:"queries_total"`
 	FailedToExecuteQueries int `json:"queries_failed_to_execute"`
 	FailedSimilarityID     int `json:"queries_failed_to_compute_similarity_id"`
+	ResultsOutsideDiff     int `json:"results_outside_diff,omitempty"`
+	ResultsSuppressed      int `json:"results_suppressed,omitempty"`
+	CacheFileHits          int `json:"cache_file_hits,omitempty"`
+	CacheFileMisses        int `json:"cache_file_misses,omitempty"`
+	CacheResultHits        int `json:"cache_result_hits,omitempty"`
+	CacheResultMisses      int `json:"cache_result_misses,omitempty"`
 }
 
 // Times represents an object that contains the start and end time of the scan
@@ -99,10 +109,16 @@ type Summary struct {
 	Counters
 	SeveritySummary
 	Times
-	ScannedPaths []string          `json:"paths"`
-	Queries      QueryResultSlice  `json:"queries"`
-	Bom          QueryResultSlice  `json:"bill_of_materials,omitempty"`
-	FilePaths    map[string]string `json:"-"`
+	ScannedPaths      []string          `json:"paths"`
+	Queries           QueryResultSlice  `json:"queries"`
+	Bom               QueryResultSlice  `json:"bill_of_materials,omitempty"`
+	Baseline          *BaselineSummary  `json:"baseline,omitempty"`
+	ChangedSince      *ChangedSince     `json:"changed_since,omitempty"`
+	OutsideDiff       QueryResultSlice  `json:"outside_diff_queries,omitempty"`
+	Suppressed        QueryResultSlice  `json:"suppressed_queries,omitempty"`
+	QueryProfile      *QueryProfile     `json:"query_profile,omitempty"`
+	FilePaths         map[string]string `json:"-"`
+	MarkdownMaxLength int               `json:"-"`
 }
 
 // PathParameters - structure wraps the required fields for temporary path translation
@@ -183,6 +199,18 @@ func resolvePath(filePath string, pathExtractionMap map[string]ExtractedPathObje
 	return returnPath
 }
 
+func resolveModuleCall(call *ModuleCall, pathExtractionMap map[string]ExtractedPathObject) *ModuleCall {
+	if call == nil {
+		return nil
+	}
+	return &ModuleCall{
+		Name:     call.Name,
+		Source:   call.Source,
+		FileName: resolvePath(call.FileName, pathExtractionMap),
+		Line:     call.Line,
+	}
+}
+
 // CreateSummary creates a report for a single scan, based on its scanID
 func CreateSummary(counters Counters, vulnerabilities []Vulnerability,
 	scanID string, pathExtractionMap map[string]ExtractedPathObject, version Version) Summary {
@@ -231,6 +259,9 @@ func CreateSummary(counters Counters, vulnerabilities []Vulnerability,
 			Value:            item.Value,
 			Remediation:      item.Remediation,
 			RemediationType:  item.RemediationType,
+			ModuleCall:       resolveModuleCall(item.ModuleCall, pathExtractionMap),
+			ChangeAction:     item.ChangeAction,
+			Suppression:      item.Suppression,
 		})
 
 		filePaths[resolvedPath] = item.FileName
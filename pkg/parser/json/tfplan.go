@@ -44,7 +44,14 @@ func readPlan(plan *hcl_plan.Plan) model.Document {
 		Resource: make(map[string]KicsPlanResource),
 	}
 
-	kp.readModule(plan.PlannedValues.RootModule)
+	changes := make(map[string]*hcl_plan.Change, len(plan.ResourceChanges))
+	for _, resourceChange := range plan.ResourceChanges {
+		if resourceChange != nil && resourceChange.Change != nil {
+			changes[resourceChange.Address] = resourceChange.Change
+		}
+	}
+
+	kp.readModule(plan.PlannedValues.RootModule, changes)
 
 	doc := model.Document{}
 
@@ -60,8 +67,9 @@ func readPlan(plan *hcl_plan.Plan) model.Document {
 	return doc
 }
 
-// readModule will iterate over all planned_value getting the information required
-func (kp *KicsPlan) readModule(module *hcl_plan.StateModule) {
+// readModule will iterate over all planned_value getting the information required, resources with a
+// resource_changes entry keep the planned action and the values before the change under model.KicsChangeKey
+func (kp *KicsPlan) readModule(module *hcl_plan.StateModule, changes map[string]*hcl_plan.Change) {
 	// initialize all the types interfaces
 	for _, resource := range module.Resources {
 		if _, ok := kp.Resource[resource.Type]; !ok {
@@ -70,10 +78,46 @@ func (kp *KicsPlan) readModule(module *hcl_plan.StateModule) {
 	}
 	// fill in all the types interfaces
 	for _, resource := range module.Resources {
-		kp.Resource[resource.Type][resource.Name] = resource.AttributeValues
+		values := resource.AttributeValues
+		if change, ok := changes[resource.Address]; ok {
+			if values == nil {
+				values = make(map[string]interface{})
+			}
+			values[model.KicsChangeKey] = readChange(change)
+		}
+		kp.Resource[resource.Type][resource.Name] = values
 	}
 
 	for _, childModule := range module.ChildModules {
-		kp.readModule(childModule)
+		kp.readModule(childModule, changes)
+	}
+}
+
+// readChange returns the planned action of a resource change and the resource values before it
+func readChange(change *hcl_plan.Change) map[string]interface{} {
+	kicsChange := map[string]interface{}{
+		"action": getChangeAction(change.Actions),
+	}
+	if change.Before != nil {
+		kicsChange["before"] = change.Before
+	}
+	return kicsChange
+}
+
+// getChangeAction summarizes the actions of a resource change into a single action
+func getChangeAction(actions hcl_plan.Actions) string {
+	switch {
+	case actions.Replace():
+		return model.ChangeActionReplace
+	case actions.Create():
+		return model.ChangeActionCreate
+	case actions.Update():
+		return model.ChangeActionUpdate
+	case actions.Delete():
+		return model.ChangeActionDelete
+	case actions.Read():
+		return model.ChangeActionRead
+	default:
+		return model.ChangeActionNoOp
 	}
 }
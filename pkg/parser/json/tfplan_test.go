@@ -60,6 +60,89 @@ func TestJson_parseTFPlan(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "test - parse tfplan resource changes",
+			args: args{
+				doc: model.Document{
+					"format_version":    "0.2",
+					"terraform_version": "1.0.5",
+					"planned_values": map[string]interface{}{
+						"root_module": map[string]interface{}{
+							"resources": []map[string]interface{}{
+								{
+									"address": "aws_s3_bucket.logs",
+									"mode":    "managed",
+									"type":    "aws_s3_bucket",
+									"name":    "logs",
+									"values": map[string]interface{}{
+										"acl": "private",
+									},
+								},
+								{
+									"address": "aws_s3_bucket.assets",
+									"mode":    "managed",
+									"type":    "aws_s3_bucket",
+									"name":    "assets",
+									"values": map[string]interface{}{
+										"acl": "public-read",
+									},
+								},
+							},
+						},
+					},
+					"resource_changes": []map[string]interface{}{
+						{
+							"address": "aws_s3_bucket.logs",
+							"type":    "aws_s3_bucket",
+							"name":    "logs",
+							"change": map[string]interface{}{
+								"actions": []string{"update"},
+								"before": map[string]interface{}{
+									"acl": "public-read",
+								},
+								"after": map[string]interface{}{
+									"acl": "private",
+								},
+							},
+						},
+						{
+							"address": "aws_s3_bucket.assets",
+							"type":    "aws_s3_bucket",
+							"name":    "assets",
+							"change": map[string]interface{}{
+								"actions": []string{"delete", "create"},
+								"before":  nil,
+								"after": map[string]interface{}{
+									"acl": "public-read",
+								},
+							},
+						},
+					},
+				},
+			},
+			want: model.Document{
+				"resource": map[string]interface{}{
+					"aws_s3_bucket": map[string]interface{}{
+						"logs": map[string]interface{}{
+							"acl": "private",
+							model.KicsChangeKey: map[string]interface{}{
+								"action": model.ChangeActionUpdate,
+								"before": map[string]interface{}{
+									"acl": "public-read",
+								},
+							},
+						},
+						"assets": map[string]interface{}{
+							"acl": "public-read",
+							model.KicsChangeKey: map[string]interface{}{
+								"action": model.ChangeActionReplace,
+							},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
 		{
 			name: "test - should not parse tfplan",
 			args: args{
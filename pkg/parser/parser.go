@@ -22,6 +22,12 @@ type kindParser interface {
 	GetResolvedFiles() map[string]model.ResolvedFile
 }
 
+// readFilesParser is implemented by the parsers reading other files while parsing a file, such as the files read by
+// the Terraform filesystem functions
+type readFilesParser interface {
+	GetReadFiles() []string
+}
+
 // Builder is a representation of parsers that will be construct
 type Builder struct {
 	parsers []kindParser
@@ -82,6 +88,7 @@ type ParsedDocument struct {
 	IgnoreLines   []int
 	CountLines    int
 	ResolvedFiles map[string]model.ResolvedFile
+	ReadFiles     []string
 	IsMinified    bool
 }
 
@@ -146,6 +153,11 @@ func (c *Parser) Parse(
 			cont = string(fileContent)
 		}
 
+		var readFiles []string
+		if p, ok := c.parsers.(readFilesParser); ok {
+			readFiles = p.GetReadFiles()
+		}
+
 		return ParsedDocument{
 			Docs:          obj,
 			Kind:          c.parsers.GetKind(),
@@ -153,6 +165,7 @@ func (c *Parser) Parse(
 			IgnoreLines:   igLines,
 			CountLines:    bytes.Count(resolved, []byte{'\n'}) + 1,
 			ResolvedFiles: c.parsers.GetResolvedFiles(),
+			ReadFiles:     readFiles,
 			IsMinified:    isMinified,
 		}, nil
 	}
@@ -169,6 +182,11 @@ func (c *Parser) SupportedExtensions() model.Extensions {
 	return c.extensions
 }
 
+// GetKind returns the kind of the files parsed by the parser
+func (c *Parser) GetKind() model.FileKind {
+	return c.parsers.GetKind()
+}
+
 func contains(types []string, supportedTypes map[string]bool) bool {
 	if types[0] == "" {
 		return true
@@ -13,6 +13,7 @@ import (
 	"github.com/rs/zerolog/log"
 	"github.com/zclconf/go-cty/cty"
 	ctyconvert "github.com/zclconf/go-cty/cty/convert"
+	"github.com/zclconf/go-cty/cty/function"
 	ctyjson "github.com/zclconf/go-cty/cty/json"
 )
 
@@ -27,8 +28,19 @@ var inputVarMap = make(VariableMap)
 // DefaultConverted an hcl File to a toJson serializable object
 // This assumes that the body is a hclsyntax.Body
 var DefaultConverted = func(file *hcl.File, inputVariables VariableMap) (model.Document, error) {
+	return convertFile(file, inputVariables, false)
+}
+
+// ExpandedConverted an hcl File to a toJson serializable object, expanding resources with count and for_each
+// meta-arguments into indexed instances (e.g. aws_s3_bucket.b[0] or aws_s3_bucket.b["key"]), resources whose
+// count or for_each can not be resolved are kept unexpanded
+var ExpandedConverted = func(file *hcl.File, inputVariables VariableMap) (model.Document, error) {
+	return convertFile(file, inputVariables, true)
+}
+
+func convertFile(file *hcl.File, inputVariables VariableMap, expandInstances bool) (model.Document, error) {
 	inputVarMap = inputVariables
-	c := converter{bytes: file.Bytes}
+	c := converter{bytes: file.Bytes, expandInstances: expandInstances, functions: Functions(inputVariables)}
 	body, err := c.convertBody(file.Body.(*hclsyntax.Body), 0)
 
 	if err != nil {
@@ -48,8 +60,51 @@ var DefaultConverted = func(file *hcl.File, inputVariables VariableMap) (model.D
 	return body, nil
 }
 
+// Functions returns the terraform functions with the filesystem functions resolving relative paths
+// from the path.module variable and reading the files of the path.module and path.root directories,
+// or of the current directory when they are not set
+func Functions(variables VariableMap) map[string]function.Function {
+	module, ok := pathAttribute(variables, "module")
+	if !ok {
+		return functions.TerraformFuncs
+	}
+	root, ok := pathAttribute(variables, "root")
+	if !ok {
+		root = module
+	}
+	return functions.Functions(module, root)
+}
+
+func pathAttribute(variables VariableMap, name string) (string, bool) {
+	path, ok := variables["path"]
+	if !ok || !path.Type().IsObjectType() || !path.Type().HasAttribute(name) {
+		return "", false
+	}
+	value := path.GetAttr(name)
+	if !value.IsKnown() || value.IsNull() || value.Type() != cty.String {
+		return "", false
+	}
+	return value.AsString(), true
+}
+
 type converter struct {
-	bytes []byte
+	bytes           []byte
+	expandInstances bool
+	functions       map[string]function.Function
+	// iteration holds the count and each objects of the resource instance being converted
+	iteration VariableMap
+}
+
+// instance represents a resource instance created by the count or for_each meta-arguments
+type instance struct {
+	key       string
+	iteration VariableMap
+}
+
+// expandableBlocks are the block types that support the count and for_each meta-arguments
+var expandableBlocks = map[string]bool{
+	"resource": true,
+	"data":     true,
 }
 
 const kicsLinesKey = "_kics_"
@@ -113,11 +168,19 @@ func (c *converter) convertBody(body *hclsyntax.Body, defLine int) (model.Docume
 	}
 
 	for _, block := range body.Blocks {
-		// set kics line for block
-		kicsS[kicsLinesKey+block.Type] = model.LineObject{
-			Line: block.TypeRange.Start.Line,
+		if block.Type == "dynamic" && len(block.Labels) == 1 {
+			// set kics line for the generated blocks
+			kicsS[kicsLinesKey+block.Labels[0]] = model.LineObject{
+				Line: block.TypeRange.Start.Line,
+			}
+			err = c.convertDynamicBlock(block, out)
+		} else {
+			// set kics line for block
+			kicsS[kicsLinesKey+block.Type] = model.LineObject{
+				Line: block.TypeRange.Start.Line,
+			}
+			err = c.convertBlock(block, out, block.TypeRange.Start.Line)
 		}
-		err = c.convertBlock(block, out, block.TypeRange.Start.Line)
 		if err != nil {
 			sentryReport.ReportSentry(&sentryReport.Report{
 				Location: "func convertBody",
@@ -178,24 +241,236 @@ func (c *converter) getArrLines(expr hclsyntax.Expression) []map[string]*model.L
 	return arr
 }
 
-func (c *converter) convertBlock(block *hclsyntax.Block, out model.Document, defLine int) error {
-	var key = block.Type
-	value, err := c.convertBody(block.Body, defLine)
+// evalContext returns the context used to evaluate expressions, containing the input variables, locals
+// and the count and each objects of the resource instance being converted
+func (c *converter) evalContext(withFunctions bool) *hcl.EvalContext {
+	variables := inputVarMap
+	if len(c.iteration) > 0 {
+		variables = make(VariableMap, len(inputVarMap)+len(c.iteration))
+		for key, value := range inputVarMap {
+			variables[key] = value
+		}
+		for key, value := range c.iteration {
+			variables[key] = value
+		}
+	}
+	ctx := &hcl.EvalContext{
+		Variables: variables,
+	}
+	if withFunctions {
+		ctx.Functions = c.functions
+		if ctx.Functions == nil {
+			ctx.Functions = functions.TerraformFuncs
+		}
+	}
+	return ctx
+}
+
+// getInstances returns the instances created by the count or for_each meta-arguments of a block,
+// the boolean is false when the block has none of them or their value can not be resolved
+func (c *converter) getInstances(block *hclsyntax.Block) ([]instance, bool) {
+	if !expandableBlocks[block.Type] || len(block.Labels) == 0 {
+		return nil, false
+	}
+	var instances []instance
+	var ok bool
+	meta := "count"
+	if countAttr, found := block.Body.Attributes["count"]; found {
+		instances, ok = c.getCountInstances(countAttr.Expr)
+	} else if forEachAttr, found := block.Body.Attributes["for_each"]; found {
+		meta = "for_each"
+		instances, ok = c.getForEachInstances(forEachAttr.Expr)
+	} else {
+		return nil, false
+	}
+	if !ok {
+		log.Debug().Msgf("Keeping %s %s unexpanded, its %s can not be resolved",
+			block.Type, strings.Join(block.Labels, "."), meta)
+	}
+	return instances, ok
+}
 
+func (c *converter) getCountInstances(expr hclsyntax.Expression) ([]instance, bool) {
+	value, diags := expr.Value(c.evalContext(true))
+	if diags.HasErrors() || !value.IsWhollyKnown() || value.IsNull() {
+		return nil, false
+	}
+	value, err := ctyconvert.Convert(value, cty.Number)
 	if err != nil {
-		return err
+		return nil, false
+	}
+	count, accuracy := value.AsBigFloat().Int64()
+	if accuracy != 0 || count < 0 {
+		return nil, false
+	}
+	instances := make([]instance, 0, count)
+	for i := int64(0); i < count; i++ {
+		instances = append(instances, instance{
+			key: fmt.Sprintf("[%d]", i),
+			iteration: VariableMap{
+				"count": cty.ObjectVal(map[string]cty.Value{
+					"index": cty.NumberIntVal(i),
+				}),
+			},
+		})
+	}
+	return instances, true
+}
+
+func (c *converter) getForEachInstances(expr hclsyntax.Expression) ([]instance, bool) {
+	value, diags := expr.Value(c.evalContext(true))
+	if diags.HasErrors() || !value.IsWhollyKnown() || value.IsNull() || !value.CanIterateElements() {
+		return nil, false
+	}
+	isMap := value.Type().IsMapType() || value.Type().IsObjectType()
+	instances := make([]instance, 0, value.LengthInt())
+	for it := value.ElementIterator(); it.Next(); {
+		key, val := it.Element()
+		if !isMap {
+			// sets are iterated by value, each.key and each.value are the same
+			key = val
+		}
+		key, err := ctyconvert.Convert(key, cty.String)
+		if err != nil || key.IsNull() {
+			return nil, false
+		}
+		instances = append(instances, instance{
+			key: fmt.Sprintf("[%q]", key.AsString()),
+			iteration: VariableMap{
+				"each": cty.ObjectVal(map[string]cty.Value{
+					"key":   key,
+					"value": val,
+				}),
+			},
+		})
+	}
+	return instances, true
+}
+
+func (c *converter) convertBlock(block *hclsyntax.Block, out model.Document, defLine int) error {
+	var instances []instance
+	ok := false
+	if c.expandInstances {
+		instances, ok = c.getInstances(block)
+	}
+	if !ok {
+		// the block is kept unexpanded, count.index and each references are left as expressions and only a
+		// literal count of 0 drops the block (see convertBody)
+		value, err := c.convertBody(block.Body, defLine)
+		if err != nil {
+			return err
+		}
+		return addBlock(block.Type, block.Labels, out, value)
+	}
+
+	for _, inst := range instances {
+		previous := c.iteration
+		c.iteration = inst.iteration
+		value, err := c.convertBody(block.Body, defLine)
+		c.iteration = previous
+		if err != nil {
+			return err
+		}
+
+		labels := make([]string, len(block.Labels))
+		copy(labels, block.Labels)
+		labels[len(labels)-1] += inst.key
+		if err := addBlock(block.Type, labels, out, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// convertDynamicBlock expands a dynamic block into the blocks it generates, when the for_each collection
+// can not be resolved a single block is generated with the iterator references kept as expressions
+func (c *converter) convertDynamicBlock(block *hclsyntax.Block, out model.Document) error {
+	var content *hclsyntax.Block
+	for _, inner := range block.Body.Blocks {
+		if inner.Type == "content" {
+			content = inner
+			break
+		}
+	}
+	if content == nil {
+		return nil
+	}
+
+	blockType := block.Labels[0]
+	iterator := blockType
+	if iteratorAttr, ok := block.Body.Attributes["iterator"]; ok {
+		if name := hcl.ExprAsKeyword(iteratorAttr.Expr); name != "" {
+			iterator = name
+		}
+	}
+
+	elements, ok := c.getDynamicElements(block.Body)
+	if !ok {
+		elements = []cty.Value{cty.ObjectVal(map[string]cty.Value{
+			"key":   cty.DynamicVal,
+			"value": cty.DynamicVal,
+		})}
+	}
+
+	for _, element := range elements {
+		previous := c.iteration
+		c.iteration = make(VariableMap, len(previous)+1)
+		for key, value := range previous {
+			c.iteration[key] = value
+		}
+		c.iteration[iterator] = element
+		value, err := c.convertBody(content.Body, content.TypeRange.Start.Line)
+		c.iteration = previous
+		if err != nil {
+			return err
+		}
+		if err := addBlock(blockType, nil, out, value); err != nil {
+			return err
+		}
 	}
 
+	return nil
+}
+
+// getDynamicElements returns the iterator objects (key and value) of the for_each collection of a dynamic block
+func (c *converter) getDynamicElements(body *hclsyntax.Body) ([]cty.Value, bool) {
+	forEachAttr, ok := body.Attributes["for_each"]
+	if !ok {
+		return nil, false
+	}
+	value, diags := forEachAttr.Expr.Value(c.evalContext(true))
+	if diags.HasErrors() || !value.IsWhollyKnown() || value.IsNull() || !value.CanIterateElements() {
+		return nil, false
+	}
+	isSet := value.Type().IsSetType()
+	elements := make([]cty.Value, 0, value.LengthInt())
+	for it := value.ElementIterator(); it.Next(); {
+		key, val := it.Element()
+		if isSet {
+			key = val
+		}
+		elements = append(elements, cty.ObjectVal(map[string]cty.Value{
+			"key":   key,
+			"value": val,
+		}))
+	}
+	return elements, true
+}
+
+func addBlock(blockType string, labels []string, out model.Document, value model.Document) error {
+	var key = blockType
+
 	if value == nil {
 		return nil
 	}
 
-	for _, label := range block.Labels {
+	for _, label := range labels {
 		if inner, exists := out[key]; exists {
 			var ok bool
 			out, ok = inner.(model.Document)
 			if !ok {
-				return fmt.Errorf("unable to convert Block to JSON: %v.%v", block.Type, strings.Join(block.Labels, "."))
+				return fmt.Errorf("unable to convert Block to JSON: %v.%v", blockType, strings.Join(labels, "."))
 			}
 		} else {
 			obj := make(model.Document)
@@ -248,20 +523,14 @@ func (c *converter) convertExpression(expr hclsyntax.Expression) (interface{}, e
 	case *hclsyntax.FunctionCallExpr:
 		return c.evalFunction(expr)
 	case *hclsyntax.ConditionalExpr:
-		expressionEvaluated, err := expr.Value(&hcl.EvalContext{
-			Variables: inputVarMap,
-			Functions: functions.TerraformFuncs,
-		})
+		expressionEvaluated, err := expr.Value(c.evalContext(true))
 		if err != nil {
 			return c.wrapExpr(expr)
 		}
 		return ctyjson.SimpleJSONValue{Value: expressionEvaluated}, nil
 	default:
 		// try to evaluate with variables and functions
-		valueConverted, _ := expr.Value(&hcl.EvalContext{
-			Variables: inputVarMap,
-			Functions: functions.TerraformFuncs,
-		})
+		valueConverted, _ := expr.Value(c.evalContext(true))
 		if !checkDynamicKnownTypes(valueConverted) {
 			return ctyjson.SimpleJSONValue{Value: valueConverted}, nil
 		}
@@ -396,12 +665,17 @@ func (c *converter) convertStringPart(expr hclsyntax.Expression) (string, error)
 		return c.convertStringPart(v.Expression)
 	default:
 		// try to evaluate with variables
-		valueConverted, _ := expr.Value(&hcl.EvalContext{
-			Variables: inputVarMap,
-		})
+		valueConverted, _ := expr.Value(c.evalContext(false))
 		if valueConverted.Type().FriendlyName() == "string" {
 			return valueConverted.AsString(), nil
 		}
+		if _, isTraversal := expr.(*hclsyntax.ScopeTraversalExpr); isTraversal &&
+			valueConverted.IsWhollyKnown() && !valueConverted.IsNull() && valueConverted.Type().IsPrimitiveType() {
+			// referenced numbers and bools (e.g. count.index) are interpolated as strings
+			if s, err := ctyconvert.Convert(valueConverted, cty.String); err == nil {
+				return s.AsString(), nil
+			}
+		}
 		// treating as an embedded expression
 		return c.wrapExpr(expr)
 	}
@@ -487,10 +761,7 @@ func (c *converter) wrapExpr(expr hclsyntax.Expression) (string, error) {
 }
 
 func (c *converter) evalFunction(expression hclsyntax.Expression) (interface{}, error) {
-	expressionEvaluated, err := expression.Value(&hcl.EvalContext{
-		Variables: inputVarMap,
-		Functions: functions.TerraformFuncs,
-	})
+	expressionEvaluated, err := expression.Value(c.evalContext(true))
 	if err != nil {
 		for _, expressionError := range err {
 			if expressionError.Summary == "Unknown variable" {
@@ -507,15 +778,12 @@ func (c *converter) evalFunction(expression hclsyntax.Expression) (interface{},
 				}
 			}
 		}
-		expressionEvaluated, err = expression.Value(&hcl.EvalContext{
-			Variables: inputVarMap,
-			Functions: functions.TerraformFuncs,
-		})
+		expressionEvaluated, err = expression.Value(c.evalContext(true))
 		if err != nil {
 			return c.wrapExpr(expression)
 		}
 	}
-	if !expressionEvaluated.HasWhollyKnownType() {
+	if !expressionEvaluated.HasWhollyKnownType() || !expressionEvaluated.IsWhollyKnown() {
 		// in some cases, the expression is evaluated with no error but the type is unknown.
 		// this causes the json marshaling of the Document later on to fail with an error, and the entire scan fails.
 		// Therefore, we prefer to wrap it as a string and continue the scan.
@@ -299,6 +299,157 @@ block "label_one" {
 	}
 }
 
+// TestInstances tests if count.index, each.key and each.value are evaluated and if instances are expanded
+func TestInstances(t *testing.T) {
+	input := `
+resource "aws_s3_bucket" "counted" {
+	count  = var.buckets
+	bucket = "bucket-${count.index}"
+}
+
+resource "aws_s3_bucket" "iterated" {
+	for_each = local.acls
+	bucket   = each.key
+	acl      = each.value
+}
+
+resource "aws_s3_bucket" "disabled" {
+	count  = var.buckets - 2
+	bucket = "disabled"
+}
+
+resource "aws_s3_bucket" "removed" {
+	count  = 0
+	bucket = "removed"
+}
+
+resource "aws_s3_bucket" "none" {
+	for_each = local.none
+	bucket   = "none"
+}
+
+resource "aws_s3_bucket" "unknown" {
+	for_each = var.unknown
+	bucket   = "unknown"
+	acl      = each.value
+}
+`
+	variables := VariableMap{
+		"var": cty.ObjectVal(map[string]cty.Value{
+			"buckets": cty.NumberIntVal(2),
+		}),
+		"local": cty.ObjectVal(map[string]cty.Value{
+			"acls": cty.MapVal(map[string]cty.Value{
+				"logs":   cty.StringVal("log-delivery-write"),
+				"public": cty.StringVal("public-read"),
+			}),
+			"none": cty.MapValEmpty(cty.String),
+		}),
+	}
+
+	getValue := func(doc model.Document, name, key string) string {
+		if token, ok := doc[name].(model.Document)[key].(ctyjson.SimpleJSONValue); ok {
+			return token.Value.AsString()
+		}
+		return doc[name].(model.Document)[key].(string)
+	}
+
+	file, _ := hclsyntax.ParseConfig([]byte(input), "testFileName", hcl.Pos{Byte: 0, Line: 1, Column: 1})
+
+	t.Run("blocks are kept unexpanded when instances are not expanded", func(t *testing.T) {
+		body, err := DefaultConverted(file, variables)
+		require.NoError(t, err)
+		buckets := body["resource"].(model.Document)["aws_s3_bucket"].(model.Document)
+		require.Len(t, buckets, 5)
+		require.Contains(t, buckets, "disabled", "a count computed to 0 is kept without expansion")
+		require.Contains(t, buckets, "none", "an empty for_each is kept without expansion")
+		require.NotContains(t, buckets, "removed", "a literal count of 0 drops the resource")
+		require.Equal(t, "bucket-${count.index}", getValue(buckets, "counted", "bucket"))
+		require.Equal(t, "${each.key}", getValue(buckets, "iterated", "bucket"))
+		require.Equal(t, "${each.value}", getValue(buckets, "iterated", "acl"))
+	})
+
+	t.Run("instances are expanded with their index", func(t *testing.T) {
+		body, err := ExpandedConverted(file, variables)
+		require.NoError(t, err)
+		buckets := body["resource"].(model.Document)["aws_s3_bucket"].(model.Document)
+		require.Len(t, buckets, 5)
+		require.Equal(t, "bucket-0", getValue(buckets, "counted[0]", "bucket"))
+		require.Equal(t, "bucket-1", getValue(buckets, "counted[1]", "bucket"))
+		require.Equal(t, "log-delivery-write", getValue(buckets, `iterated["logs"]`, "acl"))
+		require.Equal(t, "public-read", getValue(buckets, `iterated["public"]`, "acl"))
+		require.NotContains(t, buckets, "disabled", "a count computed to 0 drops the resource")
+		require.NotContains(t, buckets, "none", "an empty for_each drops the resource")
+	})
+
+	t.Run("block is kept unexpanded when its for_each is unknown", func(t *testing.T) {
+		body, err := ExpandedConverted(file, variables)
+		require.NoError(t, err)
+		buckets := body["resource"].(model.Document)["aws_s3_bucket"].(model.Document)
+		require.Contains(t, buckets, "unknown")
+		require.Equal(t, "unknown", getValue(buckets, "unknown", "bucket"))
+		require.Equal(t, "${each.value}", getValue(buckets, "unknown", "acl"))
+	})
+}
+
+// TestDynamicBlocks tests if dynamic blocks are expanded into the blocks they generate
+func TestDynamicBlocks(t *testing.T) {
+	input := `
+resource "aws_security_group" "resolved" {
+	dynamic "ingress" {
+		for_each = var.rules
+		iterator = rule
+		content {
+			from_port   = rule.value.port
+			cidr_blocks = rule.value.cidrs
+		}
+	}
+}
+
+resource "aws_security_group" "unresolved" {
+	dynamic "ingress" {
+		for_each = var.unknown_rules
+		content {
+			from_port = ingress.value.port
+			protocol  = "tcp"
+		}
+	}
+}
+`
+	variables := VariableMap{
+		"var": cty.ObjectVal(map[string]cty.Value{
+			"rules": cty.TupleVal([]cty.Value{
+				cty.ObjectVal(map[string]cty.Value{
+					"port":  cty.NumberIntVal(22),
+					"cidrs": cty.TupleVal([]cty.Value{cty.StringVal("0.0.0.0/0")}),
+				}),
+				cty.ObjectVal(map[string]cty.Value{
+					"port":  cty.NumberIntVal(443),
+					"cidrs": cty.TupleVal([]cty.Value{cty.StringVal("10.0.0.0/8")}),
+				}),
+			}),
+		}),
+	}
+
+	file, _ := hclsyntax.ParseConfig([]byte(input), "testFileName", hcl.Pos{Byte: 0, Line: 1, Column: 1})
+	body, err := DefaultConverted(file, variables)
+	require.NoError(t, err)
+	groups := body["resource"].(model.Document)["aws_security_group"].(model.Document)
+
+	resolved := groups["resolved"].(model.Document)
+	require.NotContains(t, resolved, "dynamic")
+	ingress := resolved["ingress"].([]interface{})
+	require.Len(t, ingress, 2)
+	port, _ := ingress[1].(model.Document)["from_port"].(ctyjson.SimpleJSONValue).Value.AsBigFloat().Int64()
+	require.Equal(t, int64(443), port)
+	require.Equal(t, 3, resolved["_kics_lines"].(map[string]model.LineObject)["_kics_ingress"].Line)
+
+	unresolved := groups["unresolved"].(model.Document)
+	require.NotContains(t, unresolved, "dynamic")
+	require.Equal(t, "${ingress.value.port}", unresolved["ingress"].(model.Document)["from_port"])
+	require.Equal(t, "tcp", unresolved["ingress"].(model.Document)["protocol"])
+}
+
 func TestEvalFunction(t *testing.T) { //nolint
 	type funcTest struct {
 		name    string
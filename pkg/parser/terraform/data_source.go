@@ -7,7 +7,7 @@ import (
 	"sync"
 
 	"github.com/Checkmarx/kics/v2/pkg/builder/engine"
-	"github.com/Checkmarx/kics/v2/pkg/parser/terraform/functions"
+	"github.com/Checkmarx/kics/v2/pkg/parser/terraform/converter"
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hcldec"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
@@ -235,7 +235,7 @@ func parseDataSourceBody(body *hclsyntax.Body) string {
 
 	target, decodeErrs := hcldec.Decode(body, dataSourceSpec, &hcl.EvalContext{
 		Variables: inputVariableMap,
-		Functions: functions.TerraformFuncs,
+		Functions: converter.Functions(inputVariableMap),
 	})
 
 	// check decode errors
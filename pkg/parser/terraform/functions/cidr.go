@@ -0,0 +1,189 @@
+package functions
+
+import (
+	"fmt"
+	"math/big"
+	"net"
+
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+	"github.com/zclconf/go-cty/cty/gocty"
+)
+
+// CidrHostFunc - https://developer.hashicorp.com/terraform/language/functions/cidrhost
+var CidrHostFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "prefix",
+			Type: cty.String,
+		},
+		{
+			Name: "hostnum",
+			Type: cty.Number,
+		},
+	},
+	Type: function.StaticReturnType(cty.String),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		var hostNum *big.Int
+		if err := gocty.FromCtyValue(args[1], &hostNum); err != nil {
+			return cty.UnknownVal(cty.String), err
+		}
+		network, err := parseCIDR(args[0].AsString())
+		if err != nil {
+			return cty.UnknownVal(cty.String), err
+		}
+		ones, bits := network.Mask.Size()
+		hostCount := new(big.Int).Lsh(big.NewInt(1), uint(bits-ones))
+		if hostNum.Sign() < 0 {
+			hostNum = new(big.Int).Add(hostCount, hostNum)
+		}
+		if hostNum.Sign() < 0 || hostNum.Cmp(hostCount) >= 0 {
+			return cty.UnknownVal(cty.String), fmt.Errorf("prefix of %d does not accommodate a host numbered %s", ones, args[1].AsBigFloat().String())
+		}
+		ip := intToIP(new(big.Int).Add(ipToInt(network.IP), hostNum), bits)
+		return cty.StringVal(ip.String()), nil
+	},
+})
+
+// CidrNetmaskFunc - https://developer.hashicorp.com/terraform/language/functions/cidrnetmask
+var CidrNetmaskFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "prefix",
+			Type: cty.String,
+		},
+	},
+	Type: function.StaticReturnType(cty.String),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		network, err := parseCIDR(args[0].AsString())
+		if err != nil {
+			return cty.UnknownVal(cty.String), err
+		}
+		if network.IP.To4() == nil {
+			return cty.UnknownVal(cty.String), fmt.Errorf("IPv6 addresses cannot have a netmask: %s", args[0].AsString())
+		}
+		return cty.StringVal(net.IP(network.Mask).String()), nil
+	},
+})
+
+// CidrSubnetFunc - https://developer.hashicorp.com/terraform/language/functions/cidrsubnet
+var CidrSubnetFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "prefix",
+			Type: cty.String,
+		},
+		{
+			Name: "newbits",
+			Type: cty.Number,
+		},
+		{
+			Name: "netnum",
+			Type: cty.Number,
+		},
+	},
+	Type: function.StaticReturnType(cty.String),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		var newBits int
+		var netNum *big.Int
+		if err := gocty.FromCtyValue(args[1], &newBits); err != nil {
+			return cty.UnknownVal(cty.String), err
+		}
+		if err := gocty.FromCtyValue(args[2], &netNum); err != nil {
+			return cty.UnknownVal(cty.String), err
+		}
+		network, err := parseCIDR(args[0].AsString())
+		if err != nil {
+			return cty.UnknownVal(cty.String), err
+		}
+		ones, bits := network.Mask.Size()
+		if newBits < 0 || ones+newBits > bits {
+			return cty.UnknownVal(cty.String), fmt.Errorf("insufficient address space to extend prefix of %d by %d", ones, newBits)
+		}
+		maxNetNum := new(big.Int).Lsh(big.NewInt(1), uint(newBits))
+		if netNum.Sign() < 0 || netNum.Cmp(maxNetNum) >= 0 {
+			return cty.UnknownVal(cty.String), fmt.Errorf("prefix extension of %d does not accommodate a subnet numbered %s", newBits, netNum.String())
+		}
+		offset := new(big.Int).Lsh(netNum, uint(bits-ones-newBits))
+		subnet := &net.IPNet{
+			IP:   intToIP(new(big.Int).Or(ipToInt(network.IP), offset), bits),
+			Mask: net.CIDRMask(ones+newBits, bits),
+		}
+		return cty.StringVal(subnet.String()), nil
+	},
+})
+
+// CidrSubnetsFunc - https://developer.hashicorp.com/terraform/language/functions/cidrsubnets
+var CidrSubnetsFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "prefix",
+			Type: cty.String,
+		},
+	},
+	VarParam: &function.Parameter{
+		Name: "newbits",
+		Type: cty.Number,
+	},
+	Type: function.StaticReturnType(cty.List(cty.String)),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		network, err := parseCIDR(args[0].AsString())
+		if err != nil {
+			return cty.UnknownVal(retType), err
+		}
+		if len(args) == 1 {
+			return cty.ListValEmpty(cty.String), nil
+		}
+		ones, bits := network.Mask.Size()
+		start := ipToInt(network.IP)
+		end := new(big.Int).Add(start, new(big.Int).Lsh(big.NewInt(1), uint(bits-ones)))
+		next := new(big.Int).Set(start)
+
+		subnets := make([]cty.Value, 0, len(args)-1)
+		for _, arg := range args[1:] {
+			var newBits int
+			if err := gocty.FromCtyValue(arg, &newBits); err != nil {
+				return cty.UnknownVal(retType), err
+			}
+			if newBits < 1 || ones+newBits > bits {
+				return cty.UnknownVal(retType), fmt.Errorf("insufficient address space to extend prefix of %d by %d", ones, newBits)
+			}
+			size := new(big.Int).Lsh(big.NewInt(1), uint(bits-ones-newBits))
+			// subnets are aligned to their own size
+			if remainder := new(big.Int).Mod(new(big.Int).Sub(next, start), size); remainder.Sign() != 0 {
+				next.Add(next, new(big.Int).Sub(size, remainder))
+			}
+			if new(big.Int).Add(next, size).Cmp(end) > 0 {
+				return cty.UnknownVal(retType), fmt.Errorf("not enough remaining address space for a subnet with a prefix of %d bits", ones+newBits)
+			}
+			subnet := &net.IPNet{
+				IP:   intToIP(next, bits),
+				Mask: net.CIDRMask(ones+newBits, bits),
+			}
+			subnets = append(subnets, cty.StringVal(subnet.String()))
+			next = new(big.Int).Add(next, size)
+		}
+		return cty.ListVal(subnets), nil
+	},
+})
+
+func parseCIDR(prefix string) (*net.IPNet, error) {
+	_, network, err := net.ParseCIDR(prefix)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CIDR expression: %w", err)
+	}
+	return network, nil
+}
+
+func ipToInt(ip net.IP) *big.Int {
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+	}
+	return new(big.Int).SetBytes(ip)
+}
+
+func intToIP(value *big.Int, bits int) net.IP {
+	ip := make(net.IP, bits/8)
+	value.FillBytes(ip)
+	return ip
+}
@@ -0,0 +1,50 @@
+package functions
+
+import (
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+)
+
+// SensitiveFunc - https://developer.hashicorp.com/terraform/language/functions/sensitive
+// values are not marked during the static analysis so the value is returned as is
+var SensitiveFunc = makeIdentityFunction()
+
+// NonsensitiveFunc - https://developer.hashicorp.com/terraform/language/functions/nonsensitive
+var NonsensitiveFunc = makeIdentityFunction()
+
+// IsSensitiveFunc - https://developer.hashicorp.com/terraform/language/functions/issensitive
+var IsSensitiveFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name:             "value",
+			Type:             cty.DynamicPseudoType,
+			AllowUnknown:     true,
+			AllowNull:        true,
+			AllowDynamicType: true,
+		},
+	},
+	Type: function.StaticReturnType(cty.Bool),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		return cty.False, nil
+	},
+})
+
+func makeIdentityFunction() function.Function {
+	return function.New(&function.Spec{
+		Params: []function.Parameter{
+			{
+				Name:             "value",
+				Type:             cty.DynamicPseudoType,
+				AllowUnknown:     true,
+				AllowNull:        true,
+				AllowDynamicType: true,
+			},
+		},
+		Type: func(args []cty.Value) (cty.Type, error) {
+			return args[0].Type(), nil
+		},
+		Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+			return args[0], nil
+		},
+	})
+}
@@ -0,0 +1,120 @@
+package functions
+
+import (
+	"crypto/md5"  //nolint:gosec
+	"crypto/sha1" //nolint:gosec
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"hash"
+
+	"github.com/google/uuid"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+)
+
+// MD5Func - https://developer.hashicorp.com/terraform/language/functions/md5
+var MD5Func = makeStringHashFunction(md5.New, hex.EncodeToString)
+
+// SHA1Func - https://developer.hashicorp.com/terraform/language/functions/sha1
+var SHA1Func = makeStringHashFunction(sha1.New, hex.EncodeToString)
+
+// SHA256Func - https://developer.hashicorp.com/terraform/language/functions/sha256
+var SHA256Func = makeStringHashFunction(sha256.New, hex.EncodeToString)
+
+// SHA512Func - https://developer.hashicorp.com/terraform/language/functions/sha512
+var SHA512Func = makeStringHashFunction(sha512.New, hex.EncodeToString)
+
+// Base64SHA256Func - https://developer.hashicorp.com/terraform/language/functions/base64sha256
+var Base64SHA256Func = makeStringHashFunction(sha256.New, base64.StdEncoding.EncodeToString)
+
+// Base64SHA512Func - https://developer.hashicorp.com/terraform/language/functions/base64sha512
+var Base64SHA512Func = makeStringHashFunction(sha512.New, base64.StdEncoding.EncodeToString)
+
+// UUIDFunc - https://developer.hashicorp.com/terraform/language/functions/uuid
+// the result is different on every call so it is returned as unknown
+var UUIDFunc = makeUnknownFunction(cty.String)
+
+// BcryptFunc - https://developer.hashicorp.com/terraform/language/functions/bcrypt
+// the result is salted with a random value so it is returned as unknown
+var BcryptFunc = makeUnknownFunction(cty.String, cty.String)
+
+// RSADecryptFunc - https://developer.hashicorp.com/terraform/language/functions/rsadecrypt
+// private keys are not evaluated so the result is returned as unknown
+var RSADecryptFunc = makeUnknownFunction(cty.String, cty.String, cty.String)
+
+// UUIDV5Func - https://developer.hashicorp.com/terraform/language/functions/uuidv5
+var UUIDV5Func = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "namespace",
+			Type: cty.String,
+		},
+		{
+			Name: "name",
+			Type: cty.String,
+		},
+	},
+	Type: function.StaticReturnType(cty.String),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		var namespace uuid.UUID
+		switch ns := args[0].AsString(); ns {
+		case "dns":
+			namespace = uuid.NameSpaceDNS
+		case "oid":
+			namespace = uuid.NameSpaceOID
+		case "url":
+			namespace = uuid.NameSpaceURL
+		case "x500":
+			namespace = uuid.NameSpaceX500
+		default:
+			parsed, err := uuid.Parse(ns)
+			if err != nil {
+				return cty.UnknownVal(cty.String), fmt.Errorf("uuidv5() doesn't support namespace %s (%w)", ns, err)
+			}
+			namespace = parsed
+		}
+		return cty.StringVal(uuid.NewSHA1(namespace, []byte(args[1].AsString())).String()), nil
+	},
+})
+
+func makeStringHashFunction(hf func() hash.Hash, enc func([]byte) string) function.Function {
+	return function.New(&function.Spec{
+		Params: []function.Parameter{
+			{
+				Name: "str",
+				Type: cty.String,
+			},
+		},
+		Type: function.StaticReturnType(cty.String),
+		Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+			h := hf()
+			h.Write([]byte(args[0].AsString()))
+			return cty.StringVal(enc(h.Sum(nil))), nil
+		},
+	})
+}
+
+// makeUnknownFunction creates a function whose result is not known during the static analysis
+// (e.g. random or time dependent results)
+func makeUnknownFunction(retType cty.Type, params ...cty.Type) function.Function {
+	parameters := make([]function.Parameter, 0, len(params))
+	for idx, param := range params {
+		parameters = append(parameters, function.Parameter{
+			Name:             fmt.Sprintf("arg%d", idx),
+			Type:             param,
+			AllowUnknown:     true,
+			AllowNull:        true,
+			AllowDynamicType: true,
+		})
+	}
+	return function.New(&function.Spec{
+		Params: parameters,
+		Type:   function.StaticReturnType(retType),
+		Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+			return cty.UnknownVal(retType), nil
+		},
+	})
+}
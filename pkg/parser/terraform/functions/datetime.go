@@ -0,0 +1,49 @@
+package functions
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+)
+
+// TimestampFunc - https://developer.hashicorp.com/terraform/language/functions/timestamp
+// the result depends on when terraform runs so it is returned as unknown
+var TimestampFunc = makeUnknownFunction(cty.String)
+
+// PlanTimestampFunc - https://developer.hashicorp.com/terraform/language/functions/plantimestamp
+var PlanTimestampFunc = makeUnknownFunction(cty.String)
+
+// TimeCmpFunc - https://developer.hashicorp.com/terraform/language/functions/timecmp
+var TimeCmpFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "timestamp_a",
+			Type: cty.String,
+		},
+		{
+			Name: "timestamp_b",
+			Type: cty.String,
+		},
+	},
+	Type: function.StaticReturnType(cty.Number),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		tsA, err := time.Parse(time.RFC3339, args[0].AsString())
+		if err != nil {
+			return cty.UnknownVal(cty.Number), fmt.Errorf("invalid timestamp_a: %w", err)
+		}
+		tsB, err := time.Parse(time.RFC3339, args[1].AsString())
+		if err != nil {
+			return cty.UnknownVal(cty.Number), fmt.Errorf("invalid timestamp_b: %w", err)
+		}
+		switch {
+		case tsA.Equal(tsB):
+			return cty.NumberIntVal(0), nil
+		case tsA.Before(tsB):
+			return cty.NumberIntVal(-1), nil
+		default:
+			return cty.NumberIntVal(1), nil
+		}
+	},
+})
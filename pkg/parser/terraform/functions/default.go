@@ -3,6 +3,7 @@ package functions
 import (
 	"encoding/base64"
 
+	"github.com/hashicorp/hcl/v2/ext/tryfunc"
 	"github.com/zclconf/go-cty/cty"
 	"github.com/zclconf/go-cty/cty/function"
 	"github.com/zclconf/go-cty/cty/function/stdlib"
@@ -38,59 +39,134 @@ var Base64EncodeFunc = function.New(&function.Spec{
 	},
 })
 
-// TerraformFuncs contains all functions, if KICS has to override a function
-// it should create a file in this package and add/change this function key here
-var TerraformFuncs = map[string]function.Function{
-	"abs":             stdlib.AbsoluteFunc,
-	"base64encode":    Base64EncodeFunc,
-	"ceil":            stdlib.CeilFunc,
-	"chomp":           stdlib.ChompFunc,
-	"coalescelist":    stdlib.CoalesceListFunc,
-	"compact":         stdlib.CompactFunc,
-	"concat":          stdlib.ConcatFunc,
-	"contains":        stdlib.ContainsFunc,
-	"csvdecode":       stdlib.CSVDecodeFunc,
-	"distinct":        stdlib.DistinctFunc,
-	"element":         stdlib.ElementFunc,
-	"chunklist":       stdlib.ChunklistFunc,
-	"flatten":         stdlib.FlattenFunc,
-	"floor":           stdlib.FloorFunc,
-	"format":          stdlib.FormatFunc,
-	"formatdate":      stdlib.FormatDateFunc,
-	"formatlist":      stdlib.FormatListFunc,
-	"indent":          stdlib.IndentFunc,
-	"join":            stdlib.JoinFunc,
-	"jsondecode":      stdlib.JSONDecodeFunc,
-	"jsonencode":      stdlib.JSONEncodeFunc,
-	"keys":            stdlib.KeysFunc,
-	"log":             stdlib.LogFunc,
-	"lower":           stdlib.LowerFunc,
-	"max":             stdlib.MaxFunc,
-	"merge":           stdlib.MergeFunc,
-	"min":             stdlib.MinFunc,
-	"parseint":        stdlib.ParseIntFunc,
-	"pow":             stdlib.PowFunc,
-	"range":           stdlib.RangeFunc,
-	"regex":           stdlib.RegexFunc,
-	"regexall":        stdlib.RegexAllFunc,
-	"reverse":         stdlib.ReverseListFunc,
-	"setintersection": stdlib.SetIntersectionFunc,
-	"setproduct":      stdlib.SetProductFunc,
-	"setsubtract":     stdlib.SetSubtractFunc,
-	"setunion":        stdlib.SetUnionFunc,
-	"signum":          stdlib.SignumFunc,
-	"slice":           stdlib.SliceFunc,
-	"sort":            stdlib.SortFunc,
-	"split":           stdlib.SplitFunc,
-	"strrev":          stdlib.ReverseFunc,
-	"substr":          stdlib.SubstrFunc,
-	"timeadd":         stdlib.TimeAddFunc,
-	"title":           stdlib.TitleFunc,
-	"trim":            stdlib.TrimFunc,
-	"trimprefix":      stdlib.TrimPrefixFunc,
-	"trimspace":       stdlib.TrimSpaceFunc,
-	"trimsuffix":      stdlib.TrimSuffixFunc,
-	"upper":           stdlib.UpperFunc,
-	"values":          stdlib.ValuesFunc,
-	"zipmap":          stdlib.ZipmapFunc,
+// TerraformFuncs contains all functions, with the filesystem functions reading the files of the current directory,
+// if KICS has to override a function it should create a file in this package and add/change this function key in
+// terraformFuncs
+var TerraformFuncs = Functions(".", ".")
+
+// Functions returns all functions with the filesystem functions (file, fileset, templatefile, ...) resolving
+// relative paths from moduleDir (path.module) and reading only the files of moduleDir and rootDir (path.root),
+// the paths outside of them evaluate to unknown values
+func Functions(moduleDir, rootDir string) map[string]function.Function {
+	scope := &fileScope{moduleDir: moduleDir, rootDir: rootDir}
+	fileFuncs := fileFunctions(scope)
+	funcs := make(map[string]function.Function, len(terraformFuncs)+len(fileFuncs)+1)
+	for name, fn := range terraformFuncs {
+		funcs[name] = fn
+	}
+	for name, fn := range fileFuncs {
+		funcs[name] = fn
+	}
+	funcs["templatefile"] = makeTemplateFileFunction(scope, func() map[string]function.Function {
+		return funcs
+	})
+	return funcs
+}
+
+var terraformFuncs = map[string]function.Function{
+	"abs":              stdlib.AbsoluteFunc,
+	"abspath":          AbsPathFunc,
+	"alltrue":          AllTrueFunc,
+	"anytrue":          AnyTrueFunc,
+	"base64decode":     Base64DecodeFunc,
+	"base64encode":     Base64EncodeFunc,
+	"base64gzip":       Base64GzipFunc,
+	"base64sha256":     Base64SHA256Func,
+	"base64sha512":     Base64SHA512Func,
+	"basename":         BasenameFunc,
+	"bcrypt":           BcryptFunc,
+	"can":              tryfunc.CanFunc,
+	"ceil":             stdlib.CeilFunc,
+	"chomp":            stdlib.ChompFunc,
+	"chunklist":        stdlib.ChunklistFunc,
+	"cidrhost":         CidrHostFunc,
+	"cidrnetmask":      CidrNetmaskFunc,
+	"cidrsubnet":       CidrSubnetFunc,
+	"cidrsubnets":      CidrSubnetsFunc,
+	"coalesce":         stdlib.CoalesceFunc,
+	"coalescelist":     stdlib.CoalesceListFunc,
+	"compact":          stdlib.CompactFunc,
+	"concat":           stdlib.ConcatFunc,
+	"contains":         stdlib.ContainsFunc,
+	"csvdecode":        stdlib.CSVDecodeFunc,
+	"dirname":          DirnameFunc,
+	"distinct":         stdlib.DistinctFunc,
+	"element":          stdlib.ElementFunc,
+	"endswith":         EndsWithFunc,
+	"flatten":          stdlib.FlattenFunc,
+	"floor":            stdlib.FloorFunc,
+	"format":           stdlib.FormatFunc,
+	"formatdate":       stdlib.FormatDateFunc,
+	"formatlist":       stdlib.FormatListFunc,
+	"indent":           stdlib.IndentFunc,
+	"index":            IndexFunc,
+	"issensitive":      IsSensitiveFunc,
+	"join":             stdlib.JoinFunc,
+	"jsondecode":       stdlib.JSONDecodeFunc,
+	"jsonencode":       stdlib.JSONEncodeFunc,
+	"keys":             stdlib.KeysFunc,
+	"length":           LengthFunc,
+	"log":              stdlib.LogFunc,
+	"lookup":           stdlib.LookupFunc,
+	"lower":            stdlib.LowerFunc,
+	"matchkeys":        MatchkeysFunc,
+	"max":              stdlib.MaxFunc,
+	"md5":              MD5Func,
+	"merge":            stdlib.MergeFunc,
+	"min":              stdlib.MinFunc,
+	"nonsensitive":     NonsensitiveFunc,
+	"one":              OneFunc,
+	"parseint":         stdlib.ParseIntFunc,
+	"pathexpand":       PathExpandFunc,
+	"plantimestamp":    PlanTimestampFunc,
+	"pow":              stdlib.PowFunc,
+	"range":            stdlib.RangeFunc,
+	"regex":            stdlib.RegexFunc,
+	"regexall":         stdlib.RegexAllFunc,
+	"replace":          ReplaceFunc,
+	"reverse":          stdlib.ReverseListFunc,
+	"rsadecrypt":       RSADecryptFunc,
+	"sensitive":        SensitiveFunc,
+	"setintersection":  stdlib.SetIntersectionFunc,
+	"setproduct":       stdlib.SetProductFunc,
+	"setsubtract":      stdlib.SetSubtractFunc,
+	"setunion":         stdlib.SetUnionFunc,
+	"sha1":             SHA1Func,
+	"sha256":           SHA256Func,
+	"sha512":           SHA512Func,
+	"signum":           stdlib.SignumFunc,
+	"slice":            stdlib.SliceFunc,
+	"sort":             stdlib.SortFunc,
+	"split":            stdlib.SplitFunc,
+	"startswith":       StartsWithFunc,
+	"strcontains":      StrContainsFunc,
+	"strrev":           stdlib.ReverseFunc,
+	"substr":           stdlib.SubstrFunc,
+	"sum":              SumFunc,
+	"textdecodebase64": TextDecodeBase64Func,
+	"textencodebase64": TextEncodeBase64Func,
+	"timeadd":          stdlib.TimeAddFunc,
+	"timecmp":          TimeCmpFunc,
+	"timestamp":        TimestampFunc,
+	"title":            stdlib.TitleFunc,
+	"tobool":           stdlib.MakeToFunc(cty.Bool),
+	"tolist":           stdlib.MakeToFunc(cty.List(cty.DynamicPseudoType)),
+	"tomap":            stdlib.MakeToFunc(cty.Map(cty.DynamicPseudoType)),
+	"tonumber":         stdlib.MakeToFunc(cty.Number),
+	"toset":            stdlib.MakeToFunc(cty.Set(cty.DynamicPseudoType)),
+	"tostring":         stdlib.MakeToFunc(cty.String),
+	"transpose":        TransposeFunc,
+	"trim":             stdlib.TrimFunc,
+	"trimprefix":       stdlib.TrimPrefixFunc,
+	"trimspace":        stdlib.TrimSpaceFunc,
+	"trimsuffix":       stdlib.TrimSuffixFunc,
+	"try":              tryfunc.TryFunc,
+	"upper":            stdlib.UpperFunc,
+	"urlencode":        URLEncodeFunc,
+	"uuid":             UUIDFunc,
+	"uuidv5":           UUIDV5Func,
+	"values":           stdlib.ValuesFunc,
+	"yamldecode":       YAMLDecodeFunc,
+	"yamlencode":       YAMLEncodeFunc,
+	"zipmap":           stdlib.ZipmapFunc,
 }
@@ -0,0 +1,344 @@
+package functions
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+)
+
+var fixturesDir = filepath.FromSlash("../../../../test/fixtures/test_terraform_functions")
+
+func TestFunctions(t *testing.T) {
+	tests := []struct {
+		name    string
+		expr    string
+		want    cty.Value
+		wantErr bool
+	}{
+		{
+			name: "jsonencode policy",
+			expr: `jsonencode({ Version = "2012-10-17", Statement = [{ Effect = "Allow", Action = "s3:*" }] })`,
+			want: cty.StringVal(`{"Statement":[{"Action":"s3:*","Effect":"Allow"}],"Version":"2012-10-17"}`),
+		},
+		{
+			name: "cidrsubnet",
+			expr: `cidrsubnet("10.0.0.0/16", 8, 2)`,
+			want: cty.StringVal("10.0.2.0/24"),
+		},
+		{
+			name: "cidrsubnet ipv6",
+			expr: `cidrsubnet("fd00:fd12:3456:7890::/56", 16, 162)`,
+			want: cty.StringVal("fd00:fd12:3456:7800:a200::/72"),
+		},
+		{
+			name:    "cidrsubnet out of range",
+			expr:    `cidrsubnet("10.0.0.0/16", 8, 256)`,
+			wantErr: true,
+		},
+		{
+			name: "cidrsubnets",
+			expr: `cidrsubnets("10.1.0.0/16", 4, 4, 8, 4)`,
+			want: cty.ListVal([]cty.Value{
+				cty.StringVal("10.1.0.0/20"),
+				cty.StringVal("10.1.16.0/20"),
+				cty.StringVal("10.1.32.0/24"),
+				cty.StringVal("10.1.48.0/20"),
+			}),
+		},
+		{
+			name: "cidrhost",
+			expr: `cidrhost("10.12.112.0/20", -2)`,
+			want: cty.StringVal("10.12.127.254"),
+		},
+		{
+			name: "cidrnetmask",
+			expr: `cidrnetmask("172.16.0.0/12")`,
+			want: cty.StringVal("255.240.0.0"),
+		},
+		{
+			name: "lookup with default",
+			expr: `lookup({ a = "ay", b = "bee" }, "c", "what?")`,
+			want: cty.StringVal("what?"),
+		},
+		{
+			name: "try falls back",
+			expr: `try({ a = 1 }.b, "fallback")`,
+			want: cty.StringVal("fallback"),
+		},
+		{
+			name: "can",
+			expr: `can(regex("^ami-", "ami-123"))`,
+			want: cty.True,
+		},
+		{
+			name: "merge",
+			expr: `merge({ a = "b", c = "d" }, { e = "f", c = "z" })`,
+			want: cty.ObjectVal(map[string]cty.Value{
+				"a": cty.StringVal("b"),
+				"c": cty.StringVal("z"),
+				"e": cty.StringVal("f"),
+			}),
+		},
+		{
+			name: "tomap",
+			expr: `tomap({ a = 1, b = 2 })`,
+			want: cty.MapVal(map[string]cty.Value{
+				"a": cty.NumberIntVal(1),
+				"b": cty.NumberIntVal(2),
+			}),
+		},
+		{
+			name: "yamldecode",
+			expr: `yamldecode("acl: private\nversioning:\n  enabled: true\n")`,
+			want: cty.ObjectVal(map[string]cty.Value{
+				"acl": cty.StringVal("private"),
+				"versioning": cty.ObjectVal(map[string]cty.Value{
+					"enabled": cty.True,
+				}),
+			}),
+		},
+		{
+			name: "yamlencode",
+			expr: `yamlencode({ a = "b" })`,
+			want: cty.StringVal("a: b\n"),
+		},
+		{
+			name: "base64 round trip",
+			expr: `base64decode(base64encode("kics"))`,
+			want: cty.StringVal("kics"),
+		},
+		{
+			name: "sha256",
+			expr: `sha256("hello world")`,
+			want: cty.StringVal("b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9"),
+		},
+		{
+			name: "uuidv5",
+			expr: `uuidv5("dns", "www.terraform.io")`,
+			want: cty.StringVal("a5008fae-b28c-5ba5-96cd-82b4c53552d6"),
+		},
+		{
+			name: "uuid is unknown",
+			expr: `uuid()`,
+			want: cty.UnknownVal(cty.String),
+		},
+		{
+			name: "length of string",
+			expr: `length("kics")`,
+			want: cty.NumberIntVal(4),
+		},
+		{
+			name: "index",
+			expr: `index(["a", "b", "c"], "b")`,
+			want: cty.NumberIntVal(1),
+		},
+		{
+			name: "one",
+			expr: `one(["only"])`,
+			want: cty.StringVal("only"),
+		},
+		{
+			name: "sum",
+			expr: `sum([1, 2, 3.5])`,
+			want: cty.NumberFloatVal(6.5),
+		},
+		{
+			name: "alltrue",
+			expr: `alltrue([true, false])`,
+			want: cty.False,
+		},
+		{
+			name: "anytrue",
+			expr: `anytrue([false, true])`,
+			want: cty.True,
+		},
+		{
+			name: "matchkeys",
+			expr: `matchkeys(["i-1", "i-2", "i-3"], ["us-1", "us-2", "us-1"], ["us-1"])`,
+			want: cty.ListVal([]cty.Value{cty.StringVal("i-1"), cty.StringVal("i-3")}),
+		},
+		{
+			name: "transpose",
+			expr: `transpose({ a = ["1", "2"], b = ["2"] })`,
+			want: cty.MapVal(map[string]cty.Value{
+				"1": cty.ListVal([]cty.Value{cty.StringVal("a")}),
+				"2": cty.ListVal([]cty.Value{cty.StringVal("a"), cty.StringVal("b")}),
+			}),
+		},
+		{
+			name: "replace with regex",
+			expr: `replace("hello world", "/w.*d/", "kics")`,
+			want: cty.StringVal("hello kics"),
+		},
+		{
+			name: "startswith",
+			expr: `startswith("arn:aws:s3", "arn:")`,
+			want: cty.True,
+		},
+		{
+			name: "timecmp",
+			expr: `timecmp("2017-11-22T00:00:00Z", "2017-11-22T01:00:00+01:00")`,
+			want: cty.NumberIntVal(0),
+		},
+		{
+			name: "file relative to the module",
+			expr: `file("policy.json")`,
+			want: cty.StringVal("{\n  \"Version\": \"2012-10-17\"\n}\n"),
+		},
+		{
+			name: "jsondecode of file",
+			expr: `jsondecode(file("policy.json")).Version`,
+			want: cty.StringVal("2012-10-17"),
+		},
+		{
+			name:    "file not found",
+			expr:    `file("missing.json")`,
+			wantErr: true,
+		},
+		{
+			name: "fileexists",
+			expr: `fileexists("missing.json")`,
+			want: cty.False,
+		},
+		{
+			name: "fileset",
+			expr: `fileset("files", "**/*.txt")`,
+			want: cty.SetVal([]cty.Value{cty.StringVal("a.txt"), cty.StringVal("nested/b.txt")}),
+		},
+		{
+			name: "fileset with alternatives",
+			expr: `fileset("files", "*.{json,txt}")`,
+			want: cty.SetVal([]cty.Value{cty.StringVal("a.txt"), cty.StringVal("c.json")}),
+		},
+		{
+			name: "templatefile",
+			expr: `templatefile("user_data.tpl", { name = "kics", ports = [80, 443] })`,
+			want: cty.StringVal("#!/bin/bash\necho \"kics\" > /tmp/80443\necho KICS\n"),
+		},
+		{
+			name: "file outside of the module and root directories",
+			expr: `file("../../../../go.mod")`,
+			want: cty.UnknownVal(cty.String),
+		},
+		{
+			name: "absolute file outside of the module and root directories",
+			expr: `filebase64("/etc/hostname")`,
+			want: cty.UnknownVal(cty.String),
+		},
+		{
+			name: "file of the home directory",
+			expr: `file("~/.aws/credentials")`,
+			want: cty.UnknownVal(cty.String),
+		},
+		{
+			name: "fileexists outside of the module and root directories",
+			expr: `fileexists("/etc/hostname")`,
+			want: cty.UnknownVal(cty.Bool),
+		},
+		{
+			name: "fileset outside of the module and root directories",
+			expr: `fileset("/etc", "*")`,
+			want: cty.UnknownVal(cty.Set(cty.String)),
+		},
+		{
+			name: "templatefile outside of the module and root directories",
+			expr: `templatefile("../../../../go.mod", {})`,
+			want: cty.UnknownVal(cty.String),
+		},
+		{
+			name: "basename",
+			expr: `basename("foo/bar/baz.txt")`,
+			want: cty.StringVal("baz.txt"),
+		},
+	}
+
+	funcs := Functions(fixturesDir, fixturesDir)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr, diags := hclsyntax.ParseExpression([]byte(tt.expr), "test.tf", hcl.Pos{Line: 1, Column: 1})
+			require.False(t, diags.HasErrors(), diags.Error())
+
+			got, diags := expr.Value(&hcl.EvalContext{Functions: funcs})
+			if tt.wantErr {
+				require.True(t, diags.HasErrors())
+				return
+			}
+			require.False(t, diags.HasErrors(), diags.Error())
+			require.True(t, tt.want.RawEquals(got), "expected %#v, got %#v", tt.want, got)
+		})
+	}
+}
+
+func TestTerraformFuncs(t *testing.T) {
+	// every function must be available without a module directory
+	for name := range terraformFuncs {
+		require.Contains(t, TerraformFuncs, name)
+	}
+	for _, name := range []string{"file", "fileset", "filebase64", "templatefile", "filesha256"} {
+		require.Contains(t, TerraformFuncs, name)
+	}
+}
+
+func TestFunctionsScope(t *testing.T) {
+	dir := t.TempDir()
+	root := filepath.Join(dir, "root")
+	module := filepath.Join(dir, "modules", "bucket")
+	outside := filepath.Join(dir, "outside")
+	for _, d := range []string{root, module, outside} {
+		require.NoError(t, os.MkdirAll(d, os.ModePerm))
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(root, "root.txt"), []byte("root"), os.ModePerm))
+	require.NoError(t, os.WriteFile(filepath.Join(module, "module.txt"), []byte("module"), os.ModePerm))
+	require.NoError(t, os.WriteFile(filepath.Join(outside, "secret.txt"), []byte("secret"), os.ModePerm))
+	require.NoError(t, os.Symlink(filepath.Join(outside, "secret.txt"), filepath.Join(module, "link.txt")))
+
+	funcs := Functions(module, root)
+	eval := func(src string) cty.Value {
+		expr, diags := hclsyntax.ParseExpression([]byte(src), "test.tf", hcl.Pos{Line: 1, Column: 1})
+		require.False(t, diags.HasErrors(), diags.Error())
+		got, diags := expr.Value(&hcl.EvalContext{Functions: funcs})
+		require.False(t, diags.HasErrors(), diags.Error())
+		return got
+	}
+
+	require.Equal(t, cty.StringVal("module"), eval(`file("module.txt")`))
+	require.Equal(t, cty.StringVal("root"), eval(`file("../../root/root.txt")`))
+	require.False(t, eval(`file("../../outside/secret.txt")`).IsKnown())
+	require.False(t, eval(`file("link.txt")`).IsKnown(), "symbolic links are followed")
+}
+
+func TestReadPaths(t *testing.T) {
+	module := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(module, "templates", "nested"), os.ModePerm))
+	require.NoError(t, os.WriteFile(filepath.Join(module, "templates", "nested", "policy.json"), []byte("{}"),
+		os.ModePerm))
+
+	funcs := Functions(module, module)
+	eval := func(src string) {
+		expr, diags := hclsyntax.ParseExpression([]byte(src), "test.tf", hcl.Pos{Line: 1, Column: 1})
+		require.False(t, diags.HasErrors(), diags.Error())
+		_, diags = expr.Value(&hcl.EvalContext{Functions: funcs})
+		require.False(t, diags.HasErrors(), diags.Error())
+	}
+
+	ResetReadPaths()
+	eval(`file("templates/nested/policy.json")`)
+	eval(`fileexists("missing.txt")`)
+	eval(`file("/etc/hostname")`)
+	require.Equal(t, []string{
+		filepath.Join(module, "missing.txt"),
+		filepath.Join(module, "templates", "nested", "policy.json"),
+	}, ReadPaths(), "the files outside of the scope are not read")
+
+	ResetReadPaths()
+	eval(`fileset("templates", "**/*.json")`)
+	require.Equal(t, []string{
+		filepath.Join(module, "templates"),
+		filepath.Join(module, "templates", "nested"),
+	}, ReadPaths(), "fileset depends on the entries of the directories it walks")
+}
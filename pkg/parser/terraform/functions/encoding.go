@@ -0,0 +1,198 @@
+package functions
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"unicode/utf8"
+
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+	"golang.org/x/text/encoding/ianaindex"
+	"gopkg.in/yaml.v3"
+)
+
+// Base64DecodeFunc - https://developer.hashicorp.com/terraform/language/functions/base64decode
+var Base64DecodeFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "str",
+			Type: cty.String,
+		},
+	},
+	Type: function.StaticReturnType(cty.String),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		decoded, err := base64.StdEncoding.DecodeString(args[0].AsString())
+		if err != nil {
+			return cty.UnknownVal(cty.String), fmt.Errorf("failed to decode base64 data: %w", err)
+		}
+		if !utf8.Valid(decoded) {
+			return cty.UnknownVal(cty.String), fmt.Errorf("the result of decoding the provided string is not valid UTF-8")
+		}
+		return cty.StringVal(string(decoded)), nil
+	},
+})
+
+// Base64GzipFunc - https://developer.hashicorp.com/terraform/language/functions/base64gzip
+var Base64GzipFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "str",
+			Type: cty.String,
+		},
+	},
+	Type: function.StaticReturnType(cty.String),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		var b bytes.Buffer
+		gz := gzip.NewWriter(&b)
+		if _, err := gz.Write([]byte(args[0].AsString())); err != nil {
+			return cty.UnknownVal(cty.String), fmt.Errorf("failed to write gzip raw data: %w", err)
+		}
+		if err := gz.Close(); err != nil {
+			return cty.UnknownVal(cty.String), fmt.Errorf("failed to close gzip writer: %w", err)
+		}
+		return cty.StringVal(base64.StdEncoding.EncodeToString(b.Bytes())), nil
+	},
+})
+
+// TextEncodeBase64Func - https://developer.hashicorp.com/terraform/language/functions/textencodebase64
+var TextEncodeBase64Func = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "string",
+			Type: cty.String,
+		},
+		{
+			Name: "encoding",
+			Type: cty.String,
+		},
+	},
+	Type: function.StaticReturnType(cty.String),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		encoding, err := ianaindex.IANA.Encoding(args[1].AsString())
+		if err != nil || encoding == nil {
+			return cty.UnknownVal(cty.String), fmt.Errorf("%q is not a supported IANA encoding name", args[1].AsString())
+		}
+		encoded, err := encoding.NewEncoder().String(args[0].AsString())
+		if err != nil {
+			return cty.UnknownVal(cty.String), fmt.Errorf("the given string can not be encoded with %s", args[1].AsString())
+		}
+		return cty.StringVal(base64.StdEncoding.EncodeToString([]byte(encoded))), nil
+	},
+})
+
+// TextDecodeBase64Func - https://developer.hashicorp.com/terraform/language/functions/textdecodebase64
+var TextDecodeBase64Func = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "source",
+			Type: cty.String,
+		},
+		{
+			Name: "encoding",
+			Type: cty.String,
+		},
+	},
+	Type: function.StaticReturnType(cty.String),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		encoding, err := ianaindex.IANA.Encoding(args[1].AsString())
+		if err != nil || encoding == nil {
+			return cty.UnknownVal(cty.String), fmt.Errorf("%q is not a supported IANA encoding name", args[1].AsString())
+		}
+		source, err := base64.StdEncoding.DecodeString(args[0].AsString())
+		if err != nil {
+			return cty.UnknownVal(cty.String), fmt.Errorf("failed to decode base64 data: %w", err)
+		}
+		decoded, err := encoding.NewDecoder().Bytes(source)
+		if err != nil || !utf8.Valid(decoded) {
+			return cty.UnknownVal(cty.String), fmt.Errorf("the given value can not be decoded with %s", args[1].AsString())
+		}
+		return cty.StringVal(string(decoded)), nil
+	},
+})
+
+// URLEncodeFunc - https://developer.hashicorp.com/terraform/language/functions/urlencode
+var URLEncodeFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "str",
+			Type: cty.String,
+		},
+	},
+	Type: function.StaticReturnType(cty.String),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		return cty.StringVal(url.QueryEscape(args[0].AsString())), nil
+	},
+})
+
+// YAMLDecodeFunc - https://developer.hashicorp.com/terraform/language/functions/yamldecode
+// the YAML document is converted to JSON so the resulting value has the same types as jsondecode
+var YAMLDecodeFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "src",
+			Type: cty.String,
+		},
+	},
+	Type: func(args []cty.Value) (cty.Type, error) {
+		if !args[0].IsKnown() {
+			return cty.DynamicPseudoType, nil
+		}
+		jsonContent, err := yamlToJSON(args[0].AsString())
+		if err != nil {
+			return cty.NilType, err
+		}
+		return ctyjson.ImpliedType(jsonContent)
+	},
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		jsonContent, err := yamlToJSON(args[0].AsString())
+		if err != nil {
+			return cty.NilVal, err
+		}
+		return ctyjson.Unmarshal(jsonContent, retType)
+	},
+})
+
+// YAMLEncodeFunc - https://developer.hashicorp.com/terraform/language/functions/yamlencode
+var YAMLEncodeFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name:             "value",
+			Type:             cty.DynamicPseudoType,
+			AllowDynamicType: true,
+			AllowNull:        true,
+		},
+	},
+	Type: function.StaticReturnType(cty.String),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		val := args[0]
+		if !val.IsWhollyKnown() {
+			return cty.UnknownVal(retType), nil
+		}
+		jsonContent, err := ctyjson.Marshal(val, val.Type())
+		if err != nil {
+			return cty.UnknownVal(retType), err
+		}
+		var content interface{}
+		if err := json.Unmarshal(jsonContent, &content); err != nil {
+			return cty.UnknownVal(retType), err
+		}
+		yamlContent, err := yaml.Marshal(content)
+		if err != nil {
+			return cty.UnknownVal(retType), err
+		}
+		return cty.StringVal(string(yamlContent)), nil
+	},
+})
+
+func yamlToJSON(src string) ([]byte, error) {
+	var content interface{}
+	if err := yaml.Unmarshal([]byte(src), &content); err != nil {
+		return nil, fmt.Errorf("failed to decode YAML: %w", err)
+	}
+	return json.Marshal(content)
+}
@@ -0,0 +1,444 @@
+package functions
+
+import (
+	"crypto/md5"  //nolint:gosec
+	"crypto/sha1" //nolint:gosec
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+	"github.com/zclconf/go-cty/cty/function"
+)
+
+// AbsPathFunc - https://developer.hashicorp.com/terraform/language/functions/abspath
+var AbsPathFunc = makePathFunction(func(path string) (string, error) {
+	absPath, err := filepath.Abs(path)
+	return filepath.ToSlash(absPath), err
+})
+
+// DirnameFunc - https://developer.hashicorp.com/terraform/language/functions/dirname
+var DirnameFunc = makePathFunction(func(path string) (string, error) {
+	return filepath.Dir(path), nil
+})
+
+// BasenameFunc - https://developer.hashicorp.com/terraform/language/functions/basename
+var BasenameFunc = makePathFunction(func(path string) (string, error) {
+	return filepath.Base(path), nil
+})
+
+// PathExpandFunc - https://developer.hashicorp.com/terraform/language/functions/pathexpand
+var PathExpandFunc = makePathFunction(func(path string) (string, error) {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, path[1:]), nil
+})
+
+// readPaths records the paths read by the filesystem functions, so the documents evaluated with them can be parsed
+// again when those files change. Like the input variables of the Terraform parser, it holds the reads of the file
+// being parsed, see ResetReadPaths
+var readPaths = struct {
+	sync.Mutex
+	paths map[string]struct{}
+}{paths: make(map[string]struct{})}
+
+// ResetReadPaths forgets the paths read by the filesystem functions so far
+func ResetReadPaths() {
+	readPaths.Lock()
+	defer readPaths.Unlock()
+	readPaths.paths = make(map[string]struct{})
+}
+
+// ReadPaths returns the sorted files and directories read by the filesystem functions since ResetReadPaths,
+// including the files that did not exist when they were read
+func ReadPaths() []string {
+	readPaths.Lock()
+	defer readPaths.Unlock()
+	paths := make([]string, 0, len(readPaths.paths))
+	for path := range readPaths.paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+func recordRead(path string) {
+	readPaths.Lock()
+	defer readPaths.Unlock()
+	readPaths.paths[path] = struct{}{}
+}
+
+// fileScope resolves the paths read by the filesystem functions, relative paths are resolved from the module
+// directory and only the files of the module and root directories can be read, so a scanned configuration can not
+// put the files of the host running the scan in its documents
+type fileScope struct {
+	moduleDir string
+	rootDir   string
+}
+
+// resolve returns the path resolved from the module directory, false when it is outside of the module and root
+// directories, following symbolic links
+func (s *fileScope) resolve(path string) (string, bool) {
+	if strings.HasPrefix(path, "~") {
+		return "", false
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(s.moduleDir, path)
+	}
+	path = filepath.Clean(path)
+	if !s.contains(path) {
+		return "", false
+	}
+	if realPath, err := filepath.EvalSymlinks(path); err == nil && !s.contains(realPath) {
+		return "", false
+	}
+	recordRead(path)
+	return path, true
+}
+
+func (s *fileScope) contains(path string) bool {
+	for _, dir := range []string{s.moduleDir, s.rootDir} {
+		if isWithin(dir, path) {
+			return true
+		}
+		if realDir, err := filepath.EvalSymlinks(dir); err == nil && isWithin(realDir, path) {
+			return true
+		}
+	}
+	return false
+}
+
+func isWithin(dir, path string) bool {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return false
+	}
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return false
+	}
+	rel, err := filepath.Rel(absDir, absPath)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// fileFunctions returns the functions that read files of the scope
+func fileFunctions(scope *fileScope) map[string]function.Function {
+	return map[string]function.Function{
+		"file":             makeFileFunction(scope, readFileString),
+		"filebase64":       makeFileFunction(scope, encodeFile(base64.StdEncoding.EncodeToString)),
+		"filebase64sha256": makeFileFunction(scope, hashFile(sha256.New, base64.StdEncoding.EncodeToString)),
+		"filebase64sha512": makeFileFunction(scope, hashFile(sha512.New, base64.StdEncoding.EncodeToString)),
+		"fileexists":       makeFileExistsFunction(scope),
+		"filemd5":          makeFileFunction(scope, hashFile(md5.New, hex.EncodeToString)),
+		"fileset":          makeFileSetFunction(scope),
+		"filesha1":         makeFileFunction(scope, hashFile(sha1.New, hex.EncodeToString)),
+		"filesha256":       makeFileFunction(scope, hashFile(sha256.New, hex.EncodeToString)),
+		"filesha512":       makeFileFunction(scope, hashFile(sha512.New, hex.EncodeToString)),
+	}
+}
+
+// makeTemplateFileFunction creates the templatefile function, templates are evaluated with funcs
+// https://developer.hashicorp.com/terraform/language/functions/templatefile
+func makeTemplateFileFunction(scope *fileScope, funcs func() map[string]function.Function) function.Function {
+	return function.New(&function.Spec{
+		Params: []function.Parameter{
+			{
+				Name: "path",
+				Type: cty.String,
+			},
+			{
+				Name: "vars",
+				Type: cty.DynamicPseudoType,
+			},
+		},
+		Type: function.StaticReturnType(cty.String),
+		Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+			path, ok := scope.resolve(args[0].AsString())
+			if !ok {
+				return cty.UnknownVal(cty.String), nil
+			}
+			content, err := readFileString(path)
+			if err != nil {
+				return cty.UnknownVal(cty.String), err
+			}
+			vars := args[1]
+			if !vars.Type().IsMapType() && !vars.Type().IsObjectType() {
+				return cty.UnknownVal(cty.String), errors.New("invalid vars value: must be a map")
+			}
+			if !vars.IsWhollyKnown() {
+				return cty.UnknownVal(cty.String), nil
+			}
+
+			expr, diags := hclsyntax.ParseTemplate([]byte(content), args[0].AsString(), hcl.Pos{Line: 1, Column: 1})
+			if diags.HasErrors() {
+				return cty.UnknownVal(cty.String), diags
+			}
+			variables := make(map[string]cty.Value)
+			for it := vars.ElementIterator(); it.Next(); {
+				key, value := it.Element()
+				variables[key.AsString()] = value
+			}
+			// templatefile can not be called recursively
+			templateFuncs := make(map[string]function.Function)
+			for name, fn := range funcs() {
+				if name != "templatefile" {
+					templateFuncs[name] = fn
+				}
+			}
+
+			result, diags := expr.Value(&hcl.EvalContext{
+				Variables: variables,
+				Functions: templateFuncs,
+			})
+			if diags.HasErrors() {
+				return cty.UnknownVal(cty.String), diags
+			}
+			return convert.Convert(result, cty.String)
+		},
+	})
+}
+
+func makeFileFunction(scope *fileScope, read func(path string) (string, error)) function.Function {
+	return function.New(&function.Spec{
+		Params: []function.Parameter{
+			{
+				Name: "path",
+				Type: cty.String,
+			},
+		},
+		Type: function.StaticReturnType(cty.String),
+		Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+			path, ok := scope.resolve(args[0].AsString())
+			if !ok {
+				return cty.UnknownVal(cty.String), nil
+			}
+			content, err := read(path)
+			if err != nil {
+				return cty.UnknownVal(cty.String), err
+			}
+			return cty.StringVal(content), nil
+		},
+	})
+}
+
+func makeFileExistsFunction(scope *fileScope) function.Function {
+	return function.New(&function.Spec{
+		Params: []function.Parameter{
+			{
+				Name: "path",
+				Type: cty.String,
+			},
+		},
+		Type: function.StaticReturnType(cty.Bool),
+		Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+			path, ok := scope.resolve(args[0].AsString())
+			if !ok {
+				return cty.UnknownVal(cty.Bool), nil
+			}
+			info, err := os.Stat(path)
+			if err != nil {
+				if os.IsNotExist(err) {
+					return cty.False, nil
+				}
+				return cty.UnknownVal(cty.Bool), err
+			}
+			if !info.Mode().IsRegular() {
+				return cty.UnknownVal(cty.Bool), fmt.Errorf("%s is not a regular file", args[0].AsString())
+			}
+			return cty.True, nil
+		},
+	})
+}
+
+// makeFileSetFunction creates the fileset function, the pattern supports the same syntax as terraform
+// (*, **, ?, [class] and {alternatives})
+// https://developer.hashicorp.com/terraform/language/functions/fileset
+func makeFileSetFunction(scope *fileScope) function.Function {
+	return function.New(&function.Spec{
+		Params: []function.Parameter{
+			{
+				Name: "path",
+				Type: cty.String,
+			},
+			{
+				Name: "pattern",
+				Type: cty.String,
+			},
+		},
+		Type: function.StaticReturnType(cty.Set(cty.String)),
+		Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+			root, ok := scope.resolve(args[0].AsString())
+			if !ok {
+				return cty.UnknownVal(retType), nil
+			}
+			pattern, err := globToRegex(args[1].AsString())
+			if err != nil {
+				return cty.UnknownVal(retType), err
+			}
+
+			matches := make([]string, 0)
+			err = filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+				if err != nil {
+					return err
+				}
+				if entry.IsDir() {
+					// the set changes when files are added to or removed from the directories walked
+					recordRead(path)
+					return nil
+				}
+				if !entry.Type().IsRegular() {
+					return nil
+				}
+				rel, err := filepath.Rel(root, path)
+				if err != nil {
+					return err
+				}
+				if rel = filepath.ToSlash(rel); pattern.MatchString(rel) {
+					matches = append(matches, rel)
+				}
+				return nil
+			})
+			if err != nil && !os.IsNotExist(err) {
+				return cty.UnknownVal(retType), fmt.Errorf("failed to glob pattern %s: %w", args[1].AsString(), err)
+			}
+			if len(matches) == 0 {
+				return cty.SetValEmpty(cty.String), nil
+			}
+			sort.Strings(matches)
+			values := make([]cty.Value, 0, len(matches))
+			for _, match := range matches {
+				values = append(values, cty.StringVal(match))
+			}
+			return cty.SetVal(values), nil
+		},
+	})
+}
+
+func makePathFunction(transform func(path string) (string, error)) function.Function {
+	return function.New(&function.Spec{
+		Params: []function.Parameter{
+			{
+				Name: "path",
+				Type: cty.String,
+			},
+		},
+		Type: function.StaticReturnType(cty.String),
+		Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+			path, err := transform(args[0].AsString())
+			if err != nil {
+				return cty.UnknownVal(cty.String), err
+			}
+			return cty.StringVal(path), nil
+		},
+	})
+}
+
+func readFileString(path string) (string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read file %s: %w", path, err)
+	}
+	if !utf8.Valid(content) {
+		return "", fmt.Errorf("contents of %s are not valid UTF-8", path)
+	}
+	return string(content), nil
+}
+
+func encodeFile(enc func([]byte) string) func(path string) (string, error) {
+	return func(path string) (string, error) {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("failed to read file %s: %w", path, err)
+		}
+		return enc(content), nil
+	}
+}
+
+func hashFile(hf func() hash.Hash, enc func([]byte) string) func(path string) (string, error) {
+	return encodeFile(func(content []byte) string {
+		h := hf()
+		h.Write(content)
+		return enc(h.Sum(nil))
+	})
+}
+
+// globToRegex converts a fileset pattern into an anchored regular expression
+func globToRegex(pattern string) (*regexp.Regexp, error) {
+	var sb strings.Builder
+	sb.WriteString("^")
+	inAlternatives := false
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; c {
+		case '*':
+			if i+1 < len(pattern) && pattern[i+1] == '*' {
+				i++
+				if i+1 < len(pattern) && pattern[i+1] == '/' {
+					i++
+					sb.WriteString("(?:.*/)?")
+				} else {
+					sb.WriteString(".*")
+				}
+			} else {
+				sb.WriteString("[^/]*")
+			}
+		case '?':
+			sb.WriteString("[^/]")
+		case '[':
+			end := strings.IndexByte(pattern[i:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("invalid pattern %s: unclosed character class", pattern)
+			}
+			class := pattern[i+1 : i+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			sb.WriteString("[" + class + "]")
+			i += end
+		case '{':
+			inAlternatives = true
+			sb.WriteString("(?:")
+		case '}':
+			if !inAlternatives {
+				sb.WriteString(regexp.QuoteMeta(string(c)))
+				continue
+			}
+			inAlternatives = false
+			sb.WriteString(")")
+		case ',':
+			if inAlternatives {
+				sb.WriteString("|")
+			} else {
+				sb.WriteString(",")
+			}
+		case '\\':
+			if i+1 < len(pattern) {
+				i++
+				sb.WriteString(regexp.QuoteMeta(string(pattern[i])))
+			}
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	sb.WriteString("$")
+	return regexp.Compile(sb.String())
+}
@@ -0,0 +1,71 @@
+package functions
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+)
+
+// EndsWithFunc - https://developer.hashicorp.com/terraform/language/functions/endswith
+var EndsWithFunc = makeStringPredicateFunction("suffix", strings.HasSuffix)
+
+// StartsWithFunc - https://developer.hashicorp.com/terraform/language/functions/startswith
+var StartsWithFunc = makeStringPredicateFunction("prefix", strings.HasPrefix)
+
+// StrContainsFunc - https://developer.hashicorp.com/terraform/language/functions/strcontains
+var StrContainsFunc = makeStringPredicateFunction("substr", strings.Contains)
+
+// ReplaceFunc - https://developer.hashicorp.com/terraform/language/functions/replace
+// when substr is wrapped in forward slashes it is treated as a regular expression
+var ReplaceFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "str",
+			Type: cty.String,
+		},
+		{
+			Name: "substr",
+			Type: cty.String,
+		},
+		{
+			Name: "replace",
+			Type: cty.String,
+		},
+	},
+	Type: function.StaticReturnType(cty.String),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		str := args[0].AsString()
+		substr := args[1].AsString()
+		replace := args[2].AsString()
+
+		if len(substr) > 1 && substr[0] == '/' && substr[len(substr)-1] == '/' {
+			re, err := regexp.Compile(substr[1 : len(substr)-1])
+			if err != nil {
+				return cty.UnknownVal(cty.String), err
+			}
+			return cty.StringVal(re.ReplaceAllString(str, replace)), nil
+		}
+		return cty.StringVal(strings.ReplaceAll(str, substr, replace)), nil
+	},
+})
+
+func makeStringPredicateFunction(name string, predicate func(s, sub string) bool) function.Function {
+	return function.New(&function.Spec{
+		Params: []function.Parameter{
+			{
+				Name: "str",
+				Type: cty.String,
+			},
+			{
+				Name: name,
+				Type: cty.String,
+			},
+		},
+		Type: function.StaticReturnType(cty.Bool),
+		Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+			return cty.BoolVal(predicate(args[0].AsString(), args[1].AsString())), nil
+		},
+	})
+}
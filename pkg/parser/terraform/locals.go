@@ -0,0 +1,118 @@
+package terraform
+
+import (
+	"path/filepath"
+	"sort"
+
+	"github.com/Checkmarx/kics/v2/pkg/parser/terraform/converter"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/rs/zerolog/log"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// localsResolver evaluates the locals of a module following the dependencies between them
+type localsResolver struct {
+	attributes map[string]*hclsyntax.Attribute
+	variables  converter.VariableMap
+	values     map[string]cty.Value
+	visiting   map[string]bool
+	done       map[string]bool
+}
+
+func getLocalsAttributes(filename string) map[string]*hclsyntax.Attribute {
+	attributes := make(map[string]*hclsyntax.Attribute)
+	parsedFile, err := parseFile(filename, false)
+	if err != nil || parsedFile == nil {
+		return attributes
+	}
+	body, ok := parsedFile.Body.(*hclsyntax.Body)
+	if !ok {
+		return attributes
+	}
+	for _, block := range body.Blocks {
+		if block.Type != "locals" {
+			continue
+		}
+		for name, attr := range block.Body.Attributes {
+			attributes[name] = attr
+		}
+	}
+	return attributes
+}
+
+// getLocals returns the values of the locals declared in the .tf files of currentPath, locals that
+// can not be evaluated are left out so their references are kept as expressions
+func getLocals(currentPath string, variables converter.VariableMap) map[string]cty.Value {
+	tfFiles, err := filepath.Glob(filepath.Join(currentPath, "*.tf"))
+	if err != nil {
+		log.Error().Msg("Error getting .tf files")
+	}
+
+	resolver := &localsResolver{
+		attributes: make(map[string]*hclsyntax.Attribute),
+		variables:  variables,
+		values:     make(map[string]cty.Value),
+		visiting:   make(map[string]bool),
+		done:       make(map[string]bool),
+	}
+	for _, tfFile := range tfFiles {
+		for name, attr := range getLocalsAttributes(tfFile) {
+			resolver.attributes[name] = attr
+		}
+	}
+
+	names := make([]string, 0, len(resolver.attributes))
+	for name := range resolver.attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		resolver.resolve(name)
+	}
+	return resolver.values
+}
+
+func (r *localsResolver) resolve(name string) {
+	if r.done[name] {
+		return
+	}
+	attr, ok := r.attributes[name]
+	if !ok {
+		return
+	}
+	if r.visiting[name] {
+		log.Debug().Msgf("Cycle found resolving local.%s", name)
+		return
+	}
+	r.visiting[name] = true
+
+	// locals referenced by this local have to be evaluated first
+	for _, traversal := range attr.Expr.Variables() {
+		if traversal.RootName() != "local" || len(traversal) < 2 {
+			continue
+		}
+		if step, isAttr := traversal[1].(hcl.TraverseAttr); isAttr {
+			r.resolve(step.Name)
+		}
+	}
+
+	r.visiting[name] = false
+	r.done[name] = true
+
+	ctxVariables := make(converter.VariableMap, len(r.variables)+1)
+	for key, value := range r.variables {
+		ctxVariables[key] = value
+	}
+	ctxVariables["local"] = cty.ObjectVal(r.values)
+
+	value, diags := attr.Expr.Value(&hcl.EvalContext{
+		Variables: ctxVariables,
+		Functions: converter.Functions(r.variables),
+	})
+	if diags.HasErrors() || !value.IsWhollyKnown() {
+		log.Trace().Msgf("Local local.%s value not resolved", name)
+		return
+	}
+	r.values[name] = value
+}
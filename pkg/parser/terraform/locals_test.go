@@ -0,0 +1,27 @@
+package terraform
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/Checkmarx/kics/v2/pkg/parser/terraform/converter"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// TestGetLocals tests that locals are evaluated following their dependencies and cycles are left unresolved
+func TestGetLocals(t *testing.T) {
+	variables := converter.VariableMap{
+		"var": cty.ObjectVal(map[string]cty.Value{
+			"environment": cty.StringVal("dev"),
+		}),
+	}
+
+	locals := getLocals(filepath.FromSlash("../../../test/fixtures/test_terraform_locals"), variables)
+
+	require.Equal(t, cty.StringVal("KICS-dev"), locals["bucket_name"])
+	require.Equal(t, cty.StringVal("aws:kms"), locals["encryption"])
+	require.Equal(t, cty.StringVal("KICS"), locals["prefix"])
+	require.NotContains(t, locals, "cycle_a")
+	require.NotContains(t, locals, "cycle_b")
+}
@@ -0,0 +1,249 @@
+package terraform
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Checkmarx/kics/v2/pkg/model"
+	"github.com/Checkmarx/kics/v2/pkg/parser/terraform/converter"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/rs/zerolog/log"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	// moduleMaxDepth limits how deep nested module calls are followed
+	moduleMaxDepth = 5
+	// ModulesManifestPath is the manifest written by 'terraform init' with the downloaded modules
+	ModulesManifestPath = ".terraform/modules/modules.json"
+)
+
+// moduleMetaArguments are the module block arguments that are not input variables
+var moduleMetaArguments = map[string]struct{}{
+	"source":     {},
+	"version":    {},
+	"count":      {},
+	"for_each":   {},
+	"providers":  {},
+	"depends_on": {},
+}
+
+type modulesManifest struct {
+	Modules []modulesManifestEntry `json:"Modules"`
+}
+
+type modulesManifestEntry struct {
+	Key    string `json:"Key"`
+	Source string `json:"Source"`
+	Dir    string `json:"Dir"`
+}
+
+// moduleScope keeps the information needed to resolve module calls of a given module
+type moduleScope struct {
+	rootDir   string
+	keyPrefix string
+	depth     int
+	manifest  *modulesManifest
+}
+
+func newModuleScope(rootDir string) *moduleScope {
+	return &moduleScope{
+		rootDir:  rootDir,
+		manifest: readModulesManifest(rootDir),
+	}
+}
+
+func readModulesManifest(rootDir string) *modulesManifest {
+	content, err := os.ReadFile(filepath.Join(rootDir, filepath.FromSlash(ModulesManifestPath)))
+	if err != nil {
+		log.Trace().Msgf("modules manifest not found on %s", rootDir)
+		return nil
+	}
+	manifest := &modulesManifest{}
+	if err := json.Unmarshal(content, manifest); err != nil {
+		log.Debug().Msgf("failed to unmarshal modules manifest on %s: %s", rootDir, err)
+		return nil
+	}
+	return manifest
+}
+
+func (s *moduleScope) child(key string) *moduleScope {
+	return &moduleScope{
+		rootDir:   s.rootDir,
+		keyPrefix: key,
+		depth:     s.depth + 1,
+		manifest:  s.manifest,
+	}
+}
+
+func (s *moduleScope) key(name string) string {
+	if s.keyPrefix == "" {
+		return name
+	}
+	return s.keyPrefix + "." + name
+}
+
+// moduleDir returns the directory of a module given its source, local sources are resolved relative to the
+// caller directory and the remaining ones are looked up in the modules manifest
+func (s *moduleScope) moduleDir(key, source, callerDir string) (string, bool) {
+	var dir string
+	if strings.HasPrefix(source, "./") || strings.HasPrefix(source, "../") {
+		dir = filepath.Join(callerDir, filepath.FromSlash(source))
+	} else if s.manifest != nil {
+		for _, entry := range s.manifest.Modules {
+			if entry.Key == key {
+				dir = filepath.Join(s.rootDir, filepath.FromSlash(entry.Dir))
+				break
+			}
+		}
+	}
+	if dir == "" {
+		return "", false
+	}
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		log.Debug().Msgf("module %s directory %s not found", key, dir)
+		return "", false
+	}
+	return dir, true
+}
+
+// getModuleDocuments parses the modules called in body and returns a document for each module file,
+// with the module call information under model.KicsModuleKey
+func (p *Parser) getModuleDocuments(callerPath string, body *hclsyntax.Body,
+	callerVariables converter.VariableMap, scope *moduleScope) []model.Document {
+	documents := make([]model.Document, 0)
+	if scope.depth >= moduleMaxDepth {
+		return documents
+	}
+
+	for _, block := range body.Blocks {
+		if block.Type != "module" || len(block.Labels) != 1 {
+			continue
+		}
+		sourceAttr, ok := block.Body.Attributes["source"]
+		if !ok {
+			continue
+		}
+		sourceValue, diags := sourceAttr.Expr.Value(nil)
+		if diags.HasErrors() || sourceValue.Type() != cty.String || sourceValue.IsNull() {
+			continue
+		}
+		source := sourceValue.AsString()
+		key := scope.key(block.Labels[0])
+
+		dir, ok := scope.moduleDir(key, source, filepath.Dir(callerPath))
+		if !ok {
+			continue
+		}
+
+		moduleVariables := converter.VariableMap{
+			"var":  cty.ObjectVal(getModuleInputVariables(dir, block.Body, callerVariables)),
+			"path": getPathVariable(dir, scope.rootDir),
+		}
+		moduleVariables["local"] = cty.ObjectVal(getLocals(dir, moduleVariables))
+
+		tfFiles, err := filepath.Glob(filepath.Join(dir, "*.tf"))
+		if err != nil {
+			log.Error().Msgf("Error getting .tf files of module %s", key)
+			continue
+		}
+		sort.Strings(tfFiles)
+
+		for _, tfFile := range tfFiles {
+			documents = append(documents, p.getModuleFileDocuments(tfFile, source, key, callerPath,
+				block.TypeRange.Start.Line, moduleVariables, scope.child(key))...)
+		}
+	}
+
+	return documents
+}
+
+func (p *Parser) getModuleFileDocuments(tfFile, source, key, callerPath string, callerLine int,
+	moduleVariables converter.VariableMap, scope *moduleScope) []model.Document {
+	content, err := os.ReadFile(tfFile)
+	if err != nil {
+		log.Error().Msgf("Error reading module file %s", tfFile)
+		return nil
+	}
+	file, diagnostics := hclsyntax.ParseConfig(content, filepath.Base(tfFile), hcl.Pos{Byte: 0, Line: 1, Column: 1})
+	if diagnostics != nil && diagnostics.HasErrors() {
+		log.Debug().Msgf("Failed to parse module file %s: %s", tfFile, diagnostics.Error())
+		return nil
+	}
+
+	document, err := p.convertFunc(file, moduleVariables)
+	if err != nil || document == nil {
+		log.Debug().Msgf("Failed to convert module file %s", tfFile)
+		return nil
+	}
+	document[model.KicsModuleKey] = map[string]interface{}{
+		"name":      key,
+		"source":    source,
+		"path":      tfFile,
+		"file_name": callerPath,
+		"line":      callerLine,
+	}
+
+	documents := []model.Document{document}
+	return append(documents, p.getModuleDocuments(tfFile, file.Body.(*hclsyntax.Body), moduleVariables, scope)...)
+}
+
+// getModuleInputVariables returns the module variables default values overridden by the values given in the module call
+func getModuleInputVariables(dir string, call *hclsyntax.Body, callerVariables converter.VariableMap) converter.VariableMap {
+	variables := make(converter.VariableMap)
+	tfFiles, err := filepath.Glob(filepath.Join(dir, "*.tf"))
+	if err != nil {
+		log.Error().Msg("Error getting .tf files")
+	}
+	for _, tfFile := range tfFiles {
+		defaultValues, errDefaultValues := setInputVariablesDefaultValues(tfFile)
+		if errDefaultValues != nil {
+			log.Error().Msgf("Error getting default values from %s", tfFile)
+			continue
+		}
+		mergeMaps(variables, defaultValues)
+	}
+
+	ctx := &hcl.EvalContext{
+		Variables: callerVariables,
+		Functions: converter.Functions(callerVariables),
+	}
+	for name, attr := range call.Attributes {
+		if _, isMeta := moduleMetaArguments[name]; isMeta {
+			continue
+		}
+		value, diags := attr.Expr.Value(ctx)
+		if diags.HasErrors() || !value.IsWhollyKnown() {
+			log.Trace().Msgf("Module argument %s value not resolved", name)
+			continue
+		}
+		variables[name] = value
+	}
+	return variables
+}
+
+// GetModuleCall returns the module call and the module file path of a document generated from a module
+func GetModuleCall(document model.Document) (*model.ModuleCall, string, bool) {
+	module, ok := document[model.KicsModuleKey].(map[string]interface{})
+	if !ok {
+		return nil, "", false
+	}
+	path, _ := module["path"].(string)
+	name, _ := module["name"].(string)
+	source, _ := module["source"].(string)
+	fileName, _ := module["file_name"].(string)
+	line, _ := module["line"].(int)
+	if path == "" {
+		return nil, "", false
+	}
+	return &model.ModuleCall{
+		Name:     name,
+		Source:   source,
+		FileName: fileName,
+		Line:     line,
+	}, path, true
+}
@@ -0,0 +1,53 @@
+package terraform
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Checkmarx/kics/v2/pkg/model"
+	"github.com/Checkmarx/kics/v2/pkg/parser/terraform/converter"
+	"github.com/stretchr/testify/require"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+// TestParser_ModuleCalls tests that local and manifest resolved module calls generate the module documents
+func TestParser_ModuleCalls(t *testing.T) {
+	path := filepath.FromSlash("../../../test/fixtures/test_terraform_modules/main.tf")
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	parser := NewDefault()
+	_, err = parser.Resolve(content, path, false, 15)
+	require.NoError(t, err)
+	documents, _, err := parser.Parse(path, content)
+	require.NoError(t, err)
+	require.Len(t, documents, 3)
+
+	bucketCall, bucketPath, ok := GetModuleCall(documents[1])
+	require.True(t, ok)
+	require.Equal(t, &model.ModuleCall{
+		Name:     "bucket",
+		Source:   "./modules/bucket",
+		FileName: path,
+		Line:     5,
+	}, bucketCall)
+	require.Equal(t, filepath.Join(filepath.Dir(path), "modules", "bucket", "main.tf"), bucketPath)
+
+	bucket := documents[1]["resource"].(model.Document)["aws_s3_bucket"].(model.Document)["this"].(model.Document)
+	require.Equal(t, "kics-module-bucket", bucket["bucket"].(ctyjson.SimpleJSONValue).Value.AsString())
+	require.Equal(t, "public-read", bucket["acl"].(ctyjson.SimpleJSONValue).Value.AsString())
+
+	logsCall, logsPath, ok := GetModuleCall(documents[2])
+	require.True(t, ok)
+	require.Equal(t, "logs", logsCall.Name)
+	require.Equal(t, 11, logsCall.Line)
+	require.Equal(t, filepath.Join(filepath.Dir(path), ".terraform", "modules", "logs", "main.tf"), logsPath)
+
+	_, _, ok = GetModuleCall(documents[0])
+	require.False(t, ok)
+
+	t.Cleanup(func() {
+		inputVariableMap = make(converter.VariableMap)
+	})
+}
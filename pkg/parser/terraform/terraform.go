@@ -8,6 +8,7 @@ import (
 	"github.com/Checkmarx/kics/v2/pkg/model"
 	"github.com/Checkmarx/kics/v2/pkg/parser/terraform/comment"
 	"github.com/Checkmarx/kics/v2/pkg/parser/terraform/converter"
+	"github.com/Checkmarx/kics/v2/pkg/parser/terraform/functions"
 	"github.com/Checkmarx/kics/v2/pkg/parser/utils"
 	masterUtils "github.com/Checkmarx/kics/v2/pkg/utils"
 	"github.com/hashicorp/hcl/v2"
@@ -45,6 +46,16 @@ func NewDefaultWithVarsPath(terraformVarsPath string) *Parser {
 	return parser
 }
 
+// NewDefaultWithParams initializes a parser with the default values using a variables path and, if
+// expandInstances is set, expanding count and for_each resources into indexed instances
+func NewDefaultWithParams(terraformVarsPath string, expandInstances bool) *Parser {
+	parser := NewDefaultWithVarsPath(terraformVarsPath)
+	if expandInstances {
+		parser.convertFunc = converter.ExpandedConverted
+	}
+	return parser
+}
+
 // Resolve - replace or modifies in-memory content before parsing
 func (p *Parser) Resolve(fileContent []byte, filename string, _ bool, _ int) ([]byte, error) {
 	// handle panic during resolve process
@@ -54,6 +65,7 @@ func (p *Parser) Resolve(fileContent []byte, filename string, _ bool, _ int) ([]
 			masterUtils.HandlePanic(r, errMessage)
 		}
 	}()
+	functions.ResetReadPaths()
 	getInputVariables(filepath.Dir(filename), string(fileContent), p.terraformVarsPath)
 	getDataSourcePolicy(filepath.Dir(filename))
 	return fileContent, nil
@@ -69,6 +81,18 @@ func processContent(elements model.Document, content, path string) {
 	}
 }
 
+// processCertificate adds the certificate information of 'certificate_body', which either refers
+// a certificate file or holds the certificate content loaded with the file function
+func processCertificate(elements model.Document, value, path string) {
+	if utils.IsCertificateContent(value) {
+		if certInfo := utils.AddCertificateInfoFromContent(value); certInfo != nil {
+			elements["certificate_body"] = certInfo
+		}
+		return
+	}
+	processContent(elements, utils.CheckCertificate(value), path)
+}
+
 func processElements(elements model.Document, path string) {
 	for k, v3 := range elements { // resource elements
 		if k != "certificate_body" {
@@ -76,11 +100,9 @@ func processElements(elements model.Document, path string) {
 		}
 		switch value := v3.(type) {
 		case string:
-			content := utils.CheckCertificate(value)
-			processContent(elements, content, path)
+			processCertificate(elements, value, path)
 		case ctyjson.SimpleJSONValue:
-			content := utils.CheckCertificate(value.Value.AsString())
-			processContent(elements, content, path)
+			processCertificate(elements, value.Value.AsString(), path)
 		}
 	}
 }
@@ -179,15 +201,15 @@ func (p *Parser) Parse(path string, content []byte) ([]model.Document, []int, er
 		return nil, []int{}, err
 	}
 
-	ignore, err := comment.ParseComments(content, path)
-	if err != nil {
-		log.Err(err).Msg("failed to parse comments")
-	}
-
-	linesToIgnore := comment.GetIgnoreLines(ignore, file.Body.(*hclsyntax.Body))
+	linesToIgnore := ignoreLines(path, content, file.Body.(*hclsyntax.Body))
 
 	fc, parseErr := p.convertFunc(file, inputVariableMap)
-	json, err := addExtraInfo([]model.Document{fc}, path)
+	documents := []model.Document{fc}
+	if parseErr == nil {
+		documents = append(documents,
+			p.getModuleDocuments(path, file.Body.(*hclsyntax.Body), inputVariableMap, newModuleScope(filepath.Dir(path)))...)
+	}
+	json, err := addExtraInfo(documents, path)
 	if err != nil {
 		return json, []int{}, errors.Wrap(err, "failed terraform parse")
 	}
@@ -195,6 +217,24 @@ func (p *Parser) Parse(path string, content []byte) ([]model.Document, []int, er
 	return json, linesToIgnore, errors.Wrap(parseErr, "failed terraform parse")
 }
 
+// GetIgnoreLines returns the lines of the Terraform file ignored by its kics-scan comments, the same way as Parse
+func GetIgnoreLines(path string, content []byte) []int {
+	file, diagnostics := hclsyntax.ParseConfig(content, filepath.Base(path), hcl.Pos{Byte: 0, Line: 1, Column: 1})
+	if diagnostics != nil && diagnostics.HasErrors() {
+		return []int{}
+	}
+	return ignoreLines(path, content, file.Body.(*hclsyntax.Body))
+}
+
+func ignoreLines(path string, content []byte, body *hclsyntax.Body) []int {
+	ignore, err := comment.ParseComments(content, path)
+	if err != nil {
+		log.Err(err).Msg("failed to parse comments")
+	}
+
+	return comment.GetIgnoreLines(ignore, body)
+}
+
 // SupportedExtensions returns Terraform extensions
 func (p *Parser) SupportedExtensions() []string {
 	return []string{".tf", ".tfvars"}
@@ -224,3 +264,8 @@ func (p *Parser) StringifyContent(content []byte) (string, error) {
 func (p *Parser) GetResolvedFiles() map[string]model.ResolvedFile {
 	return make(map[string]model.ResolvedFile)
 }
+
+// GetReadFiles returns the files read by the filesystem functions while resolving and parsing the last file
+func (p *Parser) GetReadFiles() []string {
+	return functions.ReadPaths()
+}
@@ -1,6 +1,7 @@
 package terraform
 
 import (
+	"os"
 	"path/filepath"
 	"reflect"
 	"strings"
@@ -409,3 +410,31 @@ func TestParser_GetResolvedFiles(t *testing.T) {
 		})
 	}
 }
+
+func TestParser_GetReadFiles(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "policies"), os.ModePerm))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "policies", "bucket.json"), []byte(`{"Version": "2012-10-17"}`),
+		os.ModePerm))
+	content := []byte(`resource "aws_s3_bucket_policy" "b" {
+  policy = file("${path.module}/policies/bucket.json")
+}
+`)
+	path := filepath.Join(dir, "main.tf")
+	require.NoError(t, os.WriteFile(path, content, os.ModePerm))
+
+	p := NewDefault()
+	resolved, err := p.Resolve(content, path, false, 15)
+	require.NoError(t, err)
+	_, _, err = p.Parse(path, resolved)
+	require.NoError(t, err)
+	require.Equal(t, []string{filepath.Join(dir, "policies", "bucket.json")}, p.GetReadFiles())
+
+	other := []byte(`resource "aws_s3_bucket" "b" {}
+`)
+	resolved, err = p.Resolve(other, path, false, 15)
+	require.NoError(t, err)
+	_, _, err = p.Parse(path, resolved)
+	require.NoError(t, err)
+	require.Empty(t, p.GetReadFiles(), "the reads of the previous file should be forgotten")
+}
@@ -157,4 +157,15 @@ func getInputVariables(currentPath, fileContent, terraformVarsPath string) {
 	}
 
 	inputVariableMap["var"] = cty.ObjectVal(variablesMap)
+	inputVariableMap["path"] = getPathVariable(currentPath, currentPath)
+	inputVariableMap["local"] = cty.ObjectVal(getLocals(currentPath, inputVariableMap))
+}
+
+// getPathVariable returns the path object (path.module, path.root and path.cwd) of the module on moduleDir
+func getPathVariable(moduleDir, rootDir string) cty.Value {
+	return cty.ObjectVal(map[string]cty.Value{
+		"module": cty.StringVal(filepath.ToSlash(moduleDir)),
+		"root":   cty.StringVal(filepath.ToSlash(rootDir)),
+		"cwd":    cty.StringVal(filepath.ToSlash(rootDir)),
+	})
 }
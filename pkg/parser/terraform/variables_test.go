@@ -178,6 +178,8 @@ func TestGetInputVariables(t *testing.T) {
 					"default_var_file":  cty.StringVal("default_var_file"),
 					"local_default_var": cty.StringVal("local_default"),
 				}),
+				"local": cty.EmptyObjectVal,
+				"path":  getPathVariable("../../../test/fixtures/test_terraform_variables", "../../../test/fixtures/test_terraform_variables"),
 			},
 			wantErr: false,
 		},
@@ -190,6 +192,9 @@ func TestGetInputVariables(t *testing.T) {
 						"map3Key1": cty.StringVal("givenByVar"),
 					}),
 				}),
+				"local": cty.EmptyObjectVal,
+				"path": getPathVariable("../../../test/fixtures/test_terraform_variables/test_variables_comment_path.tf",
+					"../../../test/fixtures/test_terraform_variables/test_variables_comment_path.tf"),
 			},
 			wantErr: false,
 		},
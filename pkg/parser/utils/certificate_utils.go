@@ -8,6 +8,7 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"strings"
 
 	"github.com/rs/zerolog/log"
 )
@@ -26,6 +27,12 @@ func CheckCertificate(content string) string {
 	return match
 }
 
+// IsCertificateContent verifies if the attribute 'certificate_body' holds the certificate PEM content
+// (e.g. loaded with the terraform file function)
+func IsCertificateContent(content string) bool {
+	return strings.Contains(content, "-----BEGIN CERTIFICATE-----")
+}
+
 func getCertificateInfo(filePath string) (certInfo, error) {
 	certPEM, err := os.ReadFile(filePath)
 
@@ -33,6 +40,10 @@ func getCertificateInfo(filePath string) (certInfo, error) {
 		return certInfo{}, err
 	}
 
+	return parseCertificateInfo(certPEM)
+}
+
+func parseCertificateInfo(certPEM []byte) (certInfo, error) {
 	block, _ := pem.Decode(certPEM)
 	if block == nil {
 		return certInfo{}, errors.New("failed to parse the certificate PEM")
@@ -76,13 +87,8 @@ func AddCertificateInfo(path, content string) map[string]interface{} {
 	date, err := getCertificateInfo(filePath)
 
 	if err == nil {
-		attributes := make(map[string]interface{})
+		attributes := getCertificateAttributes(date)
 		attributes["file"] = filePath
-		attributes["expiration_date"] = date.date
-
-		if date.rsaKeyBytes != -1 {
-			attributes["rsa_key_bytes"] = date.rsaKeyBytes
-		}
 
 		return attributes
 	}
@@ -91,3 +97,24 @@ func AddCertificateInfo(path, content string) map[string]interface{} {
 
 	return nil
 }
+
+// AddCertificateInfoFromContent gets certificate information of a certificate PEM content
+func AddCertificateInfoFromContent(content string) map[string]interface{} {
+	date, err := parseCertificateInfo([]byte(content))
+	if err != nil {
+		log.Error().Msgf("Failed to parse certificate content: %s", err)
+		return nil
+	}
+	return getCertificateAttributes(date)
+}
+
+func getCertificateAttributes(date certInfo) map[string]interface{} {
+	attributes := make(map[string]interface{})
+	attributes["expiration_date"] = date.date
+
+	if date.rsaKeyBytes != -1 {
+		attributes["rsa_key_bytes"] = date.rsaKeyBytes
+	}
+
+	return attributes
+}
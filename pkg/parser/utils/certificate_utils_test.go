@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"os"
 	"path/filepath"
 	"testing"
 
@@ -33,3 +34,14 @@ func TestCheckCertificateBody(t *testing.T) {
 
 	require.NotEmpty(t, pem)
 }
+
+func TestAddCertificateInfoFromContent(t *testing.T) {
+	content, err := os.ReadFile(filepath.Join("..", "..", "..", "test", "fixtures", "test_certificate", "certificate.pem"))
+	require.NoError(t, err)
+	require.True(t, IsCertificateContent(string(content)))
+
+	info := AddCertificateInfoFromContent(string(content))
+
+	require.NotEmpty(t, info)
+	require.Contains(t, info, "expiration_date")
+}
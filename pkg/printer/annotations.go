@@ -0,0 +1,200 @@
+package printer
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Checkmarx/kics/v2/pkg/model"
+	"github.com/rs/zerolog/log"
+)
+
+// CI annotation formats
+const (
+	AnnotationsAuto     = "auto"
+	AnnotationsAzure    = "azure"
+	AnnotationsGitHub   = "github"
+	AnnotationsTeamCity = "teamcity"
+)
+
+const (
+	annotationError   = "error"
+	annotationWarning = "warning"
+	annotationNotice  = "notice"
+)
+
+var (
+	// annotationsOutput is the standard output, annotations are still printed when --ci discards the other messages
+	annotationsOutput io.Writer = os.Stdout
+
+	// githubDataEscaper escapes the message of a GitHub workflow command
+	githubDataEscaper = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A")
+	// githubPropertyEscaper escapes the properties of a GitHub workflow command
+	githubPropertyEscaper = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C")
+	// azureEscaper escapes the properties and message of an Azure Pipelines logging command
+	azureEscaper = strings.NewReplacer("%", "%AZP25", ";", "%3B", "\r", "%0D", "\n", "%0A", "]", "%5D")
+	// teamCityEscaper escapes the attribute values of a TeamCity service message
+	teamCityEscaper = strings.NewReplacer("|", "||", "'", "|'", "\n", "|n", "\r", "|r", "[", "|[", "]", "|]")
+
+	teamCitySeverities = map[string]string{
+		annotationError:   "ERROR",
+		annotationWarning: "WARNING",
+		annotationNotice:  "INFO",
+	}
+)
+
+// annotation is a result as shown inline by the CI
+type annotation struct {
+	level   string
+	queryID string
+	title   string
+	file    string
+	line    int
+	message string
+}
+
+// DetectCI returns the annotations format of the CI running KICS, based on the variables each CI sets in the
+// environment, or an empty string when the CI is not supported
+func DetectCI() string {
+	switch {
+	case os.Getenv("GITHUB_ACTIONS") == "true":
+		return AnnotationsGitHub
+	case strings.EqualFold(os.Getenv("TF_BUILD"), "true"):
+		return AnnotationsAzure
+	case os.Getenv("TEAMCITY_VERSION") != "":
+		return AnnotationsTeamCity
+	default:
+		return ""
+	}
+}
+
+// PrintAnnotations prints the results of the summary as annotations of the given CI, so they are shown inline on
+// pull request diffs. The auto format detects the CI from the environment
+func PrintAnnotations(summary *model.Summary, format string) error {
+	return writeAnnotations(annotationsOutput, summary, format)
+}
+
+func writeAnnotations(w io.Writer, summary *model.Summary, format string) error {
+	format = strings.ToLower(format)
+	if format == AnnotationsAuto {
+		format = DetectCI()
+		if format == "" {
+			log.Info().Msg("No supported CI detected, skipping CI annotations")
+			return nil
+		}
+	}
+
+	var write func(io.Writer, *annotation) error
+	switch format {
+	case AnnotationsGitHub:
+		write = writeGitHubAnnotation
+	case AnnotationsAzure:
+		write = writeAzureAnnotation
+	case AnnotationsTeamCity:
+		if err := writeTeamCityInspectionTypes(w, summary); err != nil {
+			return err
+		}
+		write = writeTeamCityAnnotation
+	default:
+		return fmt.Errorf("unknown CI annotations format: %s", format)
+	}
+
+	for idx := range summary.Queries {
+		query := &summary.Queries[idx]
+		for fileIdx := range query.Files {
+			if err := write(w, newAnnotation(query, &query.Files[fileIdx])); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func newAnnotation(query *model.QueryResult, file *model.VulnerableFile) *annotation {
+	message := file.KeyActualValue
+	if file.KeyExpectedValue != "" {
+		message += fmt.Sprintf("\nExpected: %s", file.KeyExpectedValue)
+	}
+	if file.Remediation != "" {
+		message += fmt.Sprintf("\nRemediation (%s): %s", file.RemediationType, file.Remediation)
+	}
+	if query.QueryURI != "" {
+		message += fmt.Sprintf("\n%s", query.QueryURI)
+	}
+
+	return &annotation{
+		level:   annotationLevel(query.Severity),
+		queryID: query.QueryID,
+		title:   fmt.Sprintf("KICS [%s] %s", query.Severity, query.QueryName),
+		file:    strings.TrimPrefix(filepath.ToSlash(file.FileName), "./"),
+		line:    file.Line,
+		message: strings.TrimSpace(message),
+	}
+}
+
+// annotationLevel maps critical and high results to errors, medium results to warnings and the others to notices
+func annotationLevel(severity model.Severity) string {
+	switch severity {
+	case model.SeverityCritical, model.SeverityHigh:
+		return annotationError
+	case model.SeverityMedium:
+		return annotationWarning
+	default:
+		return annotationNotice
+	}
+}
+
+func writeGitHubAnnotation(w io.Writer, a *annotation) error {
+	_, err := fmt.Fprintf(w, "::%s file=%s,line=%d,title=%s::%s\n",
+		a.level,
+		githubPropertyEscaper.Replace(a.file),
+		a.line,
+		githubPropertyEscaper.Replace(a.title),
+		githubDataEscaper.Replace(a.message))
+	return err
+}
+
+func writeAzureAnnotation(w io.Writer, a *annotation) error {
+	// Azure Pipelines only supports errors and warnings
+	level := a.level
+	if level == annotationNotice {
+		level = annotationWarning
+	}
+	_, err := fmt.Fprintf(w, "##vso[task.logissue type=%s;sourcepath=%s;linenumber=%d;]%s\n",
+		level,
+		azureEscaper.Replace(a.file),
+		a.line,
+		azureEscaper.Replace(a.title+": "+a.message))
+	return err
+}
+
+// writeTeamCityInspectionTypes declares the queries with results, TeamCity requires the inspection types to be
+// reported before their inspections
+func writeTeamCityInspectionTypes(w io.Writer, summary *model.Summary) error {
+	for idx := range summary.Queries {
+		query := &summary.Queries[idx]
+		if len(query.Files) == 0 {
+			continue
+		}
+		if _, err := fmt.Fprintf(w, "##teamcity[inspectionType id='%s' name='%s' category='%s' description='%s']\n",
+			teamCityEscaper.Replace(query.QueryID),
+			teamCityEscaper.Replace(query.QueryName),
+			teamCityEscaper.Replace(query.Category),
+			teamCityEscaper.Replace(query.Description)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeTeamCityAnnotation(w io.Writer, a *annotation) error {
+	_, err := fmt.Fprintf(w, "##teamcity[inspection typeId='%s' message='%s' file='%s' line='%d' SEVERITY='%s']\n",
+		teamCityEscaper.Replace(a.queryID),
+		teamCityEscaper.Replace(a.title+": "+a.message),
+		teamCityEscaper.Replace(a.file),
+		a.line,
+		teamCitySeverities[a.level])
+	return err
+}
@@ -0,0 +1,155 @@
+package printer
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/Checkmarx/kics/v2/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+var annotationsSummary = &model.Summary{
+	Queries: []model.QueryResult{
+		{
+			QueryName:   "S3 Bucket ACL Allows Read, Write",
+			QueryID:     "38c5ee0d-7f22-4260-ab72-5073048df100",
+			QueryURI:    "https://docs.kics.io/s3",
+			Severity:    model.SeverityHigh,
+			Category:    "Access Control",
+			Description: "S3 Buckets should not be public [read]",
+			Files: []model.VulnerableFile{
+				{
+					FileName:         "./tf/main.tf",
+					Line:             3,
+					KeyActualValue:   "'acl' is equal 'public-read'",
+					KeyExpectedValue: "'acl' should equal 'private'",
+					Remediation:      `{"after":"private","before":"public-read"}`,
+					RemediationType:  "replacement",
+				},
+			},
+		},
+		{
+			QueryName: "S3 Bucket Without Logging",
+			QueryID:   "f861041c-8c9f-4156-acfc-5e6e524f5884",
+			Severity:  model.SeverityLow,
+			Category:  "Observability",
+			Files: []model.VulnerableFile{
+				{
+					FileName:       "tf/main.tf",
+					Line:           1,
+					KeyActualValue: "'logging' is undefined; 50% done",
+				},
+			},
+		},
+	},
+}
+
+func TestPrinter_writeAnnotations(t *testing.T) {
+	tests := []struct {
+		name   string
+		format string
+		env    map[string]string
+		want   string
+	}{
+		{
+			name:   "github",
+			format: AnnotationsGitHub,
+			want: "::error file=tf/main.tf,line=3,title=KICS [HIGH] S3 Bucket ACL Allows Read%2C Write::" +
+				"'acl' is equal 'public-read'%0AExpected: 'acl' should equal 'private'%0A" +
+				`Remediation (replacement): {"after":"private","before":"public-read"}%0Ahttps://docs.kics.io/s3` + "\n" +
+				"::notice file=tf/main.tf,line=1,title=KICS [LOW] S3 Bucket Without Logging::'logging' is undefined; 50%25 done\n",
+		},
+		{
+			name:   "azure",
+			format: AnnotationsAzure,
+			want: "##vso[task.logissue type=error;sourcepath=tf/main.tf;linenumber=3;]KICS [HIGH%5D S3 Bucket ACL Allows Read, Write: " +
+				"'acl' is equal 'public-read'%0AExpected: 'acl' should equal 'private'%0A" +
+				`Remediation (replacement): {"after":"private","before":"public-read"}%0Ahttps://docs.kics.io/s3` + "\n" +
+				"##vso[task.logissue type=warning;sourcepath=tf/main.tf;linenumber=1;]KICS [LOW%5D S3 Bucket Without Logging: " +
+				"'logging' is undefined%3B 50%AZP25 done\n",
+		},
+		{
+			name:   "teamcity",
+			format: AnnotationsTeamCity,
+			want: "##teamcity[inspectionType id='38c5ee0d-7f22-4260-ab72-5073048df100' name='S3 Bucket ACL Allows Read, Write' " +
+				"category='Access Control' description='S3 Buckets should not be public |[read|]']\n" +
+				"##teamcity[inspectionType id='f861041c-8c9f-4156-acfc-5e6e524f5884' name='S3 Bucket Without Logging' " +
+				"category='Observability' description='']\n" +
+				"##teamcity[inspection typeId='38c5ee0d-7f22-4260-ab72-5073048df100' message='KICS |[HIGH|] S3 Bucket ACL Allows Read, Write: " +
+				"|'acl|' is equal |'public-read|'|nExpected: |'acl|' should equal |'private|'|n" +
+				`Remediation (replacement): {"after":"private","before":"public-read"}|nhttps://docs.kics.io/s3' ` +
+				"file='tf/main.tf' line='3' SEVERITY='ERROR']\n" +
+				"##teamcity[inspection typeId='f861041c-8c9f-4156-acfc-5e6e524f5884' message='KICS |[LOW|] S3 Bucket Without Logging: " +
+				"|'logging|' is undefined; 50% done' file='tf/main.tf' line='1' SEVERITY='INFO']\n",
+		},
+		{
+			name:   "auto detects github",
+			format: AnnotationsAuto,
+			env:    map[string]string{"GITHUB_ACTIONS": "true", "TF_BUILD": "", "TEAMCITY_VERSION": ""},
+			want: "::error file=tf/main.tf,line=3,title=KICS [HIGH] S3 Bucket ACL Allows Read%2C Write::" +
+				"'acl' is equal 'public-read'%0AExpected: 'acl' should equal 'private'%0A" +
+				`Remediation (replacement): {"after":"private","before":"public-read"}%0Ahttps://docs.kics.io/s3` + "\n" +
+				"::notice file=tf/main.tf,line=1,title=KICS [LOW] S3 Bucket Without Logging::'logging' is undefined; 50%25 done\n",
+		},
+		{
+			name:   "auto without a supported CI",
+			format: AnnotationsAuto,
+			env:    map[string]string{"GITHUB_ACTIONS": "", "TF_BUILD": "", "TEAMCITY_VERSION": ""},
+			want:   "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+			var out bytes.Buffer
+			require.NoError(t, writeAnnotations(&out, annotationsSummary, tt.format))
+			require.Equal(t, tt.want, out.String())
+		})
+	}
+}
+
+func TestPrinter_writeAnnotations_UnknownFormat(t *testing.T) {
+	var out bytes.Buffer
+	require.Error(t, writeAnnotations(&out, annotationsSummary, "jenkins"))
+}
+
+func TestPrinter_DetectCI(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		want string
+	}{
+		{
+			name: "github actions",
+			env:  map[string]string{"GITHUB_ACTIONS": "true", "TF_BUILD": "", "TEAMCITY_VERSION": ""},
+			want: AnnotationsGitHub,
+		},
+		{
+			name: "azure pipelines",
+			env:  map[string]string{"GITHUB_ACTIONS": "", "TF_BUILD": "True", "TEAMCITY_VERSION": ""},
+			want: AnnotationsAzure,
+		},
+		{
+			name: "teamcity",
+			env:  map[string]string{"GITHUB_ACTIONS": "", "TF_BUILD": "", "TEAMCITY_VERSION": "2023.11"},
+			want: AnnotationsTeamCity,
+		},
+		{
+			name: "unknown",
+			env:  map[string]string{"GITHUB_ACTIONS": "", "TF_BUILD": "", "TEAMCITY_VERSION": ""},
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+			require.Equal(t, tt.want, DetectCI())
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package printer
+
+import (
+	"fmt"
+
+	"github.com/Checkmarx/kics/v2/pkg/diff"
+)
+
+// PrintDiff prints the comparison of two reports
+func PrintDiff(r *diff.Report, printer *Printer) {
+	fmt.Printf("\n\nComparing %s with %s\n", r.Head, r.Base)
+	printDiffFindings("Added Results", r.Added, printer)
+	printDiffFindings("Removed Results", r.Removed, printer)
+
+	fmt.Printf("\nDiff Summary:\n")
+	for _, severity := range diff.Severities {
+		counter := r.SeverityCounters[severity]
+		fmt.Printf("%s: %d (%s)\n", printer.PrintBySev(string(severity), string(severity)), counter.Head, diff.FormatDelta(counter.Delta))
+	}
+	fmt.Printf("TOTAL: %d (%s)\n\n", r.Total.Head, diff.FormatDelta(r.Total.Delta))
+	fmt.Printf("Added: %d\nRemoved: %d\nUnchanged: %d\n\n", len(r.Added), len(r.Removed), len(r.Unchanged))
+}
+
+func printDiffFindings(title string, findings []diff.Finding, printer *Printer) {
+	fmt.Printf("\n%s: %d\n", printer.Bold(title), len(findings))
+	for i := range findings {
+		severity := string(findings[i].Severity)
+		fmt.Printf("\t%s %s, Severity: %s, %s:%s\n",
+			printer.PrintBySev(fmt.Sprintf("[%d]:", i+1), severity),
+			printer.PrintBySev(findings[i].QueryName, severity),
+			printer.PrintBySev(severity, severity),
+			findings[i].FileName,
+			printer.Success.Sprint(findings[i].Line))
+	}
+}
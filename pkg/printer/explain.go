@@ -0,0 +1,39 @@
+package printer
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/Checkmarx/kics/v2/pkg/engine"
+	"github.com/Checkmarx/kics/v2/pkg/model"
+)
+
+// PrintExplanations prints the input each evaluation of the query saw, followed by the trace of the evaluation
+func PrintExplanations(query *model.QueryMetadata, explanations []engine.Explanation, printer *Printer) error {
+	fmt.Printf("\n%s %s (%s)\n", printer.Bold("Explaining query"), query.Metadata["queryName"], query.Metadata["id"])
+	if len(explanations) == 0 {
+		fmt.Printf("No document of the platform %s was scanned, the query was not evaluated\n\n", query.Platform)
+		return nil
+	}
+
+	for i := range explanations {
+		explanation := &explanations[i]
+
+		input, err := json.MarshalIndent(explanation.Input, "", "  ")
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("\n%s %s\n%s\n", printer.Bold("Input of"), strings.Join(explanation.Files, ", "), input)
+		fmt.Printf("\n%s\n%s", printer.Bold("Trace:"), explanation.Trace)
+
+		results := printer.Success.Sprintf("Results: %d", explanation.Results)
+		if explanation.Results == 0 {
+			results = printer.Critical.Sprintf("Results: %d", explanation.Results)
+		}
+		fmt.Printf("\n%s\n", results)
+	}
+	fmt.Println()
+	return nil
+}
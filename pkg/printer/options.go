@@ -40,6 +40,7 @@ func Silent(opt interface{}) error {
 	if silent {
 		color.SetOutput(io.Discard)
 		os.Stdout = nil
+		annotationsOutput = io.Discard
 		log.Logger = log.Output(zerolog.MultiLevelWriter(io.Discard, outFileLogger.(io.Writer)))
 	}
 	return nil
@@ -163,6 +163,30 @@ func PrintResult(summary *model.Summary, printer *Printer, usingCustomQueries bo
 	printSeverityCounter(model.SeverityInfo, summary.SeveritySummary.SeverityCounters[model.SeverityInfo], printer.Info)
 	fmt.Printf("TOTAL: %d\n\n", summary.SeveritySummary.TotalCounter)
 
+	if summary.Baseline != nil {
+		fmt.Printf("Baseline Summary (%s):\n", summary.Baseline.Path)
+		fmt.Printf("NEW: %d\n", summary.Baseline.NewCounter)
+		fmt.Printf("EXISTING: %d\n", summary.Baseline.ExistingCounter)
+		fmt.Printf("FIXED: %d\n\n", summary.Baseline.FixedCounter)
+	}
+
+	if summary.OutsideDiff != nil {
+		fmt.Printf("Results outside of the diff: %d\n\n", summary.ResultsOutsideDiff)
+	}
+
+	if summary.ResultsSuppressed > 0 {
+		fmt.Printf("Results suppressed: %d\n\n", summary.ResultsSuppressed)
+	}
+
+	if summary.ChangedSince != nil {
+		fmt.Printf("Changed Since %s (%s): %d changed files, %d scanned paths\n\n", summary.ChangedSince.Ref,
+			summary.ChangedSince.Base, len(summary.ChangedSince.ChangedFiles), len(summary.ChangedSince.ScannedPaths))
+	}
+
+	if summary.QueryProfile != nil {
+		printQueryProfile(summary.QueryProfile, printer)
+	}
+
 	log.Info().Msgf("Scanned Files: %d", summary.ScannedFiles)
 	log.Info().Msgf("Parsed Files: %d", summary.ParsedFiles)
 	log.Info().Msgf("Scanned Lines: %d", summary.ScannedFilesLines)
@@ -170,6 +194,11 @@ func PrintResult(summary *model.Summary, printer *Printer, usingCustomQueries bo
 	log.Info().Msgf("Ignored Lines: %d", summary.IgnoredFilesLines)
 	log.Info().Msgf("Queries loaded: %d", summary.TotalQueries)
 	log.Info().Msgf("Queries failed to execute: %d", summary.FailedToExecuteQueries)
+	if summary.CacheFileHits+summary.CacheFileMisses > 0 {
+		log.Info().Msgf("Files from cache: %d of %d", summary.CacheFileHits, summary.CacheFileHits+summary.CacheFileMisses)
+		log.Info().Msgf("Query results from cache: %d of %d", summary.CacheResultHits,
+			summary.CacheResultHits+summary.CacheResultMisses)
+	}
 	log.Info().Msg("Inspector stopped")
 
 	return nil
@@ -181,8 +210,12 @@ func printSeverityCounter(severity string, counter int, printColor color.RGBColo
 
 func printFiles(query *model.QueryResult, printer *Printer) {
 	for fileIdx := range query.Files {
-		fmt.Printf("\t%s %s:%s\n", printer.PrintBySev(fmt.Sprintf("[%d]:", fileIdx+1), string(query.Severity)),
-			query.Files[fileIdx].FileName, printer.Success.Sprint(query.Files[fileIdx].Line))
+		baselineState := ""
+		if query.Files[fileIdx].BaselineState != "" {
+			baselineState = fmt.Sprintf(" (%s)", query.Files[fileIdx].BaselineState)
+		}
+		fmt.Printf("\t%s %s:%s%s\n", printer.PrintBySev(fmt.Sprintf("[%d]:", fileIdx+1), string(query.Severity)),
+			query.Files[fileIdx].FileName, printer.Success.Sprint(query.Files[fileIdx].Line), baselineState)
 		if !printer.minimal {
 			fmt.Println()
 			for _, line := range *query.Files[fileIdx].VulnLines {
@@ -0,0 +1,52 @@
+package printer
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Checkmarx/kics/v2/pkg/model"
+)
+
+// queryProfileTop is the number of the slowest queries printed at the end of the scan
+const queryProfileTop = 10
+
+// printQueryProfile prints a table with the slowest queries of the profile
+func printQueryProfile(profile *model.QueryProfile, printer *Printer) {
+	queries := profile.Queries
+	if len(queries) > queryProfileTop {
+		queries = queries[:queryProfileTop]
+	}
+
+	headers := []string{"DURATION", "EVALUATIONS", "RESULTS", "STATUS", "PLATFORM", "QUERY"}
+	rows := make([][]string, 0, len(queries))
+	for i := range queries {
+		rows = append(rows, []string{
+			queries[i].Duration.Round(time.Microsecond).String(),
+			fmt.Sprint(queries[i].Evaluations),
+			fmt.Sprint(queries[i].Results),
+			queries[i].Status,
+			queries[i].Platform,
+			fmt.Sprintf("%s (%s)", queries[i].QueryName, queries[i].QueryID),
+		})
+	}
+
+	widths := make([]int, len(headers))
+	for _, row := range append([][]string{headers}, rows...) {
+		for i, cell := range row {
+			if len(cell) > widths[i] {
+				widths[i] = len(cell)
+			}
+		}
+	}
+
+	fmt.Printf("Slowest Queries (%d of %d):\n", len(queries), len(profile.Queries))
+	fmt.Println(printer.Bold(formatQueryTestRow(headers, widths)))
+	for i, row := range rows {
+		line := formatQueryTestRow(row, widths)
+		if queries[i].Status != model.QueryProfileSuccess {
+			line = printer.Critical.Sprint(line)
+		}
+		fmt.Println(line)
+	}
+	fmt.Println()
+}
@@ -0,0 +1,132 @@
+package printer
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Checkmarx/kics/v2/pkg/querytest"
+)
+
+// PrintQueryTests prints a table with the outcome of the test cases of each query, followed by the failures
+func PrintQueryTests(r *querytest.Report, printer *Printer) {
+	headers := []string{"STATUS", "QUERY", "PLATFORM", "POSITIVE", "NEGATIVE"}
+	rows := make([][]string, 0, len(r.Queries))
+	for i := range r.Queries {
+		query := &r.Queries[i]
+		rows = append(rows, []string{
+			queryTestStatus(query.Passed()),
+			queryTestName(r, query),
+			query.Platform,
+			positiveCaseCell(query),
+			negativeCaseCell(query),
+		})
+	}
+
+	widths := make([]int, len(headers))
+	for _, row := range append([][]string{headers}, rows...) {
+		for i, cell := range row {
+			if len(cell) > widths[i] {
+				widths[i] = len(cell)
+			}
+		}
+	}
+
+	fmt.Printf("\n%s\n", printer.Bold(formatQueryTestRow(headers, widths)))
+	for i, row := range rows {
+		line := formatQueryTestRow(row, widths)
+		if r.Queries[i].Passed() {
+			fmt.Println(printer.Success.Sprint(line[:widths[0]]) + line[widths[0]:])
+		} else {
+			fmt.Println(printer.Critical.Sprint(line[:widths[0]]) + line[widths[0]:])
+		}
+	}
+
+	printQueryTestFailures(r, printer)
+
+	fmt.Printf("\nQueries: %d\nPassed: %d\nFailed: %d\nDuration: %s\n\n",
+		len(r.Queries), r.Passed, r.Failed, r.Duration.Round(time.Millisecond))
+}
+
+func printQueryTestFailures(r *querytest.Report, printer *Printer) {
+	if r.Failed == 0 {
+		return
+	}
+
+	fmt.Printf("\n%s\n", printer.Bold("Failures:"))
+	for i := range r.Queries {
+		query := &r.Queries[i]
+		if query.Passed() {
+			continue
+		}
+
+		fmt.Printf("\n%s (%s)\n", printer.Critical.Sprint(queryTestName(r, query)), query.Path)
+		if query.Error != "" {
+			fmt.Printf("\terror: %s\n", query.Error)
+			continue
+		}
+		printQueryTestCase("positive", &query.Positive)
+		printQueryTestCase("negative", &query.Negative)
+	}
+}
+
+func printQueryTestCase(name string, c *querytest.CaseResult) {
+	if c.Passed() {
+		return
+	}
+	if c.Error != "" {
+		fmt.Printf("\t%s samples error: %s\n", name, c.Error)
+		return
+	}
+	for _, line := range strings.Split(strings.TrimSuffix(querytest.FormatMismatches(c), "\n"), "\n") {
+		fmt.Printf("\t%s %s\n", name, line)
+	}
+}
+
+func queryTestStatus(passed bool) string {
+	if passed {
+		return "PASS"
+	}
+	return "FAIL"
+}
+
+func queryTestName(r *querytest.Report, query *querytest.QueryResult) string {
+	if query.QueryName != "" {
+		return query.QueryName
+	}
+	if rel, err := filepath.Rel(r.QueriesPath, query.Path); err == nil && rel != "." {
+		return filepath.ToSlash(rel)
+	}
+	return filepath.Base(query.Path)
+}
+
+func positiveCaseCell(query *querytest.QueryResult) string {
+	switch {
+	case query.Error != "" || query.Positive.Error != "":
+		return "error"
+	case len(query.Positive.Unexpected) > 0:
+		return fmt.Sprintf("%d/%d (+%d)", query.Positive.Matched, query.Positive.Expected, len(query.Positive.Unexpected))
+	default:
+		return fmt.Sprintf("%d/%d", query.Positive.Matched, query.Positive.Expected)
+	}
+}
+
+func negativeCaseCell(query *querytest.QueryResult) string {
+	switch {
+	case query.Error != "" || query.Negative.Error != "":
+		return "error"
+	case len(query.Negative.Unexpected) > 0:
+		return fmt.Sprintf("%d unexpected", len(query.Negative.Unexpected))
+	default:
+		return "no results"
+	}
+}
+
+func formatQueryTestRow(row []string, widths []int) string {
+	cells := make([]string, len(row))
+	for i, cell := range row {
+		cells[i] = fmt.Sprintf("%-*s", widths[i], cell)
+	}
+	return strings.TrimRight(strings.Join(cells, "  "), " ")
+}
@@ -0,0 +1,158 @@
+package querytest
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Checkmarx/kics/v2/internal/constants"
+	"github.com/Checkmarx/kics/v2/pkg/report"
+)
+
+type junitTestSuites struct {
+	XMLName    xml.Name         `xml:"testsuites"`
+	Name       string           `xml:"name,attr"`
+	Tests      int              `xml:"tests,attr"`
+	Failures   int              `xml:"failures,attr"`
+	Time       string           `xml:"time,attr"`
+	TestSuites []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Time      string          `xml:"time,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+	duration  time.Duration
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Type    string `xml:"type,attr"`
+	Message string `xml:"message,attr"`
+	Details string `xml:",chardata"`
+}
+
+// ExportJUnit writes the report in the JUnit format to the output file, with a test suite for each platform and
+// a test case for the positive and the negative samples of each query
+func ExportJUnit(r *Report, output string) error {
+	if output == "" {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(output), os.ModePerm); err != nil {
+		return err
+	}
+
+	return report.ExportXMLReport(filepath.Dir(output), filepath.Base(output), buildJUnitReport(r))
+}
+
+func buildJUnitReport(r *Report) *junitTestSuites {
+	suites := make(map[string]*junitTestSuite)
+	for i := range r.Queries {
+		query := &r.Queries[i]
+
+		platform := query.Platform
+		if platform == "" {
+			platform = "unknown"
+		}
+		suite, ok := suites[platform]
+		if !ok {
+			suite = &junitTestSuite{Name: platform}
+			suites[platform] = suite
+		}
+
+		className := filepath.Base(query.Path)
+		if rel, err := filepath.Rel(r.QueriesPath, query.Path); err == nil && rel != "." {
+			className = filepath.ToSlash(rel)
+		}
+
+		for _, testCase := range []junitTestCase{
+			newJUnitTestCase(query, className, "positive", &query.Positive),
+			newJUnitTestCase(query, className, "negative", &query.Negative),
+		} {
+			suite.Tests++
+			if testCase.Failure != nil {
+				suite.Failures++
+			}
+			suite.TestCases = append(suite.TestCases, testCase)
+		}
+		suite.duration += query.Duration
+	}
+
+	jUnit := &junitTestSuites{
+		Name:       fmt.Sprintf("KICS %s query tests", constants.Version),
+		Time:       formatSeconds(r.Duration),
+		TestSuites: make([]junitTestSuite, 0, len(suites)),
+	}
+	for _, suite := range suites {
+		suite.Time = formatSeconds(suite.duration)
+		jUnit.Tests += suite.Tests
+		jUnit.Failures += suite.Failures
+		jUnit.TestSuites = append(jUnit.TestSuites, *suite)
+	}
+	sort.Slice(jUnit.TestSuites, func(i, j int) bool {
+		return jUnit.TestSuites[i].Name < jUnit.TestSuites[j].Name
+	})
+
+	return jUnit
+}
+
+func newJUnitTestCase(query *QueryResult, className, name string, c *CaseResult) junitTestCase {
+	if query.QueryName != "" {
+		name = fmt.Sprintf("%s: %s samples", query.QueryName, name)
+	}
+	testCase := junitTestCase{
+		Name:      name,
+		ClassName: className,
+	}
+
+	switch {
+	case query.Error != "":
+		testCase.Failure = &junitFailure{Type: "error", Message: query.Error}
+	case c.Error != "":
+		testCase.Failure = &junitFailure{Type: "error", Message: c.Error}
+	case !c.Passed():
+		testCase.Failure = &junitFailure{
+			Type: "results",
+			Message: fmt.Sprintf("%d of %d expected results found, %d missing and %d unexpected results",
+				c.Matched, c.Expected, len(c.Missing), len(c.Unexpected)),
+			Details: FormatMismatches(c),
+		}
+	}
+
+	return testCase
+}
+
+// FormatMismatches describes the missing and the unexpected results of the case, one per line
+func FormatMismatches(c *CaseResult) string {
+	var sb strings.Builder
+	for i := range c.Missing {
+		fmt.Fprintf(&sb, "missing: %s\n", formatResult(&c.Missing[i]))
+	}
+	for i := range c.Unexpected {
+		fmt.Fprintf(&sb, "unexpected: %s\n", formatResult(&c.Unexpected[i]))
+	}
+	return sb.String()
+}
+
+func formatResult(r *Result) string {
+	fileName := r.FileName
+	if fileName == "" {
+		fileName = "*"
+	}
+	return fmt.Sprintf("%s:%d %s (%s)", fileName, r.Line, r.QueryName, r.Severity)
+}
+
+func formatSeconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
@@ -0,0 +1,64 @@
+package querytest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Checkmarx/kics/v2/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ExportJUnit(t *testing.T) {
+	report := &Report{
+		QueriesPath: filepath.Join("queries", "terraform"),
+		Queries: []QueryResult{
+			{
+				Path:      filepath.Join("queries", "terraform", "aws", "bucket_public"),
+				QueryName: "Bucket Public",
+				Platform:  "terraform",
+				Positive:  CaseResult{Expected: 1, Matched: 1},
+				Duration:  time.Second,
+			},
+			{
+				Path:      filepath.Join("queries", "terraform", "aws", "bucket_logging"),
+				QueryName: "Bucket Logging",
+				Platform:  "terraform",
+				Positive: CaseResult{
+					Expected: 1,
+					Missing:  []Result{{QueryName: "Bucket Logging", Severity: model.SeverityMedium, Line: 3, FileName: "positive.tf"}},
+				},
+				Negative: CaseResult{Error: "failed to evaluate query"},
+				Duration: time.Second,
+			},
+			{
+				Path:  filepath.Join("queries", "terraform", "broken"),
+				Error: "failed to read query broken",
+			},
+		},
+		Passed:   1,
+		Failed:   2,
+		Duration: 2 * time.Second,
+	}
+
+	output := filepath.Join(t.TempDir(), "reports", "junit.xml")
+	require.NoError(t, ExportJUnit(report, output))
+
+	content, err := os.ReadFile(output)
+	require.NoError(t, err)
+
+	got := string(content)
+	require.Contains(t, got, `<testsuites name="KICS development query tests" tests="6" failures="4" time="2.000">`)
+	require.Contains(t, got, `<testsuite name="terraform" tests="4" failures="2" time="2.000">`)
+	require.Contains(t, got, `<testsuite name="unknown" tests="2" failures="2" time="0.000">`)
+	require.Contains(t, got, `<testcase name="Bucket Public: positive samples" classname="aws/bucket_public"></testcase>`)
+	require.Contains(t, got, `<failure type="results" message="0 of 1 expected results found, 1 missing and 0 unexpected results">`+
+		`missing: positive.tf:3 Bucket Logging (MEDIUM)&#xA;</failure>`)
+	require.Contains(t, got, `<failure type="error" message="failed to evaluate query"></failure>`)
+	require.Contains(t, got, `<testcase name="negative" classname="broken">`)
+}
+
+func Test_ExportJUnitWithoutOutput(t *testing.T) {
+	require.NoError(t, ExportJUnit(&Report{}, ""))
+}
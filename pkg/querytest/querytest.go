@@ -0,0 +1,304 @@
+package querytest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Checkmarx/kics/v2/pkg/engine"
+	"github.com/Checkmarx/kics/v2/pkg/engine/source"
+	"github.com/Checkmarx/kics/v2/pkg/model"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// ExpectedResultsFileName is the file, inside the test directory of a query, with the results expected on its positive samples
+	ExpectedResultsFileName = "positive_expected_result.json"
+
+	testDirName = "test"
+	scanID      = "query_test"
+)
+
+// Parameters represents all available test parameters
+type Parameters struct {
+	QueriesPath   string
+	LibrariesPath string
+	JUnitOutput   string
+	QueryTimeout  int
+}
+
+// Result is a result expected on, or detected in, the samples of a query
+type Result struct {
+	QueryName string         `json:"queryName"`
+	Severity  model.Severity `json:"severity"`
+	Line      int            `json:"line"`
+	FileName  string         `json:"fileName"`
+}
+
+// CaseResult is the outcome of the positive or the negative samples of a query
+type CaseResult struct {
+	Files      []string
+	Expected   int
+	Matched    int
+	Missing    []Result
+	Unexpected []Result
+	Error      string
+}
+
+// QueryResult is the outcome of the test cases of a query
+type QueryResult struct {
+	Path      string
+	QueryID   string
+	QueryName string
+	Platform  string
+	Positive  CaseResult
+	Negative  CaseResult
+	Error     string
+	Duration  time.Duration
+}
+
+// Report contains the outcome of the test cases of every query found in the queries path
+type Report struct {
+	QueriesPath string
+	Queries     []QueryResult
+	Passed      int
+	Failed      int
+	Duration    time.Duration
+}
+
+// Passed returns true when the samples of the case were scanned and the detected results are the expected ones
+func (c *CaseResult) Passed() bool {
+	return c.Error == "" && len(c.Missing) == 0 && len(c.Unexpected) == 0
+}
+
+// Passed returns true when both the positive and the negative samples of the query passed
+func (q *QueryResult) Passed() bool {
+	return q.Error == "" && q.Positive.Passed() && q.Negative.Passed()
+}
+
+// Run tests every query found in the queries path, scanning its positive samples, which must produce exactly
+// the results of the expected results file, and its negative samples, which must produce no results
+func Run(ctx context.Context, params *Parameters) (*Report, error) {
+	start := time.Now()
+
+	queryDirs, err := FindQueries(params.QueriesPath)
+	if err != nil {
+		return nil, err
+	}
+	if len(queryDirs) == 0 {
+		return nil, fmt.Errorf("no queries found in %s", params.QueriesPath)
+	}
+
+	report := &Report{
+		QueriesPath: params.QueriesPath,
+		Queries:     make([]QueryResult, 0, len(queryDirs)),
+	}
+	for _, queryDir := range queryDirs {
+		log.Debug().Msgf("Testing query %s", queryDir)
+
+		result := testQuery(ctx, params, queryDir)
+		if result.Passed() {
+			report.Passed++
+		} else {
+			report.Failed++
+		}
+		report.Queries = append(report.Queries, result)
+	}
+	report.Duration = time.Since(start)
+
+	return report, nil
+}
+
+// FindQueries returns the directories with a query under the queries path, sorted by path
+func FindQueries(queriesPath string) ([]string, error) {
+	var queryDirs []string
+
+	err := filepath.WalkDir(queriesPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && d.Name() == testDirName {
+			return filepath.SkipDir
+		}
+		if !d.IsDir() && d.Name() == source.QueryFileName {
+			queryDirs = append(queryDirs, filepath.Dir(path))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(queryDirs)
+	return queryDirs, nil
+}
+
+func testQuery(ctx context.Context, params *Parameters, queryDir string) (result QueryResult) {
+	start := time.Now()
+	result = QueryResult{
+		Path:     queryDir,
+		Positive: newCaseResult(),
+		Negative: newCaseResult(),
+	}
+	defer func() {
+		result.Duration = time.Since(start)
+	}()
+
+	query, err := source.ReadQuery(queryDir)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.QueryID, _ = query.Metadata["id"].(string)
+	result.QueryName, _ = query.Metadata["queryName"].(string)
+	result.Platform = query.Platform
+
+	expected, err := readExpectedResults(queryDir)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	if result.Positive.Files, err = getSampleFiles(queryDir, "positive"); err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	if result.Negative.Files, err = getSampleFiles(queryDir, "negative"); err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	inspector, err := newInspector(ctx, params, &query)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	runCase(ctx, inspector, &result.Positive, expected)
+	runCase(ctx, inspector, &result.Negative, []Result{})
+
+	return result
+}
+
+func newCaseResult() CaseResult {
+	return CaseResult{
+		Files:      []string{},
+		Missing:    []Result{},
+		Unexpected: []Result{},
+	}
+}
+
+func runCase(ctx context.Context, inspector *engine.Inspector, c *CaseResult, expected []Result) {
+	c.Expected = len(expected)
+
+	vulnerabilities, err := scanSamples(ctx, inspector, c.Files)
+	if err != nil {
+		c.Error = err.Error()
+		return
+	}
+
+	compareResults(c, expected, vulnerabilities)
+}
+
+// compareResults matches each detected result with an expected result with the same query name, line, severity
+// and, when the expected result has one, file name
+func compareResults(c *CaseResult, expected []Result, vulnerabilities []model.Vulnerability) {
+	matched := make([]bool, len(vulnerabilities))
+
+	for i := range expected {
+		found := false
+		for j := range vulnerabilities {
+			if !matched[j] && matchResult(&expected[i], &vulnerabilities[j]) {
+				matched[j] = true
+				found = true
+				break
+			}
+		}
+		if found {
+			c.Matched++
+		} else {
+			c.Missing = append(c.Missing, expected[i])
+		}
+	}
+
+	for j := range vulnerabilities {
+		if !matched[j] {
+			c.Unexpected = append(c.Unexpected, Result{
+				QueryName: vulnerabilities[j].QueryName,
+				Severity:  vulnerabilities[j].Severity,
+				Line:      vulnerabilities[j].Line,
+				FileName:  filepath.Base(vulnerabilities[j].FileName),
+			})
+		}
+	}
+
+	sortResults(c.Missing)
+	sortResults(c.Unexpected)
+}
+
+func matchResult(expected *Result, vulnerability *model.Vulnerability) bool {
+	if expected.FileName != "" && expected.FileName != filepath.Base(vulnerability.FileName) {
+		return false
+	}
+
+	return expected.QueryName == vulnerability.QueryName &&
+		expected.Line == vulnerability.Line &&
+		strings.EqualFold(string(expected.Severity), string(vulnerability.Severity))
+}
+
+func sortResults(results []Result) {
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].FileName != results[j].FileName {
+			return results[i].FileName < results[j].FileName
+		}
+		return results[i].Line < results[j].Line
+	})
+}
+
+func readExpectedResults(queryDir string) ([]Result, error) {
+	expectedResultsPath := filepath.Join(queryDir, testDirName, ExpectedResultsFileName)
+
+	content, err := os.ReadFile(filepath.Clean(expectedResultsPath))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read expected results: %w", err)
+	}
+
+	var expected []Result
+	if err := json.Unmarshal(content, &expected); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal expected results %s: %w", expectedResultsPath, err)
+	}
+
+	return expected, nil
+}
+
+// getSampleFiles returns the positive or negative samples of the test directory of the query
+func getSampleFiles(queryDir, prefix string) ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(queryDir, testDirName, prefix+"*"))
+	if err != nil {
+		return nil, err
+	}
+
+	samples := make([]string, 0, len(files))
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			return nil, err
+		}
+		if info.IsDir() || filepath.Base(file) == ExpectedResultsFileName {
+			continue
+		}
+		samples = append(samples, file)
+	}
+
+	if len(samples) == 0 {
+		return nil, fmt.Errorf("no %s samples found in %s", prefix, filepath.Join(queryDir, testDirName))
+	}
+
+	return samples, nil
+}
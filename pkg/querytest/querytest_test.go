@@ -0,0 +1,198 @@
+package querytest
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Checkmarx/kics/v2/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+var queryTestsPath = filepath.Join("..", "..", "test", "fixtures", "query_tests")
+
+func Test_FindQueries(t *testing.T) {
+	tests := []struct {
+		name        string
+		queriesPath string
+		want        []string
+	}{
+		{
+			name:        "find the queries in the directories",
+			queriesPath: queryTestsPath,
+			want: []string{
+				filepath.Join(queryTestsPath, "image_tag_latest"),
+				filepath.Join(queryTestsPath, "image_tag_latest_wrong_expectations"),
+			},
+		},
+		{
+			name:        "find the query of the query directory",
+			queriesPath: filepath.Join(queryTestsPath, "image_tag_latest"),
+			want:        []string{filepath.Join(queryTestsPath, "image_tag_latest")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FindQueries(tt.queriesPath)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_Run(t *testing.T) {
+	report, err := Run(context.Background(), &Parameters{
+		QueriesPath:   queryTestsPath,
+		LibrariesPath: "./assets/libraries",
+		QueryTimeout:  60,
+	})
+	require.NoError(t, err)
+	require.Len(t, report.Queries, 2)
+	require.Equal(t, 1, report.Passed)
+	require.Equal(t, 1, report.Failed)
+
+	passed := report.Queries[0]
+	require.True(t, passed.Passed())
+	require.Equal(t, "9b3a4a4e-7f0c-4b0e-8d8e-2f1c6a1d5e01", passed.QueryID)
+	require.Equal(t, "Image Tag Latest", passed.QueryName)
+	require.Equal(t, "dockerfile", passed.Platform)
+	require.Equal(t, 2, passed.Positive.Matched)
+	require.Equal(t, 2, passed.Positive.Expected)
+	require.Empty(t, passed.Negative.Unexpected)
+
+	failed := report.Queries[1]
+	require.False(t, failed.Passed())
+	require.Equal(t, 1, failed.Positive.Matched)
+	require.Equal(t, []Result{
+		{QueryName: "Image Tag Latest", Severity: model.SeverityLow, Line: 5, FileName: "positive.dockerfile"},
+	}, failed.Positive.Missing)
+	require.Equal(t, []Result{
+		{QueryName: "Image Tag Latest", Severity: model.SeverityLow, Line: 4, FileName: "positive.dockerfile"},
+	}, failed.Positive.Unexpected)
+	require.Equal(t, []Result{
+		{QueryName: "Image Tag Latest", Severity: model.SeverityLow, Line: 4, FileName: "negative.dockerfile"},
+	}, failed.Negative.Unexpected)
+}
+
+func Test_RunWithoutQueries(t *testing.T) {
+	_, err := Run(context.Background(), &Parameters{
+		QueriesPath:   t.TempDir(),
+		LibrariesPath: "./assets/libraries",
+		QueryTimeout:  60,
+	})
+	require.ErrorContains(t, err, "no queries found")
+}
+
+func Test_compareResults(t *testing.T) {
+	expected := []Result{
+		{QueryName: "Query", Severity: model.SeverityHigh, Line: 2},
+		{QueryName: "Query", Severity: model.SeverityHigh, Line: 2},
+		{QueryName: "Query", Severity: model.SeverityHigh, Line: 7, FileName: "positive2.tf"},
+	}
+	vulnerabilities := []model.Vulnerability{
+		{QueryName: "Query", Severity: model.SeverityHigh, Line: 2, FileName: filepath.Join("test", "positive1.tf")},
+		{QueryName: "Query", Severity: model.SeverityMedium, Line: 2, FileName: filepath.Join("test", "positive2.tf")},
+		{QueryName: "Query", Severity: model.SeverityHigh, Line: 7, FileName: filepath.Join("test", "positive2.tf")},
+	}
+
+	c := newCaseResult()
+	compareResults(&c, expected, vulnerabilities)
+
+	require.Equal(t, 2, c.Matched)
+	require.Equal(t, []Result{{QueryName: "Query", Severity: model.SeverityHigh, Line: 2}}, c.Missing)
+	require.Equal(t, []Result{
+		{QueryName: "Query", Severity: model.SeverityMedium, Line: 2, FileName: "positive2.tf"},
+	}, c.Unexpected)
+}
+
+func Test_RunPlanChange(t *testing.T) {
+	queryDir := filepath.Join(t.TempDir(), "plan_password")
+	plan := func(planned, before string) string {
+		return `{
+  "format_version": "1.2",
+  "planned_values": {
+    "root_module": {
+      "resources": [
+        {
+          "address": "aws_db_instance.db",
+          "type": "aws_db_instance",
+          "name": "db",
+          "values": ` + planned + `
+        }
+      ]
+    }
+  },
+  "resource_changes": [
+    {
+      "address": "aws_db_instance.db",
+      "type": "aws_db_instance",
+      "name": "db",
+      "change": {
+        "actions": ["update"],
+        "before": ` + before + `,
+        "after": ` + planned + `
+      }
+    }
+  ]
+}
+`
+	}
+	files := map[string]string{
+		"query.rego": `package Cx
+
+CxPolicy[result] {
+	resource := input.document[i].resource[type][name]
+	walk(resource, [path, value])
+	key := path[count(path) - 1]
+	is_string(key)
+	contains(lower(key), "password")
+	is_string(value)
+
+	result := {
+		"documentId": input.document[i].id,
+		"resourceType": type,
+		"resourceName": name,
+		"searchKey": sprintf("%s[%s]", [type, name]),
+		"issueType": "IncorrectValue",
+		"keyExpectedValue": "password should not be hardcoded",
+		"keyActualValue": "password is hardcoded",
+	}
+}
+`,
+		"metadata.json": `{
+  "id": "6c3b5a7e-1f2d-4e8a-9b0c-3d4e5f6a7b8c",
+  "queryName": "Plan Password",
+  "severity": "HIGH",
+  "category": "Secret Management",
+  "descriptionText": "Passwords should not be hardcoded",
+  "descriptionUrl": "https://developer.hashicorp.com/terraform/language/values/variables",
+  "platform": "Terraform",
+  "descriptionID": "8e1f2a3b",
+  "cwe": "798"
+}
+`,
+		"test/positive.json": plan(`{"identifier": "db", "password": "NewSecret"}`, `{"identifier": "db"}`),
+		"test/negative.json": plan(`{"identifier": "db"}`, `{"identifier": "db", "password": "OldSecret"}`),
+		"test/positive_expected_result.json": `[
+  {"queryName": "Plan Password", "severity": "HIGH", "line": 7, "fileName": "positive.json"}
+]
+`,
+	}
+	for name, content := range files {
+		path := filepath.Join(queryDir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), os.ModePerm))
+		require.NoError(t, os.WriteFile(path, []byte(content), os.ModePerm))
+	}
+
+	report, err := Run(context.Background(), &Parameters{
+		QueriesPath:   filepath.Dir(queryDir),
+		LibrariesPath: "./assets/libraries",
+		QueryTimeout:  60,
+	})
+	require.NoError(t, err)
+	require.Len(t, report.Queries, 1)
+	require.Empty(t, report.Queries[0].Negative.Unexpected, "the values before the change should not be reported")
+	require.True(t, report.Queries[0].Passed(), "%+v", report.Queries[0])
+}
@@ -0,0 +1,174 @@
+package querytest
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Checkmarx/kics/v2/internal/constants"
+	"github.com/Checkmarx/kics/v2/internal/tracker"
+	"github.com/Checkmarx/kics/v2/pkg/engine"
+	"github.com/Checkmarx/kics/v2/pkg/engine/source"
+	"github.com/Checkmarx/kics/v2/pkg/kics"
+	"github.com/Checkmarx/kics/v2/pkg/model"
+	"github.com/Checkmarx/kics/v2/pkg/parser"
+	ansibleConfigParser "github.com/Checkmarx/kics/v2/pkg/parser/ansible/ini/config"
+	ansibleHostsParser "github.com/Checkmarx/kics/v2/pkg/parser/ansible/ini/hosts"
+	bicepParser "github.com/Checkmarx/kics/v2/pkg/parser/bicep"
+	buildahParser "github.com/Checkmarx/kics/v2/pkg/parser/buildah"
+	dockerParser "github.com/Checkmarx/kics/v2/pkg/parser/docker"
+	protoParser "github.com/Checkmarx/kics/v2/pkg/parser/grpc"
+	jsonParser "github.com/Checkmarx/kics/v2/pkg/parser/json"
+	terraformParser "github.com/Checkmarx/kics/v2/pkg/parser/terraform"
+	yamlParser "github.com/Checkmarx/kics/v2/pkg/parser/yaml"
+	"github.com/Checkmarx/kics/v2/pkg/utils"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+const maxResolverDepth = 15
+
+// querySource provides a single query, loaded from its directory, along with the libraries of the filesystem source
+type querySource struct {
+	*source.FilesystemSource
+	query model.QueryMetadata
+}
+
+// GetQueries returns the query of the source, whatever the filters
+func (s *querySource) GetQueries(_ *source.QueryInspectorParameters) ([]model.QueryMetadata, error) {
+	return []model.QueryMetadata{s.query}, nil
+}
+
+// newInspector creates an inspector running only the query, with the libraries of the libraries path
+func newInspector(ctx context.Context, params *Parameters, query *model.QueryMetadata) (*engine.Inspector, error) {
+	queriesSource := &querySource{
+		FilesystemSource: source.NewFilesystemSource([]string{params.QueriesPath}, []string{""}, []string{""},
+			params.LibrariesPath, true),
+		query: *query,
+	}
+
+	return engine.NewInspector(ctx,
+		queriesSource,
+		engine.DefaultVulnerabilityBuilder,
+		&tracker.CITracker{},
+		&source.QueryInspectorParameters{
+			IncludeQueries: source.IncludeQueries{ByIDs: []string{}},
+			ExcludeQueries: source.ExcludeQueries{ByIDs: []string{}, ByCategories: []string{}},
+		},
+		map[string]bool{}, params.QueryTimeout, false, false, 1, false)
+}
+
+// scanSamples scans the samples with the inspector, parsing and inspecting them as a KICS scan does
+func scanSamples(ctx context.Context, inspector *engine.Inspector, files []string) ([]model.Vulnerability, error) {
+	fileMetadatas, err := getFileMetadatas(files)
+	if err != nil {
+		return nil, err
+	}
+
+	currentQuery := make(chan int64)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for range currentQuery {
+		}
+	}()
+
+	vulnerabilities, err := inspector.Inspect(ctx, scanID, fileMetadatas, []string{filepath.Dir(files[0])},
+		getPlatforms(), currentQuery)
+	close(currentQuery)
+	<-done
+	if err != nil {
+		return nil, err
+	}
+
+	if failedQueries := inspector.GetFailedQueries(); len(failedQueries) > 0 {
+		messages := make([]string, 0, len(failedQueries))
+		for name, failure := range failedQueries {
+			messages = append(messages, fmt.Sprintf("%s: %s", name, failure))
+		}
+		sort.Strings(messages)
+		return nil, errors.New(strings.Join(messages, "; "))
+	}
+
+	return vulnerabilities, nil
+}
+
+// getFileMetadatas parses each sample with every parser supporting it, skipping the samples that fail to be parsed
+// as the scan does
+func getFileMetadatas(files []string) (model.FileMetadatas, error) {
+	fileMetadatas := make(model.FileMetadatas, 0, len(files))
+	for _, file := range files {
+		content, err := os.ReadFile(filepath.Clean(file))
+		if err != nil {
+			return nil, err
+		}
+
+		// the parsers keep the state of the parsed files, so each sample gets its own
+		parsers, err := getParsers()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, p := range parsers {
+			documents, err := p.Parse(file, content, true, false, maxResolverDepth)
+			if err != nil {
+				if !errors.Is(err, parser.ErrNotSupportedFile) {
+					log.Err(err).Msgf("failed to parse file content: %s", file)
+				}
+				continue
+			}
+
+			sort.Ints(documents.IgnoreLines)
+			commands := p.CommentsCommands(file, content)
+			for _, document := range documents.Docs {
+				fileMetadatas = append(fileMetadatas, model.FileMetadata{
+					ID:                uuid.New().String(),
+					ScanID:            scanID,
+					Document:          kics.PrepareScanDocument(document, documents.Kind),
+					LineInfoDocument:  document,
+					OriginalData:      documents.Content,
+					Kind:              documents.Kind,
+					FilePath:          file,
+					Commands:          commands,
+					LinesIgnore:       documents.IgnoreLines,
+					ResolvedFiles:     documents.ResolvedFiles,
+					LinesOriginalData: utils.SplitLines(documents.Content),
+					IsMinified:        documents.IsMinified,
+				})
+			}
+		}
+	}
+
+	if len(fileMetadatas) == 0 {
+		log.Warn().Msgf("No supported samples found in %s", filepath.Dir(files[0]))
+	}
+
+	return fileMetadatas, nil
+}
+
+// getPlatforms returns every platform, as named by the analyzer, so the query runs whatever its platform
+func getPlatforms() []string {
+	platforms := make([]string, 0, len(constants.AvailablePlatforms))
+	for platform := range constants.AvailablePlatforms {
+		platforms = append(platforms, platform)
+	}
+	return platforms
+}
+
+func getParsers() ([]*parser.Parser, error) {
+	return parser.NewBuilder().
+		Add(&jsonParser.Parser{}).
+		Add(&yamlParser.Parser{}).
+		Add(&bicepParser.Parser{}).
+		Add(terraformParser.NewDefault()).
+		Add(&dockerParser.Parser{}).
+		Add(&protoParser.Parser{}).
+		Add(&buildahParser.Parser{}).
+		Add(&ansibleConfigParser.Parser{}).
+		Add(&ansibleHostsParser.Parser{}).
+		Build([]string{""}, []string{""})
+}
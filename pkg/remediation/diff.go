@@ -0,0 +1,79 @@
+package remediation
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+const diffContextLines = 3
+
+// UnifiedDiff returns the changes of the remediations of a file as a unified diff with git headers,
+// so it can be applied with 'git apply'. The filePath should be relative to the root of the repository
+func UnifiedDiff(filePath, original, remediated string) string {
+	if original == remediated {
+		return ""
+	}
+
+	return fmt.Sprintf("diff --git a/%[1]s b/%[1]s\n--- a/%[1]s\n+++ b/%[1]s\n", filePath) + diffHunks(original, remediated)
+}
+
+// diffHunks returns the hunks of the unified diff of the contents
+func diffHunks(original, remediated string) string {
+	a, b := splitDiffLines(original), splitDiffLines(remediated)
+	matcher := difflib.NewMatcher(a, b)
+
+	var sb strings.Builder
+	for _, group := range matcher.GetGroupedOpCodes(diffContextLines) {
+		first, last := group[0], group[len(group)-1]
+		fmt.Fprintf(&sb, "@@ -%s +%s @@\n", hunkRange(first.I1, last.I2), hunkRange(first.J1, last.J2))
+
+		for _, op := range group {
+			if op.Tag == 'e' {
+				writeDiffLines(&sb, " ", a[op.I1:op.I2])
+				continue
+			}
+			if op.Tag == 'r' || op.Tag == 'd' {
+				writeDiffLines(&sb, "-", a[op.I1:op.I2])
+			}
+			if op.Tag == 'r' || op.Tag == 'i' {
+				writeDiffLines(&sb, "+", b[op.J1:op.J2])
+			}
+		}
+	}
+
+	return sb.String()
+}
+
+// splitDiffLines splits the content into lines keeping their line breaks
+func splitDiffLines(content string) []string {
+	lines := strings.SplitAfter(content, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	return lines
+}
+
+// hunkRange formats the range of lines of a hunk, starting at 1, as the unified diff format does
+func hunkRange(start, end int) string {
+	length := end - start
+	if length == 1 {
+		return fmt.Sprintf("%d", start+1)
+	}
+	if length == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+
+	return fmt.Sprintf("%d,%d", start+1, length)
+}
+
+func writeDiffLines(sb *strings.Builder, prefix string, lines []string) {
+	for _, line := range lines {
+		sb.WriteString(prefix + line)
+		if !strings.HasSuffix(line, "\n") {
+			sb.WriteString("\n\\ No newline at end of file\n")
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package remediation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_UnifiedDiff(t *testing.T) {
+	tests := []struct {
+		name       string
+		original   string
+		remediated string
+		want       string
+	}{
+		{
+			name:       "without changes",
+			original:   "a\nb\n",
+			remediated: "a\nb\n",
+			want:       "",
+		},
+		{
+			name:       "replacement and addition in the same hunk",
+			original:   "resource \"a\" \"b\" {\n  enabled = false\n  name    = \"b\"\n}\n",
+			remediated: "resource \"a\" \"b\" {\n  enabled = true\n  name    = \"b\"\n  encrypted = true\n}\n",
+			want: `diff --git a/main.tf b/main.tf
+--- a/main.tf
++++ b/main.tf
+@@ -1,4 +1,5 @@
+ resource "a" "b" {
+-  enabled = false
++  enabled = true
+   name    = "b"
++  encrypted = true
+ }
+`,
+		},
+		{
+			name:       "changes far apart in different hunks",
+			original:   "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n",
+			remediated: "one\n2\n3\n4\n5\n6\n7\n8\n9\nten\n",
+			want: `diff --git a/main.tf b/main.tf
+--- a/main.tf
++++ b/main.tf
+@@ -1,4 +1,4 @@
+-1
++one
+ 2
+ 3
+ 4
+@@ -7,4 +7,4 @@
+ 7
+ 8
+ 9
+-10
++ten
+`,
+		},
+		{
+			name:       "file without newline at the end",
+			original:   "a\nb",
+			remediated: "a\nc",
+			want: `diff --git a/main.tf b/main.tf
+--- a/main.tf
++++ b/main.tf
+@@ -1,2 +1,2 @@
+ a
+-b
+\ No newline at end of file
++c
+\ No newline at end of file
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, UnifiedDiff("main.tf", tt.original, tt.remediated))
+		})
+	}
+}
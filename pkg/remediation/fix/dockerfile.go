@@ -0,0 +1,131 @@
+package fix
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+var dockerEscapeDirectiveRegex = regexp.MustCompile(`(?i)^#\s*escape\s*=\s*(\S)\s*$`)
+
+// dockerfileFixer locates the remediations of Dockerfiles, replacing values in the arguments of the instructions
+// and adding the new instructions at the end of the build stage of the result
+type dockerfileFixer struct{}
+
+// dockerInstruction is an instruction of a Dockerfile, spanning from its first line to the last of its continuations
+type dockerInstruction struct {
+	command   string
+	startLine int
+	endLine   int
+	argsStart int
+	end       int
+}
+
+func (dockerfileFixer) replacement(r *Remediation, content string) (Edit, bool) {
+	replacement, ok := getReplacementInfo(r)
+	if !ok {
+		return Edit{}, false
+	}
+
+	for _, instruction := range parseDockerfile(content) {
+		if instruction.startLine <= r.Line && r.Line <= instruction.endLine {
+			return replaceValue(r, replacement, content[instruction.argsStart:instruction.end], instruction.argsStart)
+		}
+	}
+
+	return lineFixer{}.replacement(r, content)
+}
+
+// addition adds the instructions after the last instruction of the build stage of the result
+func (dockerfileFixer) addition(r *Remediation, content string) (Edit, bool) {
+	instructions := parseDockerfile(content)
+	escape := dockerEscape(content)
+
+	stage := -1
+	for i, instruction := range instructions {
+		if instruction.command == "from" && instruction.startLine <= r.Line {
+			stage = i
+		}
+	}
+	if stage < 0 {
+		return lineFixer{}.addition(r, content)
+	}
+
+	starts := lineStarts(content)
+	last := stage
+	for i := stage; i < len(instructions) && (i == stage || instructions[i].command != "from"); i++ {
+		last = i
+
+		text := content[starts[instructions[i].startLine-1]:instructions[i].end]
+		if strings.EqualFold(normalizeInstruction(text, escape), normalizeInstruction(r.Remediation, escape)) {
+			log.Info().Msgf("remediation '%s' is already done", r.SimilarityID)
+			return Edit{}, false
+		}
+	}
+
+	lines := strings.Split(content, "\n")
+	indentation := lineIndentation(lines[instructions[last].startLine-1])
+
+	return insertLines(content, instructions[last].endLine, indentLines(strings.TrimSpace(r.Remediation), indentation, "  "))
+}
+
+// parseDockerfile returns the instructions of the Dockerfile, skipping its comments and empty lines
+func parseDockerfile(content string) []dockerInstruction {
+	escape := dockerEscape(content)
+	starts := lineStarts(content)
+	lines := strings.Split(content, "\n")
+
+	var instructions []dockerInstruction
+	for i := 0; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		command := strings.Fields(trimmed)[0]
+		instruction := dockerInstruction{
+			command:   strings.ToLower(command),
+			startLine: i + 1,
+			argsStart: starts[i] + strings.Index(lines[i], command) + len(command),
+		}
+
+		for i < len(lines)-1 && strings.HasSuffix(strings.TrimRight(lines[i], " \t\r"), escape) {
+			i++
+			for i < len(lines)-1 && isDockerSkippedLine(lines[i]) {
+				i++
+			}
+		}
+
+		instruction.endLine = i + 1
+		instruction.end = starts[i] + len(strings.TrimRight(lines[i], "\r"))
+		instructions = append(instructions, instruction)
+	}
+
+	return instructions
+}
+
+// dockerEscape returns the escape character of the Dockerfile, set by the escape parser directive
+func dockerEscape(content string) string {
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if !strings.HasPrefix(trimmed, "#") {
+			break
+		}
+		if match := dockerEscapeDirectiveRegex.FindStringSubmatch(trimmed); match != nil {
+			return match[1]
+		}
+	}
+
+	return `\`
+}
+
+func isDockerSkippedLine(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	return trimmed == "" || strings.HasPrefix(trimmed, "#")
+}
+
+// normalizeInstruction joins the continuations of the instruction and collapses its whitespace
+func normalizeInstruction(text, escape string) string {
+	return strings.Join(strings.Fields(strings.ReplaceAll(text, escape+"\n", " ")), " ")
+}
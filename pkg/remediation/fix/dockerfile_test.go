@@ -0,0 +1,139 @@
+package fix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_dockerfileFixer(t *testing.T) {
+	content := `# syntax=docker/dockerfile:1
+FROM golang:1.22 AS builder
+RUN go build -o /app .
+
+FROM alpine:3.19
+RUN apk add --no-cache \
+    ca-certificates \
+    # the certificates of the root user
+    tzdata
+USER root
+COPY --from=builder /app /app
+CMD ["/app"]`
+
+	tests := []struct {
+		name        string
+		remediation Remediation
+		addition    bool
+		want        string
+	}{
+		{
+			name: "replace the value in the arguments of the instruction",
+			remediation: Remediation{
+				Line:        10,
+				Remediation: `{"before":"root","after":"nobody"}`,
+				SearchKey:   "FROM={{alpine:3.19}}.{{USER root}}",
+			},
+			want: `# syntax=docker/dockerfile:1
+FROM golang:1.22 AS builder
+RUN go build -o /app .
+
+FROM alpine:3.19
+RUN apk add --no-cache \
+    ca-certificates \
+    # the certificates of the root user
+    tzdata
+USER nobody
+COPY --from=builder /app /app
+CMD ["/app"]`,
+		},
+		{
+			name: "replace the value in the continuation lines of the instruction",
+			remediation: Remediation{
+				Line:        6,
+				Remediation: `{"before":"tzdata","after":"tzdata=2024a-r0"}`,
+				SearchKey:   "FROM={{alpine:3.19}}.{{RUN apk add --no-cache ca-certificates tzdata}}",
+			},
+			want: `# syntax=docker/dockerfile:1
+FROM golang:1.22 AS builder
+RUN go build -o /app .
+
+FROM alpine:3.19
+RUN apk add --no-cache \
+    ca-certificates \
+    # the certificates of the root user
+    tzdata=2024a-r0
+USER root
+COPY --from=builder /app /app
+CMD ["/app"]`,
+		},
+		{
+			name: "add an instruction at the end of the build stage",
+			remediation: Remediation{
+				Line:        2,
+				Remediation: "HEALTHCHECK NONE",
+				SearchKey:   "FROM={{golang:1.22 AS builder}}",
+			},
+			addition: true,
+			want: `# syntax=docker/dockerfile:1
+FROM golang:1.22 AS builder
+RUN go build -o /app .
+HEALTHCHECK NONE
+
+FROM alpine:3.19
+RUN apk add --no-cache \
+    ca-certificates \
+    # the certificates of the root user
+    tzdata
+USER root
+COPY --from=builder /app /app
+CMD ["/app"]`,
+		},
+		{
+			name: "add an instruction at the end of the file without a line break at the end",
+			remediation: Remediation{
+				Line:        5,
+				Remediation: "HEALTHCHECK CMD wget -q --spider http://localhost/ || exit 1",
+				SearchKey:   "FROM={{alpine:3.19}}",
+			},
+			addition: true,
+			want: `# syntax=docker/dockerfile:1
+FROM golang:1.22 AS builder
+RUN go build -o /app .
+
+FROM alpine:3.19
+RUN apk add --no-cache \
+    ca-certificates \
+    # the certificates of the root user
+    tzdata
+USER root
+COPY --from=builder /app /app
+CMD ["/app"]
+HEALTHCHECK CMD wget -q --spider http://localhost/ || exit 1`,
+		},
+		{
+			name: "skip an addition already done",
+			remediation: Remediation{
+				Line:        5,
+				Remediation: "user  root",
+				SearchKey:   "FROM={{alpine:3.19}}",
+			},
+			addition: true,
+			want:     "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			edit, ok := dockerfileFixer{}.replacement(&tt.remediation, content)
+			if tt.addition {
+				edit, ok = dockerfileFixer{}.addition(&tt.remediation, content)
+			}
+
+			got := ""
+			if ok {
+				got = edit.Apply(content)
+			}
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,190 @@
+// Package fix locates the changes of the remediations of the results in the content of their files,
+// using the structure of the files to find the attributes to change and the blocks to add to
+package fix
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// TypeReplacement is the type of the remediations replacing a value
+	TypeReplacement = "replacement"
+	// TypeAddition is the type of the remediations adding attributes or blocks
+	TypeAddition = "addition"
+)
+
+// Remediation is the remediation of a result
+type Remediation struct {
+	Type         string
+	Line         int
+	Remediation  string
+	SearchKey    string
+	SimilarityID string
+}
+
+// ReplacementInfo presents the relevant information to do the replacement
+type ReplacementInfo struct {
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+// Edit is the change of a remediation, replacing the content between the offsets Start and End with Text
+type Edit struct {
+	Start int
+	End   int
+	Text  string
+}
+
+// Apply returns the content with the edit applied
+func (e Edit) Apply(content string) string {
+	return content[:e.Start] + e.Text + content[e.End:]
+}
+
+// fixer locates the edit of a remediation in the content of a file, returning false when the remediation
+// can not be located or is already done
+type fixer interface {
+	replacement(r *Remediation, content string) (Edit, bool)
+	addition(r *Remediation, content string) (Edit, bool)
+}
+
+// Locate locates the edit of the remediation in the content of the file
+func Locate(filePath, content string, r *Remediation) (Edit, bool) {
+	f := getFixer(filePath)
+
+	switch r.Type {
+	case TypeReplacement:
+		return f.replacement(r, content)
+	case TypeAddition:
+		return f.addition(r, content)
+	default:
+		return Edit{}, false
+	}
+}
+
+// Supported returns true when the structure of the file is understood, so its remediations can be located
+func Supported(filePath string) bool {
+	_, ok := getFixer(filePath).(lineFixer)
+	return !ok
+}
+
+// getFixer returns the fixer that understands the structure of the file
+func getFixer(filePath string) fixer {
+	if isDockerfile(filePath) {
+		return dockerfileFixer{}
+	}
+
+	switch strings.ToLower(filepath.Ext(filePath)) {
+	case ".tf":
+		return hclFixer{}
+	case ".yaml", ".yml":
+		return yamlFixer{}
+	case ".json":
+		return jsonFixer{}
+	default:
+		return lineFixer{}
+	}
+}
+
+// isDockerfile returns true for the files named as Dockerfiles, such as 'Dockerfile', 'Dockerfile.ubi8' and 'app.dockerfile'
+func isDockerfile(filePath string) bool {
+	base := strings.ToLower(filepath.Base(filePath))
+	return base == "dockerfile" || strings.HasPrefix(base, "dockerfile.") || filepath.Ext(base) == ".dockerfile"
+}
+
+func getReplacementInfo(r *Remediation) (ReplacementInfo, bool) {
+	var replacement ReplacementInfo
+	err := json.Unmarshal([]byte(r.Remediation), &replacement)
+
+	return replacement, err == nil && replacement != (ReplacementInfo{})
+}
+
+// replaceValue locates the replacement of the 'before' value in the text found at the offset of the content
+func replaceValue(r *Remediation, replacement ReplacementInfo, text string, offset int) (Edit, bool) {
+	i := valueIndex(text, replacement.Before)
+
+	if i < 0 || replacement.Before == replacement.After {
+		log.Info().Msgf("remediation '%s' is already done", r.SimilarityID)
+		return Edit{}, false
+	}
+
+	return Edit{
+		Start: offset + i,
+		End:   offset + i + len(replacement.Before),
+		Text:  replacement.After,
+	}, true
+}
+
+// lineFixer locates the remediations in the lines of the file, being used when its structure is unknown
+type lineFixer struct{}
+
+func (lineFixer) replacement(r *Remediation, content string) (Edit, bool) {
+	replacement, ok := getReplacementInfo(r)
+	if !ok {
+		return Edit{}, false
+	}
+
+	starts := lineStarts(content)
+	if r.Line < 1 || r.Line > len(starts) {
+		return Edit{}, false
+	}
+
+	line := strings.Split(content[starts[r.Line-1]:], "\n")[0]
+	i := strings.Index(line, replacement.Before)
+
+	if i < 0 || replacement.Before == replacement.After {
+		log.Info().Msgf("remediation '%s' is already done", r.SimilarityID)
+		return Edit{}, false
+	}
+
+	return Edit{
+		Start: starts[r.Line-1] + i,
+		End:   starts[r.Line-1] + i + len(replacement.Before),
+		Text:  replacement.After,
+	}, true
+}
+
+// addition inserts the remediation after the line of the result, with the indentation of the line that follows it
+func (lineFixer) addition(r *Remediation, content string) (Edit, bool) {
+	lines := strings.Split(content, "\n")
+	fatherNumberLine := r.Line - 1
+
+	if fatherNumberLine < 0 || len(lines) <= fatherNumberLine+1 {
+		return Edit{}, false
+	}
+
+	firstLine := strings.Split(r.Remediation, "\n")[0]
+
+	if strings.TrimSpace(lines[fatherNumberLine+1]) == strings.TrimSpace(firstLine) {
+		log.Info().Msgf("remediation '%s' is already done", r.SimilarityID)
+		return Edit{}, false
+	}
+
+	return insertLines(content, r.Line, getBefore(lines[fatherNumberLine+1])+r.Remediation)
+}
+
+// insertLines locates the insertion of the text after the line
+func insertLines(content string, line int, text string) (Edit, bool) {
+	starts := lineStarts(content)
+	if content != "" && line == len(starts) && !strings.HasSuffix(content, "\n") {
+		// the last line has no line break, so the text is added in a new line keeping the file without one at the end
+		return Edit{
+			Start: len(content),
+			End:   len(content),
+			Text:  "\n" + text,
+		}, true
+	}
+
+	if line < 0 || line >= len(starts) {
+		return Edit{}, false
+	}
+
+	return Edit{
+		Start: starts[line],
+		End:   starts[line],
+		Text:  text + "\n",
+	}, true
+}
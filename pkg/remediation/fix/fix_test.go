@@ -0,0 +1,28 @@
+package fix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Supported(t *testing.T) {
+	tests := []struct {
+		filePath string
+		want     bool
+	}{
+		{filePath: "main.tf", want: true},
+		{filePath: "deployment.yml", want: true},
+		{filePath: "template.json", want: true},
+		{filePath: "build/Dockerfile", want: true},
+		{filePath: "Dockerfile.ubi8", want: true},
+		{filePath: "app.dockerfile", want: true},
+		{filePath: "service.proto", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.filePath, func(t *testing.T) {
+			require.Equal(t, tt.want, Supported(tt.filePath))
+		})
+	}
+}
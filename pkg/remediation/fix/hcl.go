@@ -0,0 +1,233 @@
+package fix
+
+import (
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rs/zerolog/log"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// hclFixer locates the remediations of Terraform files, finding the blocks and attributes to change with hclsyntax
+// and formatting the added content with hclwrite, so the rest of the file is kept as it is
+type hclFixer struct{}
+
+// hclContainer is a block or an object where new attributes and blocks can be added
+type hclContainer struct {
+	names      map[string]bool
+	openBrace  hcl.Range
+	closeBrace hcl.Range
+}
+
+func (hclFixer) replacement(r *Remediation, content string) (Edit, bool) {
+	replacement, ok := getReplacementInfo(r)
+	if !ok {
+		return Edit{}, false
+	}
+
+	body, ok := parseHCL(content)
+	if !ok {
+		return lineFixer{}.replacement(r, content)
+	}
+
+	expr := hclReplacementTarget(body, r.Line, searchKeyAttribute(r.SearchKey))
+	if expr == nil {
+		return lineFixer{}.replacement(r, content)
+	}
+
+	rng := expr.Range()
+
+	return replaceValue(r, replacement, content[rng.Start.Byte:rng.End.Byte], rng.Start.Byte)
+}
+
+func (hclFixer) addition(r *Remediation, content string) (Edit, bool) {
+	body, ok := parseHCL(content)
+	if !ok {
+		return lineFixer{}.addition(r, content)
+	}
+
+	container := hclAdditionTarget(body, r.Line)
+	if container == nil {
+		return lineFixer{}.addition(r, content)
+	}
+
+	snippet, diags := hclwrite.ParseConfig([]byte(r.Remediation), "", hcl.InitialPos)
+	if diags.HasErrors() {
+		log.Error().Msgf("failed to parse remediation '%s': %s", r.SimilarityID, diags.Error())
+		return Edit{}, false
+	}
+
+	for name := range snippet.Body().Attributes() {
+		if container.names[name] {
+			log.Info().Msgf("remediation '%s' is already done", r.SimilarityID)
+			return Edit{}, false
+		}
+	}
+
+	for _, block := range snippet.Body().Blocks() {
+		if container.names[block.Type()] {
+			log.Info().Msgf("remediation '%s' is already done", r.SimilarityID)
+			return Edit{}, false
+		}
+	}
+
+	lines := strings.Split(content, "\n")
+	unit := indentationUnit(lines)
+	indentation := lineIndentation(lines[container.openBrace.Start.Line-1])
+	formatted := strings.TrimSpace(string(hclwrite.Format(snippet.Bytes())))
+	addition := indentLines(formatted, indentation+unit, unit)
+
+	closeLineStart := lineStarts(content)[container.closeBrace.Start.Line-1]
+	if container.openBrace.Start.Line != container.closeBrace.Start.Line &&
+		strings.TrimSpace(content[closeLineStart:container.closeBrace.Start.Byte]) == "" {
+		// add the remediation before the line closing the container
+		return insertLines(content, container.closeBrace.Start.Line-1, addition)
+	}
+
+	// the container is defined in a single line, so its content is split into lines
+	inner := strings.TrimSpace(content[container.openBrace.End.Byte:container.closeBrace.Start.Byte])
+	if inner != "" {
+		addition = indentation + unit + inner + "\n" + addition
+	}
+
+	return Edit{
+		Start: container.openBrace.End.Byte,
+		End:   container.closeBrace.Start.Byte,
+		Text:  "\n" + addition + "\n" + indentation,
+	}, true
+}
+
+func parseHCL(content string) (*hclsyntax.Body, bool) {
+	file, diags := hclsyntax.ParseConfig([]byte(content), "", hcl.InitialPos)
+	if diags.HasErrors() {
+		log.Debug().Msgf("failed to parse file for remediation: %s", diags.Error())
+		return nil, false
+	}
+
+	body, ok := file.Body.(*hclsyntax.Body)
+
+	return body, ok
+}
+
+// hclReplacementTarget returns the value of the attribute named after the search key, or otherwise the one
+// defined in the line, looking into the innermost block of the line and the objects of its attributes
+func hclReplacementTarget(body *hclsyntax.Body, line int, name string) hclsyntax.Expression {
+	if block := hclInnermostBlock(body, line); block != nil {
+		body = block.Body
+	}
+
+	if attr, ok := body.Attributes[name]; ok && hclContainsLine(attr.SrcRange, line) {
+		return attr.Expr
+	}
+
+	for _, attr := range body.Attributes {
+		if hclContainsLine(attr.SrcRange, line) {
+			return hclObjectItemTarget(attr.Expr, line, name)
+		}
+	}
+
+	return nil
+}
+
+// hclObjectItemTarget returns the value of the object item defined in the line, if expr is an object
+func hclObjectItemTarget(expr hclsyntax.Expression, line int, name string) hclsyntax.Expression {
+	object, ok := expr.(*hclsyntax.ObjectConsExpr)
+	if !ok {
+		return expr
+	}
+
+	var target hclsyntax.Expression
+	for _, item := range object.Items {
+		if !hclContainsLine(hcl.RangeBetween(item.KeyExpr.Range(), item.ValueExpr.Range()), line) {
+			continue
+		}
+
+		if target == nil || hclKeyName(item.KeyExpr) == name {
+			target = hclObjectItemTarget(item.ValueExpr, line, name)
+		}
+	}
+
+	if target == nil {
+		return expr
+	}
+
+	return target
+}
+
+// hclAdditionTarget returns the innermost block of the line, or the object of the attribute defined in
+// the line when it spans several lines
+func hclAdditionTarget(body *hclsyntax.Body, line int) *hclContainer {
+	block := hclInnermostBlock(body, line)
+	if block == nil {
+		return nil
+	}
+
+	for _, attr := range block.Body.Attributes {
+		object, ok := attr.Expr.(*hclsyntax.ObjectConsExpr)
+		if ok && attr.SrcRange.Start.Line == line && object.SrcRange.End.Line > line {
+			container := &hclContainer{
+				names:     make(map[string]bool),
+				openBrace: object.OpenRange,
+				closeBrace: hcl.Range{
+					Filename: object.SrcRange.Filename,
+					Start: hcl.Pos{
+						Line:   object.SrcRange.End.Line,
+						Column: object.SrcRange.End.Column - 1,
+						Byte:   object.SrcRange.End.Byte - 1,
+					},
+					End: object.SrcRange.End,
+				},
+			}
+			for _, item := range object.Items {
+				container.names[hclKeyName(item.KeyExpr)] = true
+			}
+
+			return container
+		}
+	}
+
+	container := &hclContainer{
+		names:      make(map[string]bool),
+		openBrace:  block.OpenBraceRange,
+		closeBrace: block.CloseBraceRange,
+	}
+	for name := range block.Body.Attributes {
+		container.names[name] = true
+	}
+	for _, nested := range block.Body.Blocks {
+		container.names[nested.Type] = true
+	}
+
+	return container
+}
+
+func hclInnermostBlock(body *hclsyntax.Body, line int) *hclsyntax.Block {
+	for _, block := range body.Blocks {
+		if !hclContainsLine(block.Range(), line) {
+			continue
+		}
+
+		if nested := hclInnermostBlock(block.Body, line); nested != nil {
+			return nested
+		}
+
+		return block
+	}
+
+	return nil
+}
+
+func hclKeyName(expr hclsyntax.Expression) string {
+	value, diags := expr.Value(nil)
+	if diags.HasErrors() || !value.IsKnown() || value.IsNull() || value.Type() != cty.String {
+		return ""
+	}
+
+	return value.AsString()
+}
+
+func hclContainsLine(rng hcl.Range, line int) bool {
+	return rng.Start.Line <= line && line <= rng.End.Line
+}
@@ -0,0 +1,215 @@
+package fix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_hclFixer(t *testing.T) {
+	content := `resource "aws_s3_bucket" "b" {
+	bucket = "false-bucket"
+	acl    = "private" # keep this comment
+
+	tags = {
+		Name    = "My bucket"
+		Public  = false
+	}
+
+	logging {
+		target_bucket = "logs"
+	}
+}
+
+resource "aws_ebs_volume" "v" { size = 40 }
+`
+
+	tests := []struct {
+		name        string
+		remediation Remediation
+		addition    bool
+		want        string
+	}{
+		{
+			name: "replace the value of the attribute identified by the search key",
+			remediation: Remediation{
+				Line:        7,
+				Remediation: `{"before":"false","after":"true"}`,
+				SearchKey:   "aws_s3_bucket[b].tags.Public",
+			},
+			want: `resource "aws_s3_bucket" "b" {
+	bucket = "false-bucket"
+	acl    = "private" # keep this comment
+
+	tags = {
+		Name    = "My bucket"
+		Public  = true
+	}
+
+	logging {
+		target_bucket = "logs"
+	}
+}
+
+resource "aws_ebs_volume" "v" { size = 40 }
+`,
+		},
+		{
+			name: "replace only inside the value of the attribute",
+			remediation: Remediation{
+				Line:        3,
+				Remediation: `{"before":"private","after":"log-delivery-write"}`,
+				SearchKey:   "aws_s3_bucket[b].acl",
+			},
+			want: `resource "aws_s3_bucket" "b" {
+	bucket = "false-bucket"
+	acl    = "log-delivery-write" # keep this comment
+
+	tags = {
+		Name    = "My bucket"
+		Public  = false
+	}
+
+	logging {
+		target_bucket = "logs"
+	}
+}
+
+resource "aws_ebs_volume" "v" { size = 40 }
+`,
+		},
+		{
+			name: "add a block at the end of the block with the indentation of the file",
+			remediation: Remediation{
+				Line:        1,
+				Remediation: "versioning {\n\t\tenabled = true\n\t}",
+				SearchKey:   "aws_s3_bucket[b]",
+			},
+			addition: true,
+			want: `resource "aws_s3_bucket" "b" {
+	bucket = "false-bucket"
+	acl    = "private" # keep this comment
+
+	tags = {
+		Name    = "My bucket"
+		Public  = false
+	}
+
+	logging {
+		target_bucket = "logs"
+	}
+	versioning {
+		enabled = true
+	}
+}
+
+resource "aws_ebs_volume" "v" { size = 40 }
+`,
+		},
+		{
+			name: "add an attribute to a nested block",
+			remediation: Remediation{
+				Line:        10,
+				Remediation: `target_prefix = "log/"`,
+				SearchKey:   "aws_s3_bucket[b].logging",
+			},
+			addition: true,
+			want: `resource "aws_s3_bucket" "b" {
+	bucket = "false-bucket"
+	acl    = "private" # keep this comment
+
+	tags = {
+		Name    = "My bucket"
+		Public  = false
+	}
+
+	logging {
+		target_bucket = "logs"
+		target_prefix = "log/"
+	}
+}
+
+resource "aws_ebs_volume" "v" { size = 40 }
+`,
+		},
+		{
+			name: "add an item to an object",
+			remediation: Remediation{
+				Line:        5,
+				Remediation: `Owner = "team"`,
+				SearchKey:   "aws_s3_bucket[b].tags",
+			},
+			addition: true,
+			want: `resource "aws_s3_bucket" "b" {
+	bucket = "false-bucket"
+	acl    = "private" # keep this comment
+
+	tags = {
+		Name    = "My bucket"
+		Public  = false
+		Owner = "team"
+	}
+
+	logging {
+		target_bucket = "logs"
+	}
+}
+
+resource "aws_ebs_volume" "v" { size = 40 }
+`,
+		},
+		{
+			name: "add an attribute to a block defined in a single line",
+			remediation: Remediation{
+				Line:        15,
+				Remediation: "encrypted = true",
+				SearchKey:   "aws_ebs_volume[v]",
+			},
+			addition: true,
+			want: `resource "aws_s3_bucket" "b" {
+	bucket = "false-bucket"
+	acl    = "private" # keep this comment
+
+	tags = {
+		Name    = "My bucket"
+		Public  = false
+	}
+
+	logging {
+		target_bucket = "logs"
+	}
+}
+
+resource "aws_ebs_volume" "v" {
+	size = 40
+	encrypted = true
+}
+`,
+		},
+		{
+			name: "skip an addition already done",
+			remediation: Remediation{
+				Line:        10,
+				Remediation: `target_bucket = "other"`,
+				SearchKey:   "aws_s3_bucket[b].logging",
+			},
+			addition: true,
+			want:     "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			edit, ok := hclFixer{}.replacement(&tt.remediation, content)
+			if tt.addition {
+				edit, ok = hclFixer{}.addition(&tt.remediation, content)
+			}
+
+			got := ""
+			if ok {
+				got = edit.Apply(content)
+			}
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
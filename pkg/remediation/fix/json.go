@@ -0,0 +1,314 @@
+package fix
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+// jsonFixer locates the remediations of JSON files, finding the values to change in the decoded document and adding
+// the new members after the existing ones, so the order of the keys and the formatting of the file are kept
+type jsonFixer struct{}
+
+// jsonValue is a value of a JSON document along with its offsets in the content
+type jsonValue struct {
+	start    int
+	end      int
+	object   bool
+	array    bool
+	members  []jsonMember
+	elements []*jsonValue
+}
+
+// jsonMember is a member of a JSON object
+type jsonMember struct {
+	key      string
+	keyStart int
+	value    *jsonValue
+}
+
+func (jsonFixer) replacement(r *Remediation, content string) (Edit, bool) {
+	replacement, ok := getReplacementInfo(r)
+	if !ok {
+		return Edit{}, false
+	}
+
+	document, ok := parseJSON(content)
+	if !ok {
+		return lineFixer{}.replacement(r, content)
+	}
+
+	value := jsonReplacementTarget(document, lineStarts(content), r.Line, searchKeyAttribute(r.SearchKey))
+	if value == nil {
+		return lineFixer{}.replacement(r, content)
+	}
+
+	return replaceValue(r, replacement, content[value.start:value.end], value.start)
+}
+
+func (jsonFixer) addition(r *Remediation, content string) (Edit, bool) {
+	document, ok := parseJSON(content)
+	if !ok {
+		return lineFixer{}.addition(r, content)
+	}
+
+	starts := lineStarts(content)
+	object := jsonAdditionTarget(document, starts, r.Line, searchKeyAttribute(r.SearchKey))
+	if object == nil {
+		return lineFixer{}.addition(r, content)
+	}
+
+	snippet := strings.TrimSpace(r.Remediation)
+	if !strings.HasPrefix(snippet, "{") {
+		snippet = "{" + snippet + "}"
+	}
+
+	addition, ok := parseJSON(snippet)
+	if !ok || !addition.object {
+		log.Error().Msgf("failed to parse remediation '%s': it is not a JSON object", r.SimilarityID)
+		return Edit{}, false
+	}
+
+	object, addition = jsonNestedAddition(object, addition)
+
+	for _, member := range addition.members {
+		if jsonObjectValue(object, member.key) != nil {
+			log.Info().Msgf("remediation '%s' is already done", r.SimilarityID)
+			return Edit{}, false
+		}
+	}
+
+	lines := strings.Split(content, "\n")
+	unit := indentationUnit(lines)
+	indentation := lineIndentation(lines[lineOf(starts, object.start)-1])
+
+	if len(object.members) == 0 {
+		members := make([]string, 0, len(addition.members))
+		for _, member := range addition.members {
+			members = append(members, indentation+unit+jsonMemberText(snippet, member, indentation+unit, unit))
+		}
+
+		return Edit{
+			Start: object.start,
+			End:   object.end,
+			Text:  "{\n" + strings.Join(members, ",\n") + "\n" + indentation + "}",
+		}, true
+	}
+
+	last := object.members[len(object.members)-1]
+
+	var text strings.Builder
+	if lineOf(starts, last.keyStart) == lineOf(starts, object.start) {
+		// the object is defined in a single line, so are the new members
+		for _, member := range addition.members {
+			text.WriteString(", " + jsonMemberText(snippet, member, "", ""))
+		}
+	} else {
+		memberIndentation := lineIndentation(lines[lineOf(starts, last.keyStart)-1])
+		for _, member := range addition.members {
+			text.WriteString(",\n" + memberIndentation + jsonMemberText(snippet, member, memberIndentation, unit))
+		}
+	}
+
+	return Edit{Start: last.value.end, End: last.value.end, Text: text.String()}, true
+}
+
+func parseJSON(content string) (*jsonValue, bool) {
+	decoder := json.NewDecoder(strings.NewReader(content))
+	decoder.UseNumber()
+
+	document, err := parseJSONValue(decoder, content)
+	if err != nil {
+		log.Debug().Msgf("failed to parse file for remediation: %s", err)
+		return nil, false
+	}
+
+	return document, true
+}
+
+func parseJSONValue(decoder *json.Decoder, content string) (*jsonValue, error) {
+	value := &jsonValue{
+		start: skipJSONSeparators(content, int(decoder.InputOffset())),
+	}
+
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch token {
+	case json.Delim('{'):
+		value.object = true
+		for decoder.More() {
+			keyStart := skipJSONSeparators(content, int(decoder.InputOffset()))
+			key, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+
+			member, err := parseJSONValue(decoder, content)
+			if err != nil {
+				return nil, err
+			}
+
+			value.members = append(value.members, jsonMember{key: key.(string), keyStart: keyStart, value: member})
+		}
+	case json.Delim('['):
+		value.array = true
+		for decoder.More() {
+			element, err := parseJSONValue(decoder, content)
+			if err != nil {
+				return nil, err
+			}
+
+			value.elements = append(value.elements, element)
+		}
+	default:
+		value.end = int(decoder.InputOffset())
+		return value, nil
+	}
+
+	// closing delimiter
+	if _, err := decoder.Token(); err != nil {
+		return nil, err
+	}
+	value.end = int(decoder.InputOffset())
+
+	return value, nil
+}
+
+func skipJSONSeparators(content string, offset int) int {
+	for offset < len(content) && strings.ContainsRune(" \t\r\n,:", rune(content[offset])) {
+		offset++
+	}
+
+	return offset
+}
+
+func jsonWalk(value *jsonValue, visit func(*jsonValue)) {
+	visit(value)
+	for _, member := range value.members {
+		jsonWalk(member.value, visit)
+	}
+	for _, element := range value.elements {
+		jsonWalk(element, visit)
+	}
+}
+
+func (v *jsonValue) isScalar() bool {
+	return !v.object && !v.array
+}
+
+// jsonReplacementTarget returns the scalar value of the key named after the search key, or otherwise of any key
+// defined in the line, falling back to the scalar elements of the arrays in the line
+func jsonReplacementTarget(document *jsonValue, starts []int, line int, name string) *jsonValue {
+	var target, element *jsonValue
+
+	jsonWalk(document, func(value *jsonValue) {
+		for _, member := range value.members {
+			if lineOf(starts, member.keyStart) != line || !member.value.isScalar() {
+				continue
+			}
+			if target == nil || member.key == name {
+				target = member.value
+			}
+		}
+
+		for _, e := range value.elements {
+			if element == nil && e.isScalar() && lineOf(starts, e.start) == line {
+				element = e
+			}
+		}
+	})
+
+	if target == nil {
+		return element
+	}
+
+	return target
+}
+
+// jsonAdditionTarget returns the object of the key named after the search key, or otherwise of any key defined
+// in the line, falling back to the innermost object starting in the line and then to the object of the scalar
+// members defined in the line
+func jsonAdditionTarget(document *jsonValue, starts []int, line int, name string) *jsonValue {
+	var target, innermost, parent *jsonValue
+	parentKey := ""
+
+	jsonWalk(document, func(value *jsonValue) {
+		if value.object && lineOf(starts, value.start) == line {
+			innermost = value
+		}
+
+		for _, member := range value.members {
+			if lineOf(starts, member.keyStart) != line {
+				continue
+			}
+			if member.value.isScalar() && (parent == nil || (member.key == name && parentKey != name)) {
+				parent, parentKey = value, member.key
+			}
+			if member.value.object && (target == nil || member.key == name) {
+				target = member.value
+			}
+		}
+	})
+
+	switch {
+	case target != nil:
+		return target
+	case innermost != nil:
+		return innermost
+	default:
+		return parent
+	}
+}
+
+// jsonNestedAddition descends into the objects of the file already defining the keys of the addition, so that only
+// the missing members are added
+func jsonNestedAddition(object, addition *jsonValue) (target, remaining *jsonValue) {
+	for len(addition.members) == 1 && addition.members[0].value.object {
+		value := jsonObjectValue(object, addition.members[0].key)
+		if value == nil || !value.object {
+			break
+		}
+
+		object, addition = value, addition.members[0].value
+	}
+
+	return object, addition
+}
+
+func jsonObjectValue(object *jsonValue, key string) *jsonValue {
+	for _, member := range object.members {
+		if member.key == key {
+			return member.value
+		}
+	}
+
+	return nil
+}
+
+// jsonMemberText returns the text of the member of the snippet, indenting its value with the unit given
+// or compacting it when there is no unit
+func jsonMemberText(snippet string, member jsonMember, indentation, unit string) string {
+	var key bytes.Buffer
+	encoder := json.NewEncoder(&key)
+	encoder.SetEscapeHTML(false)
+	_ = encoder.Encode(member.key)
+
+	var value bytes.Buffer
+	raw := []byte(snippet[member.value.start:member.value.end])
+
+	err := json.Compact(&value, raw)
+	if unit != "" {
+		value.Reset()
+		err = json.Indent(&value, raw, indentation, unit)
+	}
+	if err != nil {
+		return strings.TrimSpace(key.String()) + ": " + string(raw)
+	}
+
+	return strings.TrimSpace(key.String()) + ": " + value.String()
+}
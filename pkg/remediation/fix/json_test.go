@@ -0,0 +1,245 @@
+package fix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_jsonFixer(t *testing.T) {
+	content := `{
+    "Resources": {
+        "Bucket": {
+            "Type": "AWS::S3::Bucket",
+            "Properties": {
+                "BucketName": "false-bucket",
+                "VersioningConfiguration": {
+                    "Status": "Suspended"
+                },
+                "Tags": {}
+            }
+        },
+        "Volume": { "Type": "AWS::EC2::Volume", "Properties": { "Encrypted": false } }
+    }
+}
+`
+
+	tests := []struct {
+		name        string
+		remediation Remediation
+		addition    bool
+		want        string
+	}{
+		{
+			name: "replace the value of the key identified by the search key",
+			remediation: Remediation{
+				Line:        8,
+				Remediation: `{"before":"Suspended","after":"Enabled"}`,
+				SearchKey:   "Resources.Bucket.Properties.VersioningConfiguration.Status",
+			},
+			want: `{
+    "Resources": {
+        "Bucket": {
+            "Type": "AWS::S3::Bucket",
+            "Properties": {
+                "BucketName": "false-bucket",
+                "VersioningConfiguration": {
+                    "Status": "Enabled"
+                },
+                "Tags": {}
+            }
+        },
+        "Volume": { "Type": "AWS::EC2::Volume", "Properties": { "Encrypted": false } }
+    }
+}
+`,
+		},
+		{
+			name: "replace the value of the key identified by the search key in a single line",
+			remediation: Remediation{
+				Line:        13,
+				Remediation: `{"before":"false","after":"true"}`,
+				SearchKey:   "Resources.Volume.Properties.Encrypted",
+			},
+			want: `{
+    "Resources": {
+        "Bucket": {
+            "Type": "AWS::S3::Bucket",
+            "Properties": {
+                "BucketName": "false-bucket",
+                "VersioningConfiguration": {
+                    "Status": "Suspended"
+                },
+                "Tags": {}
+            }
+        },
+        "Volume": { "Type": "AWS::EC2::Volume", "Properties": { "Encrypted": true } }
+    }
+}
+`,
+		},
+		{
+			name: "add a member after the last one keeping the order of the keys",
+			remediation: Remediation{
+				Line:        5,
+				Remediation: `"BucketEncryption": {"ServerSideEncryptionConfiguration": [{"ServerSideEncryptionByDefault": {"SSEAlgorithm": "AES256"}}]}`,
+				SearchKey:   "Resources.Bucket.Properties",
+			},
+			addition: true,
+			want: `{
+    "Resources": {
+        "Bucket": {
+            "Type": "AWS::S3::Bucket",
+            "Properties": {
+                "BucketName": "false-bucket",
+                "VersioningConfiguration": {
+                    "Status": "Suspended"
+                },
+                "Tags": {},
+                "BucketEncryption": {
+                    "ServerSideEncryptionConfiguration": [
+                        {
+                            "ServerSideEncryptionByDefault": {
+                                "SSEAlgorithm": "AES256"
+                            }
+                        }
+                    ]
+                }
+            }
+        },
+        "Volume": { "Type": "AWS::EC2::Volume", "Properties": { "Encrypted": false } }
+    }
+}
+`,
+		},
+		{
+			name: "add a member to an empty object",
+			remediation: Remediation{
+				Line:        10,
+				Remediation: `{"Owner": "team"}`,
+				SearchKey:   "Resources.Bucket.Properties.Tags",
+			},
+			addition: true,
+			want: `{
+    "Resources": {
+        "Bucket": {
+            "Type": "AWS::S3::Bucket",
+            "Properties": {
+                "BucketName": "false-bucket",
+                "VersioningConfiguration": {
+                    "Status": "Suspended"
+                },
+                "Tags": {
+                    "Owner": "team"
+                }
+            }
+        },
+        "Volume": { "Type": "AWS::EC2::Volume", "Properties": { "Encrypted": false } }
+    }
+}
+`,
+		},
+		{
+			name: "add a member to an object defined in a single line",
+			remediation: Remediation{
+				Line:        13,
+				Remediation: `"KmsKeyId": {"Ref": "Key"}`,
+				SearchKey:   "Resources.Volume.Properties",
+			},
+			addition: true,
+			want: `{
+    "Resources": {
+        "Bucket": {
+            "Type": "AWS::S3::Bucket",
+            "Properties": {
+                "BucketName": "false-bucket",
+                "VersioningConfiguration": {
+                    "Status": "Suspended"
+                },
+                "Tags": {}
+            }
+        },
+        "Volume": { "Type": "AWS::EC2::Volume", "Properties": { "Encrypted": false, "KmsKeyId": {"Ref":"Key"} } }
+    }
+}
+`,
+		},
+		{
+			name: "add a member to the object of the scalar member in the line",
+			remediation: Remediation{
+				Line:        6,
+				Remediation: `{"AccessControl": "Private"}`,
+				SearchKey:   "Resources.Bucket.Properties.BucketName",
+			},
+			addition: true,
+			want: `{
+    "Resources": {
+        "Bucket": {
+            "Type": "AWS::S3::Bucket",
+            "Properties": {
+                "BucketName": "false-bucket",
+                "VersioningConfiguration": {
+                    "Status": "Suspended"
+                },
+                "Tags": {},
+                "AccessControl": "Private"
+            }
+        },
+        "Volume": { "Type": "AWS::EC2::Volume", "Properties": { "Encrypted": false } }
+    }
+}
+`,
+		},
+		{
+			name: "add a member descending into the objects already defined",
+			remediation: Remediation{
+				Line:        5,
+				Remediation: `{"VersioningConfiguration": {"MFADelete": "Disabled"}}`,
+				SearchKey:   "Resources.Bucket.Properties",
+			},
+			addition: true,
+			want: `{
+    "Resources": {
+        "Bucket": {
+            "Type": "AWS::S3::Bucket",
+            "Properties": {
+                "BucketName": "false-bucket",
+                "VersioningConfiguration": {
+                    "Status": "Suspended",
+                    "MFADelete": "Disabled"
+                },
+                "Tags": {}
+            }
+        },
+        "Volume": { "Type": "AWS::EC2::Volume", "Properties": { "Encrypted": false } }
+    }
+}
+`,
+		},
+		{
+			name: "skip an addition already done",
+			remediation: Remediation{
+				Line:        5,
+				Remediation: `"BucketName": "bucket"`,
+				SearchKey:   "Resources.Bucket.Properties",
+			},
+			addition: true,
+			want:     "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			edit, ok := jsonFixer{}.replacement(&tt.remediation, content)
+			if tt.addition {
+				edit, ok = jsonFixer{}.addition(&tt.remediation, content)
+			}
+
+			got := ""
+			if ok {
+				got = edit.Apply(content)
+			}
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package fix
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+var searchKeyValueRegex = regexp.MustCompile(`{{.*?}}`)
+
+// searchKeyAttribute returns the name of the attribute identified by the last key of the search key
+func searchKeyAttribute(searchKey string) string {
+	keys := strings.Split(searchKeyValueRegex.ReplaceAllString(searchKey, "{{}}"), ".")
+	key := keys[len(keys)-1]
+
+	if i := strings.IndexAny(key, "=["); i >= 0 {
+		key = key[:i]
+	}
+
+	return key
+}
+
+// valueIndex returns the index of the first occurrence of the value in text that is not part of a longer value,
+// falling back to its first occurrence
+func valueIndex(text, value string) int {
+	if value == "" {
+		return -1
+	}
+
+	for i := strings.Index(text, value); i >= 0; {
+		end := i + len(value)
+		if (i == 0 || !isValueChar(text[i-1])) && (end == len(text) || !isValueChar(text[end])) {
+			return i
+		}
+
+		next := strings.Index(text[i+1:], value)
+		if next < 0 {
+			break
+		}
+		i += next + 1
+	}
+
+	return strings.Index(text, value)
+}
+
+func isValueChar(c byte) bool {
+	return c == '_' || c == '.' || c == '-' ||
+		(c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+// lineStarts returns the offset of the beginning of each line of the content
+func lineStarts(content string) []int {
+	starts := []int{0}
+	for i := 0; i < len(content); i++ {
+		if content[i] == '\n' {
+			starts = append(starts, i+1)
+		}
+	}
+
+	return starts
+}
+
+// lineOf returns the line, starting at 1, of the offset of the content
+func lineOf(starts []int, offset int) int {
+	line := 1
+	for line < len(starts) && starts[line] <= offset {
+		line++
+	}
+
+	return line
+}
+
+// offsetOf returns the offset of the column of the line, both starting at 1, counting the columns in runes
+func offsetOf(content string, starts []int, line, column int) int {
+	if line < 1 || line > len(starts) {
+		return -1
+	}
+
+	offset := starts[line-1]
+	for i := 1; i < column && offset < len(content) && content[offset] != '\n'; i++ {
+		_, size := utf8.DecodeRuneInString(content[offset:])
+		offset += size
+	}
+
+	return offset
+}
+
+// lineIndentation returns the leading whitespace of the line
+func lineIndentation(line string) string {
+	return line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+}
+
+// indentationUnit returns the indentation of a level used in the lines, defaulting to two spaces
+func indentationUnit(lines []string) string {
+	unit := ""
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		indentation := lineIndentation(line)
+		if strings.HasPrefix(indentation, "\t") {
+			return "\t"
+		}
+
+		if indentation != "" && (unit == "" || len(indentation) < len(unit)) {
+			unit = indentation
+		}
+	}
+
+	if unit == "" {
+		return "  "
+	}
+
+	return unit
+}
+
+// indentLines indents the lines of the text, which uses two spaces per level, with the indentation
+// and the unit of indentation given
+func indentLines(text, indentation, unit string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			lines[i] = ""
+			continue
+		}
+
+		trimmed := strings.TrimLeft(line, " ")
+		spaces := len(line) - len(trimmed)
+		lines[i] = indentation + strings.Repeat(unit, spaces/2) + strings.Repeat(" ", spaces%2) + trimmed
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// getBefore returns the indentation of the line, including the dashes of the YAML sequences
+func getBefore(line string) string {
+	re := regexp.MustCompile(`^[\s-]*`)
+	before := re.FindAll([]byte(line), -1)
+
+	return string(before[0])
+}
@@ -0,0 +1,370 @@
+package fix
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"gopkg.in/yaml.v3"
+)
+
+// yamlFixer locates the remediations of YAML files, finding the keys to change with yaml.Node and encoding the
+// added content with the indentation of the file, so the rest of the file, comments included, is kept as it is
+type yamlFixer struct{}
+
+func (yamlFixer) replacement(r *Remediation, content string) (Edit, bool) {
+	replacement, ok := getReplacementInfo(r)
+	if !ok {
+		return Edit{}, false
+	}
+
+	documents, ok := parseYAML(content)
+	if !ok {
+		return lineFixer{}.replacement(r, content)
+	}
+
+	node := yamlReplacementTarget(documents, r.Line, searchKeyAttribute(r.SearchKey))
+	if node == nil {
+		return lineFixer{}.replacement(r, content)
+	}
+
+	start, end := yamlScalarSpan(content, node)
+	if start < 0 {
+		return lineFixer{}.replacement(r, content)
+	}
+
+	return replaceValue(r, replacement, content[start:end], start)
+}
+
+func (yamlFixer) addition(r *Remediation, content string) (Edit, bool) {
+	documents, ok := parseYAML(content)
+	if !ok {
+		return lineFixer{}.addition(r, content)
+	}
+
+	key, mapping := yamlAdditionTarget(documents, r.Line, searchKeyAttribute(r.SearchKey))
+	if mapping == nil {
+		return lineFixer{}.addition(r, content)
+	}
+
+	snippet := &yaml.Node{}
+	if err := yaml.Unmarshal([]byte(r.Remediation), snippet); err != nil ||
+		len(snippet.Content) == 0 || snippet.Content[0].Kind != yaml.MappingNode {
+		log.Error().Msgf("failed to parse remediation '%s': it is not a YAML mapping", r.SimilarityID)
+		return Edit{}, false
+	}
+	addition := snippet.Content[0]
+	yamlBlockStyle(addition)
+
+	key, mapping, addition = yamlNestedAddition(key, mapping, addition)
+
+	for i := 0; i < len(addition.Content); i += 2 {
+		if yamlMappingValue(mapping, addition.Content[i].Value) != nil {
+			log.Info().Msgf("remediation '%s' is already done", r.SimilarityID)
+			return Edit{}, false
+		}
+	}
+
+	lines := strings.Split(content, "\n")
+	unit := indentationUnit(lines)
+	if unit == "\t" {
+		unit = "  "
+	}
+
+	switch {
+	case mapping.Kind == yaml.ScalarNode:
+		// the key has no value yet, so the remediation is added as its value
+		indentation := strings.Repeat(" ", key.Column-1) + unit
+		return insertLines(content, key.Line, indentLines(encodeYAML(addition, unit), indentation, "  "))
+	case mapping.Style&yaml.FlowStyle != 0:
+		return yamlFlowAddition(content, key, mapping, addition, unit)
+	default:
+		indentation := strings.Repeat(" ", mapping.Column-1)
+		return insertLines(content, yamlEndLine(lines, mapping), indentLines(encodeYAML(addition, unit), indentation, "  "))
+	}
+}
+
+func parseYAML(content string) ([]*yaml.Node, bool) {
+	decoder := yaml.NewDecoder(strings.NewReader(content))
+
+	var documents []*yaml.Node
+	for {
+		document := &yaml.Node{}
+		err := decoder.Decode(document)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			log.Debug().Msgf("failed to parse file for remediation: %s", err)
+			return nil, false
+		}
+
+		documents = append(documents, document)
+	}
+
+	return documents, true
+}
+
+func encodeYAML(node *yaml.Node, unit string) string {
+	var buf bytes.Buffer
+
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(len(unit))
+
+	if err := encoder.Encode(node); err != nil {
+		log.Error().Msgf("failed to encode remediation: %s", err)
+	}
+	_ = encoder.Close()
+
+	return strings.TrimRight(buf.String(), "\n")
+}
+
+// yamlReplacementTarget returns the scalar value of the key named after the search key, or otherwise of any key
+// defined in the line, falling back to the scalar items of the sequences in the line
+func yamlReplacementTarget(documents []*yaml.Node, line int, name string) *yaml.Node {
+	var target, item *yaml.Node
+
+	for _, document := range documents {
+		yamlWalk(document, func(node *yaml.Node) {
+			switch node.Kind {
+			case yaml.MappingNode:
+				for i := 0; i+1 < len(node.Content); i += 2 {
+					key, value := node.Content[i], node.Content[i+1]
+					if key.Line != line || value.Kind != yaml.ScalarNode {
+						continue
+					}
+					if target == nil || key.Value == name {
+						target = value
+					}
+				}
+			case yaml.SequenceNode:
+				for _, element := range node.Content {
+					if element.Kind == yaml.ScalarNode && element.Line == line && item == nil {
+						item = element
+					}
+				}
+			}
+		})
+	}
+
+	if target == nil {
+		return item
+	}
+
+	return target
+}
+
+// yamlAdditionTarget returns the key named after the search key, or otherwise any key defined in the line, along
+// with its mapping or its empty value, falling back to the innermost mapping starting in the line and then to the
+// mapping of the scalar keys defined in the line
+func yamlAdditionTarget(documents []*yaml.Node, line int, name string) (key, mapping *yaml.Node) {
+	var innermost, parent *yaml.Node
+	parentKey := ""
+
+	for _, document := range documents {
+		yamlWalk(document, func(node *yaml.Node) {
+			if node.Kind != yaml.MappingNode {
+				return
+			}
+
+			if node.Line == line {
+				innermost = node
+			}
+
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				k, value := node.Content[i], node.Content[i+1]
+				if k.Line != line {
+					continue
+				}
+
+				if value.Kind == yaml.ScalarNode && !isYAMLNull(value) && (parent == nil || (k.Value == name && parentKey != name)) {
+					parent, parentKey = node, k.Value
+				}
+
+				if key != nil && k.Value != name {
+					continue
+				}
+
+				if value.Kind == yaml.MappingNode || isYAMLNull(value) {
+					key, mapping = k, value
+				}
+			}
+		})
+	}
+
+	switch {
+	case key != nil:
+		return key, mapping
+	case innermost != nil:
+		return nil, innermost
+	default:
+		return nil, parent
+	}
+}
+
+// yamlNestedAddition descends into the mappings of the file already defining the keys of the addition, so that only
+// the missing keys are added
+func yamlNestedAddition(key, mapping, addition *yaml.Node) (targetKey, target, remaining *yaml.Node) {
+	for mapping.Kind == yaml.MappingNode && len(addition.Content) == 2 && addition.Content[1].Kind == yaml.MappingNode {
+		k, value := yamlMappingEntry(mapping, addition.Content[0].Value)
+		if k == nil || (value.Kind != yaml.MappingNode && !isYAMLNull(value)) {
+			break
+		}
+
+		key, mapping, addition = k, value, addition.Content[1]
+	}
+
+	return key, mapping, addition
+}
+
+// yamlBlockStyle clears the styles of the nodes, so they are encoded in block style, quoting only the values
+// that need it
+func yamlBlockStyle(node *yaml.Node) {
+	yamlWalk(node, func(n *yaml.Node) {
+		n.Style &^= yaml.FlowStyle | yaml.DoubleQuotedStyle | yaml.SingleQuotedStyle
+	})
+}
+
+func isYAMLNull(node *yaml.Node) bool {
+	return node.Kind == yaml.ScalarNode && node.Tag == "!!null" && node.Value == ""
+}
+
+func yamlWalk(node *yaml.Node, visit func(*yaml.Node)) {
+	visit(node)
+	for _, child := range node.Content {
+		yamlWalk(child, visit)
+	}
+}
+
+func yamlMappingValue(mapping *yaml.Node, name string) *yaml.Node {
+	_, value := yamlMappingEntry(mapping, name)
+	return value
+}
+
+func yamlMappingEntry(mapping *yaml.Node, name string) (key, value *yaml.Node) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == name {
+			return mapping.Content[i], mapping.Content[i+1]
+		}
+	}
+
+	return nil, nil
+}
+
+// yamlScalarSpan returns the offsets of the text of the scalar in the content
+func yamlScalarSpan(content string, node *yaml.Node) (start, end int) {
+	starts := lineStarts(content)
+	start = offsetOf(content, starts, node.Line, node.Column)
+	if start < 0 {
+		return -1, -1
+	}
+
+	switch node.Style {
+	case yaml.DoubleQuotedStyle, yaml.SingleQuotedStyle:
+		quote := content[start]
+		if quote != '"' && quote != '\'' {
+			return -1, -1
+		}
+		for i := start + 1; i < len(content); i++ {
+			switch {
+			case content[i] == '\\' && quote == '"':
+				i++
+			case content[i] == quote && quote == '\'' && i+1 < len(content) && content[i+1] == '\'':
+				i++
+			case content[i] == quote:
+				return start, i + 1
+			}
+		}
+	case yaml.LiteralStyle, yaml.FoldedStyle:
+		lines := strings.Split(content, "\n")
+		last := yamlBlockEnd(lines, node.Line, len(lineIndentation(lines[node.Line-1])))
+		return start, starts[last-1] + len(lines[last-1])
+	default:
+		if !strings.Contains(node.Value, "\n") && strings.HasPrefix(content[start:], node.Value) {
+			return start, start + len(node.Value)
+		}
+	}
+
+	return -1, -1
+}
+
+// yamlEndLine returns the last line of the mapping
+func yamlEndLine(lines []string, mapping *yaml.Node) int {
+	last := mapping.Line
+	yamlWalk(mapping, func(node *yaml.Node) {
+		if node.Line > last {
+			last = node.Line
+		}
+	})
+
+	return yamlBlockEnd(lines, last, mapping.Column-1)
+}
+
+// yamlBlockEnd returns the last line, starting from the line given, indented deeper than the indentation
+func yamlBlockEnd(lines []string, line, indentation int) int {
+	last := line
+	for last < len(lines) && (strings.TrimSpace(lines[last]) == "" || len(lineIndentation(lines[last])) > indentation) {
+		last++
+	}
+
+	for last > line && strings.TrimSpace(lines[last-1]) == "" {
+		last--
+	}
+
+	return last
+}
+
+// yamlFlowAddition adds the content to a flow mapping, turning it into a block mapping when it is empty
+func yamlFlowAddition(content string, key, mapping, addition *yaml.Node, unit string) (Edit, bool) {
+	starts := lineStarts(content)
+	start := offsetOf(content, starts, mapping.Line, mapping.Column)
+	end := flowEnd(content, start)
+	if start < 0 || end < 0 {
+		return Edit{}, false
+	}
+
+	if len(mapping.Content) > 0 || key == nil {
+		merged := *mapping
+		merged.Content = append(append([]*yaml.Node{}, mapping.Content...), addition.Content...)
+		return Edit{Start: start, End: end, Text: encodeYAML(&merged, unit)}, true
+	}
+
+	for start > 0 && content[start-1] == ' ' {
+		start--
+	}
+
+	indentation := strings.Repeat(" ", key.Column-1) + unit
+
+	return Edit{Start: start, End: end, Text: "\n" + indentLines(encodeYAML(addition, unit), indentation, "  ")}, true
+}
+
+// flowEnd returns the offset after the bracket closing the one at start, ignoring the ones in quotes
+func flowEnd(content string, start int) int {
+	depth := 0
+	var quote byte
+
+	for i := start; i >= 0 && i < len(content); i++ {
+		c := content[i]
+		switch {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '{' || c == '[':
+			depth++
+		case c == '}' || c == ']':
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		}
+	}
+
+	return -1
+}
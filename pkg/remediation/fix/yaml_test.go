@@ -0,0 +1,242 @@
+package fix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_yamlFixer(t *testing.T) {
+	content := `apiVersion: v1
+kind: Pod
+metadata:
+  name: "false-pod" # the name
+spec:
+  containers:
+    - name: web
+      image: nginx
+      securityContext:
+        privileged: true
+
+    - name: sidecar
+      image: busybox
+      securityContext: {}
+  volumes: []
+`
+
+	tests := []struct {
+		name        string
+		remediation Remediation
+		addition    bool
+		want        string
+	}{
+		{
+			name: "replace the value of the key identified by the search key",
+			remediation: Remediation{
+				Line:        10,
+				Remediation: `{"before":"true","after":"false"}`,
+				SearchKey:   "metadata.name={{false-pod}}.spec.containers.name={{web}}.securityContext.privileged",
+			},
+			want: `apiVersion: v1
+kind: Pod
+metadata:
+  name: "false-pod" # the name
+spec:
+  containers:
+    - name: web
+      image: nginx
+      securityContext:
+        privileged: false
+
+    - name: sidecar
+      image: busybox
+      securityContext: {}
+  volumes: []
+`,
+		},
+		{
+			name: "replace only inside a quoted value",
+			remediation: Remediation{
+				Line:        4,
+				Remediation: `{"before":"false","after":"true"}`,
+				SearchKey:   "metadata.name={{false-pod}}",
+			},
+			want: `apiVersion: v1
+kind: Pod
+metadata:
+  name: "true-pod" # the name
+spec:
+  containers:
+    - name: web
+      image: nginx
+      securityContext:
+        privileged: true
+
+    - name: sidecar
+      image: busybox
+      securityContext: {}
+  volumes: []
+`,
+		},
+		{
+			name: "add a key at the end of the mapping",
+			remediation: Remediation{
+				Line:        9,
+				Remediation: "allowPrivilegeEscalation: false\ncapabilities:\n  drop:\n    - ALL",
+				SearchKey:   "metadata.name={{false-pod}}.spec.containers.name={{web}}.securityContext",
+			},
+			addition: true,
+			want: `apiVersion: v1
+kind: Pod
+metadata:
+  name: "false-pod" # the name
+spec:
+  containers:
+    - name: web
+      image: nginx
+      securityContext:
+        privileged: true
+        allowPrivilegeEscalation: false
+        capabilities:
+          drop:
+            - ALL
+
+    - name: sidecar
+      image: busybox
+      securityContext: {}
+  volumes: []
+`,
+		},
+		{
+			name: "add a key to an item of a sequence",
+			remediation: Remediation{
+				Line:        7,
+				Remediation: "imagePullPolicy: Always",
+				SearchKey:   "metadata.name={{false-pod}}.spec.containers.name={{web}}",
+			},
+			addition: true,
+			want: `apiVersion: v1
+kind: Pod
+metadata:
+  name: "false-pod" # the name
+spec:
+  containers:
+    - name: web
+      image: nginx
+      securityContext:
+        privileged: true
+      imagePullPolicy: Always
+
+    - name: sidecar
+      image: busybox
+      securityContext: {}
+  volumes: []
+`,
+		},
+		{
+			name: "add a key to an empty flow mapping",
+			remediation: Remediation{
+				Line:        14,
+				Remediation: "readOnlyRootFilesystem: true",
+				SearchKey:   "metadata.name={{false-pod}}.spec.containers.name={{sidecar}}.securityContext",
+			},
+			addition: true,
+			want: `apiVersion: v1
+kind: Pod
+metadata:
+  name: "false-pod" # the name
+spec:
+  containers:
+    - name: web
+      image: nginx
+      securityContext:
+        privileged: true
+
+    - name: sidecar
+      image: busybox
+      securityContext:
+        readOnlyRootFilesystem: true
+  volumes: []
+`,
+		},
+		{
+			name: "add a JSON remediation in block style, descending into the mappings already defined",
+			remediation: Remediation{
+				Line:        7,
+				Remediation: `{"securityContext":{"runAsNonRoot":true,"seccompProfile":{"type":"RuntimeDefault"}}}`,
+				SearchKey:   "metadata.name={{false-pod}}.spec.containers.name={{web}}",
+			},
+			addition: true,
+			want: `apiVersion: v1
+kind: Pod
+metadata:
+  name: "false-pod" # the name
+spec:
+  containers:
+    - name: web
+      image: nginx
+      securityContext:
+        privileged: true
+        runAsNonRoot: true
+        seccompProfile:
+          type: RuntimeDefault
+
+    - name: sidecar
+      image: busybox
+      securityContext: {}
+  volumes: []
+`,
+		},
+		{
+			name: "add a key to the mapping of the scalar key in the line",
+			remediation: Remediation{
+				Line:        8,
+				Remediation: "imagePullPolicy: Always",
+				SearchKey:   "metadata.name={{false-pod}}.spec.containers.name={{web}}.image",
+			},
+			addition: true,
+			want: `apiVersion: v1
+kind: Pod
+metadata:
+  name: "false-pod" # the name
+spec:
+  containers:
+    - name: web
+      image: nginx
+      securityContext:
+        privileged: true
+      imagePullPolicy: Always
+
+    - name: sidecar
+      image: busybox
+      securityContext: {}
+  volumes: []
+`,
+		},
+		{
+			name: "skip an addition already done",
+			remediation: Remediation{
+				Line:        9,
+				Remediation: "privileged: false",
+				SearchKey:   "metadata.name={{false-pod}}.spec.containers.name={{web}}.securityContext",
+			},
+			addition: true,
+			want:     "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			edit, ok := yamlFixer{}.replacement(&tt.remediation, content)
+			if tt.addition {
+				edit, ok = yamlFixer{}.addition(&tt.remediation, content)
+			}
+
+			got := ""
+			if ok {
+				got = edit.Apply(content)
+			}
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package remediation
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	remediationFix "github.com/Checkmarx/kics/v2/pkg/remediation/fix"
+	"github.com/rs/zerolog/log"
+)
+
+// answer is the decision of the user about a remediation in the interactive mode
+type answer int
+
+const (
+	answerAccept answer = iota
+	answerSkip
+	answerAcceptQuery
+	answerQuit
+)
+
+// reviewItem is a remediation waiting for the decision of the user
+type reviewItem struct {
+	filePath        string
+	remediationType string
+	remediation     Remediation
+}
+
+// Review walks through the remediations of the sets, showing the query, the severity and the change of each one and
+// asking whether it should be accepted, skipped, accepted along with the other remediations of its query or whether
+// the review should stop, skipping the remaining remediations. It returns the sets of the accepted remediations
+func (s *Summary) Review(results Report, remediationSets map[string]interface{}, in io.Reader, out io.Writer) map[string]interface{} {
+	queries := make(map[string]Query, len(results.Queries))
+	for i := range results.Queries {
+		queries[results.Queries[i].QueryID] = results.Queries[i]
+	}
+
+	items := getReviewItems(remediationSets)
+	accepted := make(map[string]interface{})
+	acceptedQueries := make(map[string]bool)
+	reader := bufio.NewReader(in)
+	quit := false
+
+	for i, item := range items {
+		if quit {
+			s.skipRemediation()
+			continue
+		}
+
+		query := queries[item.remediation.QueryID]
+		fmt.Fprintf(out, "\n[%d/%d] %s (%s)\n%s:%d\n", i+1, len(items), query.QueryName, query.Severity,
+			item.filePath, item.remediation.Line)
+
+		if acceptedQueries[item.remediation.QueryID] {
+			fmt.Fprintln(out, "accepted with the other remediations of the query")
+			addToSet(accepted, item)
+			continue
+		}
+
+		change, ok := getRemediationChange(item)
+		if !ok {
+			fmt.Fprintln(out, "skipped: the remediation is already done or can not be located in the file")
+			s.skipRemediation()
+			continue
+		}
+		fmt.Fprint(out, change)
+
+		switch askAnswer(reader, out) {
+		case answerAccept:
+			addToSet(accepted, item)
+		case answerAcceptQuery:
+			acceptedQueries[item.remediation.QueryID] = true
+			addToSet(accepted, item)
+		case answerSkip:
+			s.skipRemediation()
+		case answerQuit:
+			quit = true
+			s.skipRemediation()
+		}
+	}
+
+	return accepted
+}
+
+// skipRemediation removes a remediation skipped by the user from the selected ones
+func (s *Summary) skipRemediation() {
+	s.SelectedRemediationNumber--
+	s.SkippedRemediationNumber++
+}
+
+// getReviewItems returns the remediations of the sets sorted by file, with the replacements of each file first
+// and then its additions, both sorted by line
+func getReviewItems(remediationSets map[string]interface{}) []reviewItem {
+	filePaths := make([]string, 0, len(remediationSets))
+	for filePath := range remediationSets {
+		filePaths = append(filePaths, filePath)
+	}
+	sort.Strings(filePaths)
+
+	var items []reviewItem
+	for _, filePath := range filePaths {
+		set := remediationSets[filePath].(Set)
+		items = append(items, sortReviewItems(filePath, remediationFix.TypeReplacement, set.Replacement)...)
+		items = append(items, sortReviewItems(filePath, remediationFix.TypeAddition, set.Addition)...)
+	}
+
+	return items
+}
+
+func sortReviewItems(filePath, remediationType string, remediations []Remediation) []reviewItem {
+	items := make([]reviewItem, 0, len(remediations))
+	for i := range remediations {
+		items = append(items, reviewItem{
+			filePath:        filePath,
+			remediationType: remediationType,
+			remediation:     remediations[i],
+		})
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].remediation.Line < items[j].remediation.Line
+	})
+
+	return items
+}
+
+// getRemediationChange returns the change of the remediation on the current content of its file as unified diff hunks
+func getRemediationChange(item reviewItem) (string, bool) {
+	content, err := os.ReadFile(filepath.Clean(item.filePath))
+	if err != nil {
+		log.Error().Msgf("failed to read file: %s", err)
+		return "", false
+	}
+
+	remediated := applyFix(item.filePath, string(content), item.remediationType, &item.remediation)
+	if remediated == "" || remediated == string(content) {
+		return "", false
+	}
+
+	return diffHunks(string(content), remediated), true
+}
+
+// askAnswer prompts the user until a valid answer is given, quitting when the input ends
+func askAnswer(reader *bufio.Reader, out io.Writer) answer {
+	for {
+		fmt.Fprint(out, "Apply this remediation? [y]es, [s]kip, [a]ll for this query, [q]uit: ")
+
+		line, err := reader.ReadString('\n')
+		switch strings.ToLower(strings.TrimSpace(line)) {
+		case "y", "yes":
+			return answerAccept
+		case "s", "skip", "n", "no":
+			return answerSkip
+		case "a", "all":
+			return answerAcceptQuery
+		case "q", "quit":
+			return answerQuit
+		}
+
+		if err != nil {
+			fmt.Fprintln(out)
+			return answerQuit
+		}
+	}
+}
+
+func addToSet(remediationSets map[string]interface{}, item reviewItem) {
+	set, _ := remediationSets[item.filePath].(Set)
+
+	if item.remediationType == remediationFix.TypeReplacement {
+		set.Replacement = append(set.Replacement, item.remediation)
+	} else {
+		set.Addition = append(set.Addition, item.remediation)
+	}
+
+	remediationSets[item.filePath] = set
+}
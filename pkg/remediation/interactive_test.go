@@ -0,0 +1,113 @@
+package remediation
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Review(t *testing.T) {
+	filePath := filepath.Join("..", "..", "test", "fixtures", "kics_auto_remediation", "terraform.tf")
+
+	symbols := Remediation{
+		Line:         5,
+		Remediation:  "{\"after\":\"true\",\"before\":\"false\"}",
+		SimilarityID: "87abbee5d0ec977ba193371c702dca2c040ea902d2e606806a63b66119ff89bc",
+		QueryID:      "41a38329-d81b-4be4-aef4-55b2615d3282",
+	}
+	numbers := Remediation{
+		Line:         4,
+		Remediation:  "{\"after\":\"true\",\"before\":\"false\"}",
+		SimilarityID: "2c6d4a0d4ac1b1a2e9c3d0b4b1fb9cf0cba44b53a2d8e0cd6b1c1e5a9e8b7d61",
+		QueryID:      "2bb13841-7575-439e-8e0a-cccd9ede2fa8",
+	}
+	length := Remediation{
+		Line:         1,
+		Remediation:  "minimum_password_length = 14",
+		SimilarityID: "f282fa13cf5e4ffd4bbb0ee2059f8d0240edcd2ca54b3bb71633145d961de5ce",
+		QueryID:      "2bb13841-7575-439e-8e0a-cccd9ede2fa8",
+	}
+
+	results := Report{
+		Queries: []Query{
+			{QueryID: symbols.QueryID, QueryName: "RAM Account Password Policy Not Required Symbols", Severity: "LOW"},
+			{QueryID: numbers.QueryID, QueryName: "RAM Account Password Policy Weak", Severity: "MEDIUM"},
+		},
+	}
+
+	remediationSets := map[string]interface{}{
+		filePath: Set{
+			Replacement: []Remediation{symbols, numbers},
+			Addition:    []Remediation{length},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		input    string
+		want     map[string]interface{}
+		selected int
+		skipped  int
+	}{
+		{
+			name:  "accept all the remediations",
+			input: "y\ny\nyes\n",
+			want: map[string]interface{}{
+				filePath: Set{Replacement: []Remediation{numbers, symbols}, Addition: []Remediation{length}},
+			},
+			selected: 3,
+		},
+		{
+			name:  "skip a remediation after an unknown answer",
+			input: "maybe\ns\ny\ny\n",
+			want: map[string]interface{}{
+				filePath: Set{Replacement: []Remediation{symbols}, Addition: []Remediation{length}},
+			},
+			selected: 2,
+			skipped:  1,
+		},
+		{
+			name:  "accept all the remediations of a query",
+			input: "a\nn\n",
+			want: map[string]interface{}{
+				filePath: Set{Replacement: []Remediation{numbers}, Addition: []Remediation{length}},
+			},
+			selected: 2,
+			skipped:  1,
+		},
+		{
+			name:     "quit skipping the remaining remediations",
+			input:    "q\n",
+			want:     map[string]interface{}{},
+			selected: 0,
+			skipped:  3,
+		},
+		{
+			name:  "quit when the input ends",
+			input: "y",
+			want: map[string]interface{}{
+				filePath: Set{Replacement: []Remediation{numbers}},
+			},
+			selected: 1,
+			skipped:  2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Summary{SelectedRemediationNumber: 3}
+			var out bytes.Buffer
+
+			got := s.Review(results, remediationSets, strings.NewReader(tt.input), &out)
+
+			require.Equal(t, tt.want, got)
+			require.Equal(t, tt.selected, s.SelectedRemediationNumber)
+			require.Equal(t, tt.skipped, s.SkippedRemediationNumber)
+			require.Contains(t, out.String(), "[1/3] RAM Account Password Policy Weak (MEDIUM)\n"+filePath+":4\n")
+			require.Contains(t, out.String(), "-  require_numbers              = false\n+  require_numbers              = true\n")
+		})
+	}
+}
@@ -1,12 +1,11 @@
 package remediation
 
 import (
-	"encoding/json"
 	"os"
 	"path/filepath"
 	"sort"
-	"strings"
 
+	remediationFix "github.com/Checkmarx/kics/v2/pkg/remediation/fix"
 	"github.com/rs/zerolog/log"
 )
 
@@ -17,8 +16,10 @@ type Report struct {
 
 // Query includes all the files that presents a result related to the queryID
 type Query struct {
-	Files   []File `json:"files"`
-	QueryID string `json:"query_id"`
+	Files     []File `json:"files"`
+	QueryID   string `json:"query_id"`
+	QueryName string `json:"query_name"`
+	Severity  string `json:"severity"`
 }
 
 // File presents the result information related to the file
@@ -52,23 +53,39 @@ type Set struct {
 
 // RemediateFile remediationSets the replacements first and secondly, the additions sorted down
 func (s *Summary) RemediateFile(filePath string, remediationSet Set, openAPIResolveReferences bool, maxResolverDepth int) error {
+	original, remediated, err := s.GetRemediatedContent(filePath, remediationSet, openAPIResolveReferences, maxResolverDepth)
+	if err != nil || original == remediated {
+		return err
+	}
+
+	return writeRemediation(filePath, remediated)
+}
+
+// GetRemediatedContent computes the content of the file with the replacements first and secondly, the additions
+// sorted down, keeping only the remediations that remove their results. The file is not changed
+func (s *Summary) GetRemediatedContent(
+	filePath string,
+	remediationSet Set,
+	openAPIResolveReferences bool,
+	maxResolverDepth int) (original, remediated string, err error) {
 	filepath.Clean(filePath)
 	content, err := os.ReadFile(filePath)
 
 	if err != nil {
 		log.Error().Msgf("failed to read file: %s", err)
-		return err
+		return "", "", err
 	}
 
-	lines := strings.Split(string(content), "\n")
+	original = string(content)
+	remediated = original
 
 	// do replacements first
 	if len(remediationSet.Replacement) > 0 {
 		for i := range remediationSet.Replacement {
 			r := remediationSet.Replacement[i]
-			remediatedLines := replacement(&r, lines)
-			if len(remediatedLines) > 0 && willRemediate(remediatedLines, filePath, &r, openAPIResolveReferences, maxResolverDepth) {
-				lines = s.writeRemediation(remediatedLines, lines, filePath, r.SimilarityID)
+			fixed := applyFix(filePath, remediated, remediationFix.TypeReplacement, &r)
+			if fixed != "" && willRemediate(fixed, filePath, &r, openAPIResolveReferences, maxResolverDepth) {
+				remediated = s.acceptRemediation(fixed, filePath, r.SimilarityID)
 			}
 		}
 	}
@@ -82,93 +99,53 @@ func (s *Summary) RemediateFile(filePath string, remediationSet Set, openAPIReso
 
 		for i := range remediationSet.Addition {
 			a := remediationSet.Addition[i]
-			remediatedLines := addition(&a, &lines)
-			if len(remediatedLines) > 0 && willRemediate(remediatedLines, filePath, &a, openAPIResolveReferences, maxResolverDepth) {
-				lines = s.writeRemediation(remediatedLines, lines, filePath, a.SimilarityID)
+			fixed := applyFix(filePath, remediated, remediationFix.TypeAddition, &a)
+			if fixed != "" && willRemediate(fixed, filePath, &a, openAPIResolveReferences, maxResolverDepth) {
+				remediated = s.acceptRemediation(fixed, filePath, a.SimilarityID)
 			}
 		}
 	}
 
-	return nil
-}
-
-// ReplacementInfo presents the relevant information to do the replacement
-type ReplacementInfo struct {
-	Before string `json:"before"`
-	After  string `json:"after"`
-}
-
-func replacement(r *Remediation, lines []string) []string {
-	originalLine := lines[r.Line-1]
-
-	var replacement ReplacementInfo
-	err := json.Unmarshal([]byte(r.Remediation), &replacement)
-
-	if err != nil || replacement == (ReplacementInfo{}) {
-		return []string{}
-	}
-
-	remediated := strings.Replace(lines[r.Line-1], replacement.Before, replacement.After, 1)
-
-	if originalLine == remediated {
-		log.Info().Msgf("remediation '%s' is already done", r.SimilarityID)
-		return []string{}
-	}
-
-	// replace the original line with remediation
-	lines[r.Line-1] = remediated
-
-	return lines
+	return original, remediated, nil
 }
 
-func addition(r *Remediation, lines *[]string) []string {
-	fatherNumberLine := r.Line - 1
-
-	if len(*lines) <= fatherNumberLine+1 {
-		return []string{}
+// applyFix locates the remediation in the content of the file and applies it, returning an empty string
+// when the remediation is not applied
+func applyFix(filePath, content, remediationType string, r *Remediation) string {
+	edit, ok := remediationFix.Locate(filePath, content, &remediationFix.Remediation{
+		Type:         remediationType,
+		Line:         r.Line,
+		Remediation:  r.Remediation,
+		SearchKey:    r.SearchKey,
+		SimilarityID: r.SimilarityID,
+	})
+	if !ok {
+		return ""
 	}
 
-	firstLine := strings.Split(r.Remediation, "\n")[0]
-
-	if strings.TrimSpace((*lines)[fatherNumberLine+1]) == strings.TrimSpace(firstLine) {
-		log.Info().Msgf("remediation '%s' is already done", r.SimilarityID)
-		return []string{}
-	}
-
-	begin := make([]string, len(*lines))
-	end := make([]string, len(*lines))
-
-	copy(begin, *lines)
-	copy(end, *lines)
-
-	begin = begin[:fatherNumberLine+1]
-	end = end[fatherNumberLine+1:]
-
-	before := getBefore((*lines)[fatherNumberLine+1])
-
-	remediation := begin
-	remediation = append(remediation, before+r.Remediation)
-	remediation = append(remediation, end...)
-
-	return remediation
+	return edit.Apply(content)
 }
 
 const (
 	FilePermMode = 0600 // File permissions mode with read and write only
 )
 
-func (s *Summary) writeRemediation(remediatedLines, lines []string, filePath, similarityID string) []string {
-	remediated := []byte(strings.Join(remediatedLines, "\n"))
+func (s *Summary) acceptRemediation(remediated, filePath, similarityID string) string {
+	log.Info().Msgf("remediation '%s' removes its result from file '%s'", similarityID, filePath)
+	s.ActualRemediationDoneNumber++
+
+	return remediated
+}
 
+func writeRemediation(filePath, remediated string) error {
 	mode := os.FileMode(FilePermMode)
 
-	if err := os.WriteFile(filePath, remediated, mode); err != nil {
+	if err := os.WriteFile(filePath, []byte(remediated), mode); err != nil {
 		log.Error().Msgf("failed to write file: %s", err)
-		return lines
+		return err
 	}
 
-	log.Info().Msgf("file '%s' was remediated with '%s'", filePath, similarityID)
-	s.ActualRemediationDoneNumber++
+	log.Info().Msgf("file '%s' was remediated", filePath)
 
-	return remediatedLines
+	return nil
 }
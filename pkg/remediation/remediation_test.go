@@ -119,3 +119,226 @@ func Test_RemediateFile(t *testing.T) {
 		})
 	}
 }
+
+func Test_GetRemediatedContent(t *testing.T) {
+	filePathCopyFrom := filepath.Join("..", "..", "test", "fixtures", "kics_auto_remediation", "terraform.tf")
+
+	mockCmd := &cobra.Command{
+		Use:   "mock",
+		Short: "Mock cmd",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return nil
+		},
+	}
+
+	data, err := os.ReadFile(filepath.FromSlash("../../internal/console/assets/scan-flags.json"))
+	require.NoError(t, err)
+	flags.InitJSONFlags(
+		mockCmd,
+		string(data),
+		true,
+		source.ListSupportedPlatforms(),
+		source.ListSupportedCloudProviders(),
+	)
+
+	var set Set
+	set.Replacement = append(set.Replacement, Remediation{
+		Line:         5,
+		Remediation:  "{\"after\":\"true\",\"before\":\"false\"}",
+		SimilarityID: "87abbee5d0ec977ba193371c702dca2c040ea902d2e606806a63b66119ff89bc",
+		QueryID:      "41a38329-d81b-4be4-aef4-55b2615d3282",
+		SearchKey:    "resource.alicloud_ram_account_password_policy[corporate1].require_symbols",
+	})
+
+	tmpFileName := filepath.Join(os.TempDir(), "temporary-remediation"+utils.NextRandom()+filepath.Ext(filePathCopyFrom))
+	tmpFile := CreateTempFile(filePathCopyFrom, tmpFileName)
+	defer os.Remove(tmpFile)
+
+	s := &Summary{}
+	original, remediated, err := s.GetRemediatedContent(tmpFile, set, false, 15)
+	require.NoError(t, err)
+	require.Equal(t, 1, s.ActualRemediationDoneNumber)
+	require.Contains(t, remediated, "  require_symbols              = true\n")
+
+	content, err := os.ReadFile(tmpFile)
+	require.NoError(t, err)
+	require.Equal(t, original, string(content), "the file should not be changed")
+}
+
+func Test_RemediateFileKinds(t *testing.T) {
+	fixtures := filepath.Join("..", "..", "test", "fixtures", "kics_auto_remediation")
+
+	tests := []struct {
+		name                        string
+		fileName                    string
+		remediate                   Set
+		actualRemediationDoneNumber int
+		want                        []string
+	}{
+		{
+			name:     "remediate a Kubernetes file with a replacement and addition",
+			fileName: "pod.yaml",
+			remediate: Set{
+				Replacement: []Remediation{{
+					Line:          10,
+					Remediation:   "{\"after\":\"false\",\"before\":\"true\"}",
+					SimilarityID:  "39651cd0800679ab09f6d9c867461ff78b72cf878c9f92b439927aef89b1aa1c",
+					QueryID:       "5572cc5e-1e4c-4113-92a6-7a8a3bd25e6d",
+					SearchKey:     "metadata.name={{web}}.spec.containers.name={{app}}.securityContext.allowPrivilegeEscalation",
+					ExpectedValue: "metadata.name={{web}}.spec.containers.name={{app}}.securityContext.allowPrivilegeEscalation should be set to false",
+					ActualValue:   "metadata.name={{web}}.spec.containers.name={{app}}.securityContext.allowPrivilegeEscalation is true",
+				}},
+				Addition: []Remediation{{
+					Line:          11,
+					Remediation:   "{\"securityContext\":{\"allowPrivilegeEscalation\":false}}",
+					SimilarityID:  "ec8e9874e451630cf7861cf2a10682abe4b2da86584bf5192335a020cd654090",
+					QueryID:       "5572cc5e-1e4c-4113-92a6-7a8a3bd25e6d",
+					SearchKey:     "metadata.name={{web}}.spec.containers.name={{sidecar}}",
+					ExpectedValue: "metadata.name={{web}}.spec.containers.name={{sidecar}}.securityContext.allowPrivilegeEscalation should be set and should be set to false",
+					ActualValue:   "metadata.name={{web}}.spec.containers.name={{sidecar}}.securityContext.allowPrivilegeEscalation is undefined",
+				}},
+			},
+			actualRemediationDoneNumber: 2,
+			want: []string{
+				"        allowPrivilegeEscalation: false\n    - name: sidecar",
+				"      image: busybox:1.36\n      securityContext:\n        allowPrivilegeEscalation: false\n",
+			},
+		},
+		{
+			name:     "remediate a CloudFormation JSON file with an addition",
+			fileName: "template.json",
+			remediate: Set{
+				Addition: []Remediation{{
+					Line:          6,
+					Remediation:   "{\"Encrypted\":true}",
+					SimilarityID:  "1d53b876cb96dac09ce79157b5ed90f57a51cf343a45eceeb477b8a4e3d77572",
+					QueryID:       "80b7ac3f-d2b7-4577-9b10-df7913497162",
+					SearchKey:     "Resources.Volume.Properties",
+					ExpectedValue: "Resources.Volume.Properties.Encrypted should be defined and not null",
+					ActualValue:   "Resources.Volume.Properties.Encrypted is undefined or null",
+				}},
+			},
+			actualRemediationDoneNumber: 1,
+			want:                        []string{"        \"Size\": 100,\n        \"Encrypted\": true\n"},
+		},
+		{
+			name:     "remediate a Dockerfile with an addition",
+			fileName: "Dockerfile",
+			remediate: Set{
+				Addition: []Remediation{{
+					Line:          1,
+					Remediation:   "USER nobody",
+					SimilarityID:  "2835499f57d2a606ef6ecfe4030377981291ee5e4ea125d3344f5f0886a6d443",
+					QueryID:       "fd54f200-402c-4333-a5a4-36ef6709af2f",
+					SearchKey:     "FROM={{alpine:3.19}}",
+					ExpectedValue: "The 'Dockerfile' should contain the 'USER' instruction",
+					ActualValue:   "The 'Dockerfile' does not contain any 'USER' instruction",
+				}},
+			},
+			actualRemediationDoneNumber: 1,
+			want:                        []string{"CMD [\"curl\", \"--version\"]\nUSER nobody\n"},
+		},
+		{
+			name:     "remediate a Dockerfile with a replacement",
+			fileName: "root.dockerfile",
+			remediate: Set{
+				Replacement: []Remediation{{
+					Line:          2,
+					Remediation:   "{\"after\":\"nobody\",\"before\":\"root\"}",
+					SimilarityID:  "4467ed5098120e825e566640ee270761ba31a051321ddc99026b35eb00ea8e43",
+					QueryID:       "67fd0c4a-68cf-46d7-8c41-bc9fba7e40ae",
+					SearchKey:     "FROM={{alpine:3.19}}.{{USER root}}",
+					ExpectedValue: "Last User shouldn't be root",
+					ActualValue:   "Last User is root",
+				}},
+			},
+			actualRemediationDoneNumber: 1,
+			want:                        []string{"FROM alpine:3.19\nUSER nobody\n"},
+		},
+		{
+			name:     "remediate a CloudFormation JSON file with a replacement",
+			fileName: "efs.json",
+			remediate: Set{
+				Replacement: []Remediation{{
+					Line:          7,
+					Remediation:   "{\"after\":\"true\",\"before\":\"false\"}",
+					SimilarityID:  "32a6d1b46a7f20281744dbfb1be34e27617a503c1d43ae7db13da637bd6215b6",
+					QueryID:       "2ff8e83c-90e1-4d68-a300-6d652112e622",
+					SearchKey:     "Resources.FileSystem.Properties.Encrypted",
+					ExpectedValue: "EFS resource 'FileSystem' should have encryption enabled",
+					ActualValue:   "EFS resource 'FileSystem' has encryption set to false",
+				}},
+			},
+			actualRemediationDoneNumber: 1,
+			want:                        []string{"        \"Encrypted\": true\n"},
+		},
+		{
+			name:     "remediate an OpenAPI file with an addition",
+			fileName: "openapi.yaml",
+			remediate: Set{
+				Addition: []Remediation{{
+					Line:          13,
+					Remediation:   "{\"required\":true}",
+					SimilarityID:  "b68aed0bdf6a93d3186e7fbb1822fd4c8ba5927aa6f5d2cd6208ceb7e2624ea5",
+					QueryID:       "0de50145-e845-47f4-9a15-23bcf2125710",
+					SearchKey:     "paths.{{/users/{id}}}.parameters.name={{id}}",
+					ExpectedValue: "Path parameter should have the field 'required' set to 'true' for location 'path'",
+					ActualValue:   "Path parameter does not have the field 'required' set to 'true' for location 'path'",
+				}},
+			},
+			actualRemediationDoneNumber: 1,
+			want:                        []string{"        required: true\n"},
+		},
+	}
+
+	mockCmd := &cobra.Command{
+		Use:   "mock",
+		Short: "Mock cmd",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return nil
+		},
+	}
+
+	data, err := os.ReadFile(filepath.FromSlash("../../internal/console/assets/scan-flags.json"))
+	require.NoError(t, err)
+	flags.InitJSONFlags(
+		mockCmd,
+		string(data),
+		true,
+		source.ListSupportedPlatforms(),
+		source.ListSupportedCloudProviders(),
+	)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Summary{
+				SelectedRemediationNumber:   0,
+				ActualRemediationDoneNumber: 0,
+			}
+
+			tmpFileName := filepath.Join(t.TempDir(), tt.fileName)
+			tmpFile := CreateTempFile(filepath.Join(fixtures, tt.fileName), tmpFileName)
+			require.NoError(t, s.RemediateFile(tmpFile, tt.remediate, false, 15))
+
+			remediated, err := os.ReadFile(tmpFile)
+			require.NoError(t, err)
+			require.Equal(t, tt.actualRemediationDoneNumber, s.ActualRemediationDoneNumber)
+			for _, want := range tt.want {
+				require.Contains(t, string(remediated), want)
+			}
+
+			original, err := os.ReadFile(filepath.Join(fixtures, tt.fileName))
+			require.NoError(t, err)
+			remediations := append(append([]Remediation{}, tt.remediate.Replacement...), tt.remediate.Addition...)
+			for i := range remediations {
+				before, err := scanTmpFile(tmpFile, remediations[i].QueryID, original, false, 15)
+				require.NoError(t, err)
+				require.False(t, removedResult(before, &remediations[i]), "the fixture should trigger the query")
+
+				after, err := scanTmpFile(tmpFile, remediations[i].QueryID, remediated, false, 15)
+				require.NoError(t, err)
+				require.True(t, removedResult(after, &remediations[i]), "the remediated file should no longer trigger the query")
+			}
+		})
+	}
+}
@@ -3,18 +3,19 @@ package remediation
 import (
 	"os"
 	"path/filepath"
-	"regexp"
-	"strings"
 
 	"github.com/Checkmarx/kics/v2/pkg/model"
+	remediationFix "github.com/Checkmarx/kics/v2/pkg/remediation/fix"
 	"github.com/Checkmarx/kics/v2/pkg/utils"
 	"github.com/rs/zerolog/log"
 )
 
-// Summary represents the information about the number of selected remediation and remediation done
+// Summary represents the information about the number of selected remediation, remediation done and remediation
+// skipped in the interactive mode
 type Summary struct {
 	SelectedRemediationNumber   int
 	ActualRemediationDoneNumber int
+	SkippedRemediationNumber    int
 }
 
 // GetRemediationSets collects all the replacements and additions per file
@@ -30,27 +31,22 @@ func (s *Summary) GetRemediationSets(results Report, include []string) map[strin
 	return remediationSets
 }
 
+// shouldRemediate verifies if the result has a remediation selected by the include list, in a file whose structure
+// is understood, such as Terraform, YAML, JSON and Dockerfile files
 func shouldRemediate(file *File, include []string) bool {
 	if file.Remediation != "" &&
 		file.RemediationType != "" &&
 		(include[0] == "all" || utils.Contains(file.SimilarityID, include)) &&
-		filepath.Ext(file.FilePath) == ".tf" { // temporary
+		remediationFix.Supported(file.FilePath) {
 		return true
 	}
 
 	return false
 }
 
-func getBefore(line string) string {
-	re := regexp.MustCompile(`^[\s-]*`)
-	before := re.FindAll([]byte(line), -1)
-
-	return string(before[0])
-}
-
 // willRemediate verifies if the remediation actually removes the result
 func willRemediate(
-	remediated []string,
+	remediated string,
 	originalFileName string,
 	remediation *Remediation,
 	openAPIResolveReferences bool,
@@ -65,7 +61,7 @@ func willRemediate(
 		return false
 	}
 
-	content := []byte(strings.Join(remediated, "\n"))
+	content := []byte(remediated)
 
 	defer func(f *os.File) {
 		err = f.Close()
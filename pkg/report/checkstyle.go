@@ -0,0 +1,26 @@
+package report
+
+import (
+	"strings"
+
+	"github.com/Checkmarx/kics/v2/pkg/model"
+	reportModel "github.com/Checkmarx/kics/v2/pkg/report/model"
+)
+
+// PrintCheckstyleReport prints the Checkstyle XML report in the given path and filename with the given body
+func PrintCheckstyleReport(path, filename string, body interface{}) error {
+	if !strings.HasPrefix(filename, "checkstyle-") {
+		filename = "checkstyle-" + filename
+	}
+
+	summary := model.Summary{}
+	if body != "" {
+		var err error
+		summary, err = getSummary(body)
+		if err != nil {
+			return err
+		}
+	}
+
+	return ExportXMLReport(path, filename, reportModel.BuildCheckstyleReport(&summary))
+}
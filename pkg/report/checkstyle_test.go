@@ -0,0 +1,25 @@
+package report
+
+import (
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"testing"
+
+	reportModel "github.com/Checkmarx/kics/v2/pkg/report/model"
+	"github.com/Checkmarx/kics/v2/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrintCheckstyleReport(t *testing.T) {
+	path := t.TempDir()
+
+	require.NoError(t, PrintCheckstyleReport(path, "results", test.SummaryMock))
+
+	content, err := os.ReadFile(filepath.Join(path, "checkstyle-results.xml"))
+	require.NoError(t, err)
+	var report reportModel.CheckstyleReport
+	require.NoError(t, xml.Unmarshal(content, &report))
+	require.Equal(t, "4.3", report.Version)
+	require.NotEmpty(t, report.Files)
+}
@@ -118,7 +118,8 @@ func getSummary(body interface{}) (sum model.Summary, err error) {
 	return summary, nil
 }
 
-func exportXMLReport(path, filename string, body interface{}) error {
+// ExportXMLReport - encodes a given body to a XML file in a given filepath
+func ExportXMLReport(path, filename string, body interface{}) error {
 	if !strings.HasSuffix(filename, ".xml") {
 		filename += ".xml"
 	}
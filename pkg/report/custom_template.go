@@ -0,0 +1,240 @@
+package report
+
+import (
+	"bytes"
+	_ "embed" // used for embedding the helpers of the custom templates
+	"encoding/json"
+	"fmt"
+	htmlTmpl "html/template"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	textTmpl "text/template"
+
+	"github.com/Checkmarx/kics/v2/pkg/model"
+)
+
+// TemplateFormatPrefix is the prefix of the report formats rendered by a custom template, followed by its path
+const TemplateFormatPrefix = "template:"
+
+var (
+	//go:embed template/custom/helpers.tmpl
+	customHelpersTemplate string
+
+	templateSeverities = []model.Severity{
+		model.SeverityCritical, model.SeverityHigh, model.SeverityMedium, model.SeverityLow, model.SeverityInfo,
+	}
+
+	// templateSeverityColors are the colors of the severities in the CLI report
+	templateSeverityColors = map[model.Severity]string{
+		model.SeverityCritical: "#ff0000",
+		model.SeverityHigh:     "#bb2124",
+		model.SeverityMedium:   "#ff7213",
+		model.SeverityLow:      "#edd57e",
+		model.SeverityInfo:     "#5bc0de",
+		model.SeverityTrace:    "#cccccc",
+	}
+
+	templateHTMLExtensions = map[string]bool{".html": true, ".htm": true}
+)
+
+// TemplateResult is a result in a custom template, a file with its query
+type TemplateResult struct {
+	Query *model.QueryResult
+	File  *model.VulnerableFile
+}
+
+// TemplateGroup is a group of results in a custom template, by file, platform or category
+type TemplateGroup struct {
+	Name    string
+	Results []TemplateResult
+}
+
+// executableTemplate is a parsed text or html template
+type executableTemplate interface {
+	Execute(w io.Writer, data interface{}) error
+}
+
+// TemplatePath returns the path of the template of a report format, false if it is not a template format
+func TemplatePath(format string) (string, bool) {
+	if len(format) < len(TemplateFormatPrefix) || !strings.EqualFold(format[:len(TemplateFormatPrefix)], TemplateFormatPrefix) {
+		return "", false
+	}
+	return format[len(TemplateFormatPrefix):], true
+}
+
+// ValidateTemplateFormat checks that the template of a report format can be read and parsed, so the scan does not
+// run to fail on its report
+func ValidateTemplateFormat(format string) error {
+	templatePath, ok := TemplatePath(format)
+	if !ok {
+		return fmt.Errorf("%s is not a template report format", format)
+	}
+	if templatePath == "" {
+		return fmt.Errorf("missing template path in report format %s", format)
+	}
+	_, err := parseCustomTemplate(templatePath, &model.Summary{})
+	return err
+}
+
+// PrintTemplateReport renders the summary with the custom template in templatePath. The report is named after the
+// output filename and the template, so 'report.html.tmpl' is written to '<filename>-report.html'. Templates of
+// '.html' reports are rendered by html/template, escaping the results, the others by text/template
+func PrintTemplateReport(path, filename, templatePath string, body interface{}) error {
+	// the summary is used as is when possible, VulnLines are not kept by its JSON encoding
+	summary, ok := body.(*model.Summary)
+	if !ok {
+		decoded, err := getSummary(body)
+		if err != nil {
+			return err
+		}
+		summary = &decoded
+	}
+
+	t, err := parseCustomTemplate(templatePath, summary)
+	if err != nil {
+		return err
+	}
+	var buffer bytes.Buffer
+	if err := t.Execute(&buffer, summary); err != nil {
+		return fmt.Errorf("failed to render template %s: %w", templatePath, err)
+	}
+
+	filename = strings.TrimSuffix(filename, filepath.Ext(filename)) + "-" + templateOutputName(templatePath)
+	fullPath := filepath.Join(path, filename)
+	if err := os.WriteFile(filepath.Clean(fullPath), buffer.Bytes(), os.ModePerm); err != nil {
+		return err
+	}
+	fileCreationReport(fullPath, filename)
+	return nil
+}
+
+// templateOutputName is the name of the template without its '.tmpl' or '.gotmpl' extension
+func templateOutputName(templatePath string) string {
+	name := filepath.Base(templatePath)
+	for _, extension := range []string{".tmpl", ".gotmpl"} {
+		if strings.HasSuffix(name, extension) {
+			return strings.TrimSuffix(name, extension)
+		}
+	}
+	return name
+}
+
+func parseCustomTemplate(templatePath string, summary *model.Summary) (executableTemplate, error) {
+	content, err := os.ReadFile(filepath.Clean(templatePath))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template %s: %w", templatePath, err)
+	}
+
+	name := filepath.Base(templatePath)
+	funcs := customTemplateFuncs(summary)
+	if templateHTMLExtensions[strings.ToLower(filepath.Ext(templateOutputName(templatePath)))] {
+		t, err := htmlTmpl.New(name).Funcs(funcs).Parse(customHelpersTemplate)
+		if err == nil {
+			t, err = t.Parse(string(content))
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse template %s: %w", templatePath, err)
+		}
+		return t, nil
+	}
+
+	t, err := textTmpl.New(name).Funcs(funcs).Parse(customHelpersTemplate)
+	if err == nil {
+		t, err = t.Parse(string(content))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template %s: %w", templatePath, err)
+	}
+	return t, nil
+}
+
+// customTemplateFuncs are the helper functions of the custom templates, documented in docs/results.md
+func customTemplateFuncs(summary *model.Summary) map[string]interface{} {
+	return map[string]interface{}{
+		"severities":    func() []model.Severity { return templateSeverities },
+		"severityColor": severityColor,
+		"relativePath": func(fileName string) string {
+			return relativeToScannedPath(fileName, summary.ScannedPaths)
+		},
+		"codeSnippet":     codeSnippet,
+		"results":         templateResults,
+		"groupByFile":     groupByFile,
+		"groupByPlatform": groupByPlatform,
+		"groupByCategory": groupByCategory,
+		"toJSON":          toJSON,
+		"lower":           strings.ToLower,
+		"upper":           strings.ToUpper,
+		"join":            strings.Join,
+		"sprintf":         fmt.Sprintf,
+		"version":         getVersion,
+	}
+}
+
+func severityColor(severity model.Severity) string {
+	return templateSeverityColors[model.Severity(strings.ToUpper(string(severity)))]
+}
+
+// codeSnippet renders the lines of a result with their line numbers, the lines are only kept when the report is
+// rendered from the scan
+func codeSnippet(file *model.VulnerableFile) string {
+	if file.VulnLines == nil {
+		return ""
+	}
+	var sb strings.Builder
+	for _, line := range *file.VulnLines {
+		fmt.Fprintf(&sb, "%03d: %s\n", line.Position, line.Line)
+	}
+	return sb.String()
+}
+
+// templateResults flattens the results of the queries, in the order of the queries
+func templateResults(queries model.QueryResultSlice) []TemplateResult {
+	results := make([]TemplateResult, 0, len(queries))
+	for i := range queries {
+		for j := range queries[i].Files {
+			results = append(results, TemplateResult{Query: &queries[i], File: &queries[i].Files[j]})
+		}
+	}
+	return results
+}
+
+func groupByFile(queries model.QueryResultSlice) []TemplateGroup {
+	return groupResults(queries, func(result *TemplateResult) string { return result.File.FileName })
+}
+
+func groupByPlatform(queries model.QueryResultSlice) []TemplateGroup {
+	return groupResults(queries, func(result *TemplateResult) string { return result.Query.Platform })
+}
+
+func groupByCategory(queries model.QueryResultSlice) []TemplateGroup {
+	return groupResults(queries, func(result *TemplateResult) string { return result.Query.Category })
+}
+
+// groupResults groups the results by the given key, the groups are sorted by name
+func groupResults(queries model.QueryResultSlice, key func(result *TemplateResult) string) []TemplateGroup {
+	groups := make(map[string]*TemplateGroup)
+	for _, result := range templateResults(queries) {
+		name := key(&result)
+		if _, ok := groups[name]; !ok {
+			groups[name] = &TemplateGroup{Name: name}
+		}
+		groups[name].Results = append(groups[name].Results, result)
+	}
+
+	sorted := make([]TemplateGroup, 0, len(groups))
+	for _, group := range groups {
+		sorted = append(sorted, *group)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Name < sorted[j].Name
+	})
+	return sorted
+}
+
+func toJSON(value interface{}) (string, error) {
+	content, err := json.Marshal(value)
+	return string(content), err
+}
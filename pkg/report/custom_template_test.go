@@ -0,0 +1,143 @@
+package report
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Checkmarx/kics/v2/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+var templateSummary = &model.Summary{
+	Counters: model.Counters{ScannedFiles: 2},
+	SeveritySummary: model.SeveritySummary{
+		SeverityCounters: map[model.Severity]int{
+			model.SeverityCritical: 0,
+			model.SeverityHigh:     1,
+			model.SeverityMedium:   1,
+			model.SeverityLow:      1,
+			model.SeverityInfo:     0,
+		},
+		TotalCounter: 3,
+	},
+	Queries: model.QueryResultSlice{
+		{
+			QueryName: "S3 Bucket ACL Allows Read Or Write to All Users",
+			Severity:  model.SeverityHigh,
+			Platform:  "Terraform",
+			Category:  "Access Control",
+			Files: []model.VulnerableFile{
+				{
+					FileName:       "main.tf",
+					Line:           3,
+					KeyActualValue: "'acl' is equal '<public-read>'",
+					VulnLines: &[]model.CodeLine{
+						{Position: 3, Line: `  acl = "<public-read>"`},
+					},
+				},
+			},
+		},
+		{
+			QueryName: "Image Version Using 'latest'",
+			Severity:  model.SeverityMedium,
+			Platform:  "Dockerfile",
+			Category:  "Best Practices",
+			Files: []model.VulnerableFile{
+				{FileName: "Dockerfile", Line: 1, KeyActualValue: "FROM alpine:latest"},
+			},
+		},
+		{
+			QueryName: "S3 Bucket Without Versioning",
+			Severity:  model.SeverityLow,
+			Platform:  "Terraform",
+			Category:  "Backup",
+			Files: []model.VulnerableFile{
+				{FileName: "main.tf", Line: 1, KeyActualValue: "'versioning' is undefined or null"},
+			},
+		},
+	},
+}
+
+func TestPrintTemplateReport(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		content  string
+		output   string
+		want     string
+	}{
+		{
+			name:     "text template with the helpers",
+			template: "summary.txt.tmpl",
+			content: `{{ template "kics.severityTable" . }}
+{{ range groupByFile .Queries }}{{ .Name }}
+{{ range .Results }}{{ template "kics.result" . }}
+{{ codeSnippet .File }}{{ end }}{{ end }}`,
+			output: "results-summary.txt",
+			want: `CRITICAL: 0
+HIGH: 1
+MEDIUM: 1
+LOW: 1
+INFO: 0
+TOTAL: 3
+Dockerfile
+Dockerfile:1: [MEDIUM] Image Version Using 'latest': FROM alpine:latest
+main.tf
+main.tf:3: [HIGH] S3 Bucket ACL Allows Read Or Write to All Users: 'acl' is equal '<public-read>'
+003:   acl = "<public-read>"
+main.tf:1: [LOW] S3 Bucket Without Versioning: 'versioning' is undefined or null
+`,
+		},
+		{
+			name:     "html template escapes the results",
+			template: "report.html.tmpl",
+			content: `{{ range groupByPlatform .Queries }}<h2>{{ .Name }}</h2>{{ range .Results }}` +
+				`<p style="color: {{ severityColor .Query.Severity }}">{{ .File.KeyActualValue }}</p>{{ end }}{{ end }}`,
+			output: "results-report.html",
+			want: `<h2>Dockerfile</h2><p style="color: #ff7213">FROM alpine:latest</p>` +
+				`<h2>Terraform</h2><p style="color: #bb2124">&#39;acl&#39; is equal &#39;&lt;public-read&gt;&#39;</p>` +
+				`<p style="color: #edd57e">&#39;versioning&#39; is undefined or null</p>`,
+		},
+		{
+			name:     "template without extension",
+			template: "categories",
+			content:  `{{ range groupByCategory .Queries }}{{ .Name }}: {{ len .Results }}{{ "\n" }}{{ end }}`,
+			output:   "results-categories",
+			want:     "Access Control: 1\nBackup: 1\nBest Practices: 1\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			templatePath := filepath.Join(t.TempDir(), tt.template)
+			require.NoError(t, os.WriteFile(templatePath, []byte(tt.content), 0600))
+			outputPath := t.TempDir()
+
+			require.NoError(t, ValidateTemplateFormat(TemplateFormatPrefix+templatePath))
+			require.NoError(t, PrintTemplateReport(outputPath, "results", templatePath, templateSummary))
+
+			content, err := os.ReadFile(filepath.Join(outputPath, tt.output))
+			require.NoError(t, err)
+			require.Equal(t, tt.want, string(content))
+		})
+	}
+}
+
+func TestTemplatePath(t *testing.T) {
+	path, ok := TemplatePath("Template:reports/custom.tmpl")
+	require.True(t, ok)
+	require.Equal(t, "reports/custom.tmpl", path)
+
+	_, ok = TemplatePath("json")
+	require.False(t, ok)
+}
+
+func TestValidateTemplateFormat(t *testing.T) {
+	invalidPath := filepath.Join(t.TempDir(), "invalid.tmpl")
+	require.NoError(t, os.WriteFile(invalidPath, []byte("{{ range .Queries }}"), 0600))
+
+	require.Error(t, ValidateTemplateFormat(TemplateFormatPrefix+invalidPath))
+	require.Error(t, ValidateTemplateFormat(TemplateFormatPrefix+filepath.Join(t.TempDir(), "missing.tmpl")))
+	require.Error(t, ValidateTemplateFormat(TemplateFormatPrefix))
+}
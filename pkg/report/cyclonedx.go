@@ -27,5 +27,5 @@ func PrintCycloneDxReport(path, filename string, body interface{}) error {
 		body = reportModel.BuildCycloneDxReport(&summary, filePaths)
 	}
 
-	return exportXMLReport(path, filename, body)
+	return ExportXMLReport(path, filename, body)
 }
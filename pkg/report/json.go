@@ -1,6 +1,14 @@
 package report
 
-import "github.com/Checkmarx/kics/v2/internal/constants"
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/Checkmarx/kics/v2/internal/constants"
+	"github.com/Checkmarx/kics/v2/pkg/model"
+)
 
 const jsonExtension = ".json"
 
@@ -24,3 +32,16 @@ func PrintJSONReport(path, filename string, body interface{}) error {
 
 	return ExportJSONReport(path, filename, body)
 }
+
+// ReadJSONReport reads the summary of a KICS JSON report
+func ReadJSONReport(path string) (*model.Summary, error) {
+	content, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read report %s: %w", path, err)
+	}
+	var summary model.Summary
+	if err := json.Unmarshal(content, &summary); err != nil {
+		return nil, fmt.Errorf("failed to parse report %s: %w", path, err)
+	}
+	return &summary, nil
+}
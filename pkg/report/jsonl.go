@@ -0,0 +1,168 @@
+package report
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/Checkmarx/kics/v2/internal/constants"
+	"github.com/Checkmarx/kics/v2/pkg/model"
+)
+
+const (
+	jsonlExtension = ".jsonl"
+
+	jsonlResultType  = "result"
+	jsonlSummaryType = "summary"
+)
+
+// jsonlResult is a result line of the JSON Lines report, the result with the metadata of its query
+type jsonlResult struct {
+	Type          string         `json:"type"`
+	QueryName     string         `json:"query_name"`
+	QueryID       string         `json:"query_id"`
+	QueryURI      string         `json:"query_url"`
+	Severity      model.Severity `json:"severity"`
+	Platform      string         `json:"platform"`
+	CWE           string         `json:"cwe,omitempty"`
+	CloudProvider string         `json:"cloud_provider,omitempty"`
+	Category      string         `json:"category"`
+	Experimental  bool           `json:"experimental"`
+	Description   string         `json:"description"`
+	DescriptionID string         `json:"description_id"`
+	model.VulnerableFile
+}
+
+// jsonlSummary is the last line of the JSON Lines report, the summary of the scan without its results
+type jsonlSummary struct {
+	Type    string `json:"type"`
+	Version string `json:"kics_version,omitempty"`
+	model.Counters
+	model.SeveritySummary
+	model.Times
+	ScannedPaths []string               `json:"paths"`
+	Baseline     *model.BaselineSummary `json:"baseline,omitempty"`
+	ChangedSince *model.ChangedSince    `json:"changed_since,omitempty"`
+}
+
+// JSONLWriter writes a JSON Lines report while the scan runs, a line per result as soon as it is found and a summary
+// line once the scan finishes, so the results do not have to be kept in memory
+type JSONLWriter struct {
+	mu       sync.Mutex
+	fullPath string
+	filename string
+	file     *os.File
+	writer   *bufio.Writer
+	encoder  *json.Encoder
+}
+
+// NewJSONLWriter creates the JSON Lines report in the given path and filename
+func NewJSONLWriter(path, filename string) (*JSONLWriter, error) {
+	if !strings.HasSuffix(filename, jsonlExtension) {
+		filename += jsonlExtension
+	}
+	fullPath := filepath.Join(path, filename)
+	file, err := os.Create(filepath.Clean(fullPath))
+	if err != nil {
+		return nil, err
+	}
+	writer := bufio.NewWriter(file)
+	return &JSONLWriter{
+		fullPath: fullPath,
+		filename: filename,
+		file:     file,
+		writer:   writer,
+		encoder:  json.NewEncoder(writer),
+	}, nil
+}
+
+// WriteQueries writes a line per result of the queries, the lines are flushed so they can be read right away
+func (w *JSONLWriter) WriteQueries(queries model.QueryResultSlice) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if err := writeJSONLQueries(w.encoder, queries); err != nil {
+		return err
+	}
+	return w.writer.Flush()
+}
+
+// Close writes the summary line and closes the report
+func (w *JSONLWriter) Close(summary *model.Summary) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if err := w.encoder.Encode(newJSONLSummary(summary)); err != nil {
+		_ = w.file.Close()
+		return err
+	}
+	if err := w.writer.Flush(); err != nil {
+		_ = w.file.Close()
+		return err
+	}
+	closeFile(w.fullPath, w.filename, w.file)
+	return nil
+}
+
+// PrintJSONLReport prints the JSON Lines report of a finished scan in the given path and filename, with the same
+// lines written by JSONLWriter
+func PrintJSONLReport(path, filename string, body interface{}) error {
+	summary, ok := body.(*model.Summary)
+	if !ok {
+		decoded, err := getSummary(body)
+		if err != nil {
+			return err
+		}
+		summary = &decoded
+	}
+
+	writer, err := NewJSONLWriter(path, filename)
+	if err != nil {
+		return err
+	}
+	if err := writer.WriteQueries(summary.Queries); err != nil {
+		_ = writer.file.Close()
+		return err
+	}
+	return writer.Close(summary)
+}
+
+func writeJSONLQueries(encoder *json.Encoder, queries model.QueryResultSlice) error {
+	for idx := range queries {
+		query := &queries[idx]
+		for fileIdx := range query.Files {
+			if err := encoder.Encode(&jsonlResult{
+				Type:           jsonlResultType,
+				QueryName:      query.QueryName,
+				QueryID:        query.QueryID,
+				QueryURI:       query.QueryURI,
+				Severity:       query.Severity,
+				Platform:       query.Platform,
+				CWE:            query.CWE,
+				CloudProvider:  query.CloudProvider,
+				Category:       query.Category,
+				Experimental:   query.Experimental,
+				Description:    query.Description,
+				DescriptionID:  query.DescriptionID,
+				VulnerableFile: query.Files[fileIdx],
+			}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func newJSONLSummary(summary *model.Summary) *jsonlSummary {
+	return &jsonlSummary{
+		Type:            jsonlSummaryType,
+		Version:         constants.Version,
+		Counters:        summary.Counters,
+		SeveritySummary: summary.SeveritySummary,
+		Times:           summary.Times,
+		ScannedPaths:    summary.ScannedPaths,
+		Baseline:        summary.Baseline,
+		ChangedSince:    summary.ChangedSince,
+	}
+}
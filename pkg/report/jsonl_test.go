@@ -0,0 +1,85 @@
+package report
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Checkmarx/kics/v2/pkg/model"
+	"github.com/Checkmarx/kics/v2/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrintJSONLReport(t *testing.T) {
+	path := t.TempDir()
+	summary := test.ComplexSummaryMock
+
+	require.NoError(t, PrintJSONLReport(path, "results", &summary))
+
+	file, err := os.Open(filepath.Join(path, "results.jsonl"))
+	require.NoError(t, err)
+	defer file.Close()
+
+	lines := make([]map[string]interface{}, 0)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var line map[string]interface{}
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &line))
+		lines = append(lines, line)
+	}
+	require.NoError(t, scanner.Err())
+
+	require.Len(t, lines, countResults(&summary)+1)
+	for _, line := range lines[:len(lines)-1] {
+		require.Equal(t, "result", line["type"])
+		require.NotEmpty(t, line["query_id"])
+		require.NotEmpty(t, line["file_name"])
+	}
+	require.Equal(t, "ALB protocol is HTTP", lines[0]["query_name"])
+
+	last := lines[len(lines)-1]
+	require.Equal(t, "summary", last["type"])
+	require.Equal(t, float64(summary.TotalCounter), last["total_counter"])
+	require.NotContains(t, last, "queries")
+}
+
+func TestJSONLWriter(t *testing.T) {
+	path := t.TempDir()
+	summary := test.ComplexSummaryMock
+
+	writer, err := NewJSONLWriter(path, "streamed.jsonl")
+	require.NoError(t, err)
+
+	// each batch is readable as soon as it is written
+	require.NoError(t, writer.WriteQueries(summary.Queries[:1]))
+	content, err := os.ReadFile(filepath.Join(path, "streamed.jsonl"))
+	require.NoError(t, err)
+	require.Equal(t, len(summary.Queries[0].Files), countLines(content))
+
+	require.NoError(t, writer.WriteQueries(summary.Queries[1:]))
+	require.NoError(t, writer.Close(&summary))
+
+	content, err = os.ReadFile(filepath.Join(path, "streamed.jsonl"))
+	require.NoError(t, err)
+	require.Equal(t, countResults(&summary)+1, countLines(content))
+}
+
+func countResults(summary *model.Summary) int {
+	count := 0
+	for idx := range summary.Queries {
+		count += len(summary.Queries[idx].Files)
+	}
+	return count
+}
+
+func countLines(content []byte) int {
+	count := 0
+	for _, c := range content {
+		if c == '\n' {
+			count++
+		}
+	}
+	return count
+}
@@ -30,5 +30,5 @@ func PrintJUnitReport(path, filename string, body interface{}) error {
 
 	jUnitReport.FinishReport()
 
-	return exportXMLReport(path, filename, jUnitReport)
+	return ExportXMLReport(path, filename, jUnitReport)
 }
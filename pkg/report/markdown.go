@@ -0,0 +1,205 @@
+package report
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Checkmarx/kics/v2/pkg/model"
+)
+
+const (
+	markdownExtension = ".md"
+	// DefaultMarkdownMaxLength keeps the markdown report under the size limit of GitHub comments (65536 characters)
+	DefaultMarkdownMaxLength = 65000
+	// markdownTruncationReserve is kept free for the truncation note
+	markdownTruncationReserve = 200
+)
+
+var (
+	markdownSeverities = []model.Severity{
+		model.SeverityCritical, model.SeverityHigh, model.SeverityMedium, model.SeverityLow, model.SeverityInfo,
+	}
+
+	// markdownHTMLEscaper escapes the characters that would be taken as HTML, quotes are kept readable
+	markdownHTMLEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+	markdownCodeLanguages = map[string]string{
+		".tf":         "hcl",
+		".tfvars":     "hcl",
+		".yaml":       "yaml",
+		".yml":        "yaml",
+		".json":       "json",
+		".bicep":      "bicep",
+		".proto":      "protobuf",
+		".dockerfile": "dockerfile",
+	}
+)
+
+// PrintMarkdownReport prints the markdown report in the given path and filename with the given body,
+// ready to be posted as a pull request comment. The report is kept under the MarkdownMaxLength characters of the
+// summary, a length lower than 1 disables the truncation, or DefaultMarkdownMaxLength when the body is not a summary
+func PrintMarkdownReport(path, filename string, body interface{}) error {
+	if !strings.HasSuffix(filename, markdownExtension) {
+		filename += markdownExtension
+	}
+
+	// the summary is used as is when possible, VulnLines are not kept by its JSON encoding
+	summary, ok := body.(*model.Summary)
+	maxLength := DefaultMarkdownMaxLength
+	if ok {
+		maxLength = summary.MarkdownMaxLength
+	} else {
+		decoded, err := getSummary(body)
+		if err != nil {
+			return err
+		}
+		summary = &decoded
+	}
+
+	fullPath := filepath.Join(path, filename)
+	if err := os.WriteFile(filepath.Clean(fullPath), []byte(renderMarkdown(summary, maxLength)), os.ModePerm); err != nil {
+		return err
+	}
+	fileCreationReport(fullPath, filename)
+	return nil
+}
+
+// renderMarkdown renders the summary as a severity table followed by a collapsible section per query, the query
+// sections are truncated when the report would be longer than maxLength
+func renderMarkdown(summary *model.Summary, maxLength int) string {
+	var sb strings.Builder
+
+	sb.WriteString("## KICS Scan Results\n\n")
+	fmt.Fprintf(&sb, "**%d** results found in **%d** scanned files.\n\n", summary.TotalCounter, summary.ScannedFiles)
+	sb.WriteString("| Severity | Results |\n")
+	sb.WriteString("|:---|---:|\n")
+	for _, severity := range markdownSeverities {
+		fmt.Fprintf(&sb, "| %s | %d |\n", severity, summary.SeverityCounters[severity])
+	}
+	fmt.Fprintf(&sb, "| **TOTAL** | %d |\n\n", summary.TotalCounter)
+
+	truncatedResults := 0
+	for idx := range summary.Queries {
+		query := &summary.Queries[idx]
+		if truncatedResults > 0 {
+			truncatedResults += len(query.Files)
+			continue
+		}
+		written := writeMarkdownQuery(&sb, query, summary.ScannedPaths, maxLength)
+		truncatedResults += len(query.Files) - written
+	}
+
+	if truncatedResults > 0 {
+		fmt.Fprintf(&sb, "_%d results were truncated, see the full report for all the results._\n", truncatedResults)
+	}
+
+	return sb.String()
+}
+
+// writeMarkdownQuery writes the section of a query, with as many results as fit in the maximum length,
+// and returns the number of results written
+func writeMarkdownQuery(sb *strings.Builder, query *model.QueryResult, scannedPaths []string, maxLength int) int {
+	var header strings.Builder
+	fmt.Fprintf(&header, "<details>\n<summary><b>%s</b> %s (%d)</summary>\n\n",
+		query.Severity, markdownHTMLEscaper.Replace(query.QueryName), len(query.Files))
+	details := make([]string, 0, 3)
+	if query.QueryURI != "" {
+		details = append(details, fmt.Sprintf("[Documentation](%s)", query.QueryURI))
+	}
+	if query.Platform != "" {
+		details = append(details, fmt.Sprintf("Platform: %s", query.Platform))
+	}
+	if query.Category != "" {
+		details = append(details, fmt.Sprintf("Category: %s", query.Category))
+	}
+	if len(details) > 0 {
+		fmt.Fprintf(&header, "%s\n\n", strings.Join(details, " · "))
+	}
+	if query.Description != "" {
+		fmt.Fprintf(&header, "%s\n\n", query.Description)
+	}
+	const footer = "</details>\n\n"
+
+	written := 0
+	for idx := range query.Files {
+		entry := markdownFile(&query.Files[idx], scannedPaths)
+		length := sb.Len() + len(entry) + len(footer) + markdownTruncationReserve
+		if written == 0 {
+			length += header.Len()
+		}
+		if maxLength > 0 && length > maxLength {
+			break
+		}
+		if written == 0 {
+			sb.WriteString(header.String())
+		}
+		sb.WriteString(entry)
+		written++
+	}
+	if written > 0 {
+		sb.WriteString(footer)
+	}
+	return written
+}
+
+func markdownFile(file *model.VulnerableFile, scannedPaths []string) string {
+	var sb strings.Builder
+
+	path := relativeToScannedPath(file.FileName, scannedPaths)
+	fmt.Fprintf(&sb, "- [`%s:%d`](%s#L%d)", path, file.Line, path, file.Line)
+	if file.KeyExpectedValue != "" || file.KeyActualValue != "" {
+		fmt.Fprintf(&sb, ": %s", markdownHTMLEscaper.Replace(file.KeyActualValue))
+		if file.KeyExpectedValue != "" {
+			fmt.Fprintf(&sb, " (expected: %s)", markdownHTMLEscaper.Replace(file.KeyExpectedValue))
+		}
+	}
+	sb.WriteString("\n")
+
+	if file.VulnLines != nil && len(*file.VulnLines) > 0 {
+		fmt.Fprintf(&sb, "  ```%s\n", markdownCodeLanguage(file.FileName))
+		for _, line := range *file.VulnLines {
+			fmt.Fprintf(&sb, "  %03d: %s\n", line.Position, strings.ReplaceAll(line.Line, "```", "'''"))
+		}
+		sb.WriteString("  ```\n")
+	}
+	return sb.String()
+}
+
+func markdownCodeLanguage(fileName string) string {
+	if strings.EqualFold(filepath.Base(fileName), "dockerfile") {
+		return "dockerfile"
+	}
+	return markdownCodeLanguages[strings.ToLower(filepath.Ext(fileName))]
+}
+
+// relativeToScannedPath returns the file path relative to the scanned path containing it, directories of
+// scanned files are used as their root
+func relativeToScannedPath(fileName string, scannedPaths []string) string {
+	absFile, err := filepath.Abs(fileName)
+	if err != nil {
+		return filepath.ToSlash(fileName)
+	}
+	relative := ""
+	for _, scannedPath := range scannedPaths {
+		root, err := filepath.Abs(scannedPath)
+		if err != nil {
+			continue
+		}
+		if info, err := os.Stat(root); err == nil && !info.IsDir() {
+			root = filepath.Dir(root)
+		}
+		rel, err := filepath.Rel(root, absFile)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(os.PathSeparator)) {
+			continue
+		}
+		if relative == "" || len(rel) < len(relative) {
+			relative = rel
+		}
+	}
+	if relative == "" {
+		return filepath.ToSlash(fileName)
+	}
+	return filepath.ToSlash(relative)
+}
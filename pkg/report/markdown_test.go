@@ -0,0 +1,89 @@
+package report
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Checkmarx/kics/v2/pkg/model"
+	"github.com/Checkmarx/kics/v2/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrintMarkdownReport(t *testing.T) {
+	path := t.TempDir()
+	summary := test.ComplexSummaryMock
+
+	require.NoError(t, PrintMarkdownReport(path, "results", &summary))
+
+	content, err := os.ReadFile(filepath.Join(path, "results.md"))
+	require.NoError(t, err)
+	report := string(content)
+	require.Contains(t, report, "| HIGH | 2 |")
+	require.Contains(t, report, "| **TOTAL** | 5 |")
+	require.Contains(t, report, "<summary><b>HIGH</b> ALB protocol is HTTP (2)</summary>")
+	require.NotContains(t, report, "truncated")
+
+	summary.MarkdownMaxLength = 1
+	require.NoError(t, PrintMarkdownReport(path, "truncated", &summary))
+	content, err = os.ReadFile(filepath.Join(path, "truncated.md"))
+	require.NoError(t, err)
+	require.Contains(t, string(content), "results were truncated", "the max length of the summary should be used")
+}
+
+func Test_renderMarkdown(t *testing.T) {
+	summary := model.Summary{
+		ScannedPaths: []string{filepath.Join("project", "terraform")},
+		Queries: model.QueryResultSlice{
+			{
+				QueryName: "Query <1>",
+				QueryURI:  "https://docs.kics.io",
+				Severity:  model.SeverityHigh,
+				Platform:  "Terraform",
+				Files: []model.VulnerableFile{
+					{
+						FileName:       filepath.Join("project", "terraform", "modules", "main.tf"),
+						Line:           2,
+						KeyActualValue: "'enabled' is false",
+						VulnLines: &[]model.CodeLine{
+							{Position: 1, Line: "resource \"aws_s3_bucket\" \"b\" {"},
+							{Position: 2, Line: "  enabled = false"},
+						},
+					},
+				},
+			},
+			{
+				QueryName: "Query 2",
+				Severity:  model.SeverityLow,
+				Files: []model.VulnerableFile{
+					{FileName: filepath.Join("project", "terraform", "main.tf"), Line: 10},
+					{FileName: filepath.Join("project", "terraform", "main.tf"), Line: 20},
+				},
+			},
+		},
+		SeveritySummary: model.SeveritySummary{
+			SeverityCounters: map[model.Severity]int{model.SeverityHigh: 1, model.SeverityLow: 2},
+			TotalCounter:     3,
+		},
+	}
+
+	report := renderMarkdown(&summary, 0)
+	require.Contains(t, report, "<summary><b>HIGH</b> Query &lt;1&gt; (1)</summary>")
+	require.Contains(t, report, "[Documentation](https://docs.kics.io) · Platform: Terraform")
+	require.Contains(t, report, "- [`modules/main.tf:2`](modules/main.tf#L2): 'enabled' is false\n")
+	require.Contains(t, report, "  ```hcl\n  001: resource \"aws_s3_bucket\" \"b\" {\n  002:   enabled = false\n  ```\n")
+	require.Contains(t, report, "- [`main.tf:20`](main.tf#L20)\n")
+	require.NotContains(t, report, "truncated")
+
+	// only the last result does not fit
+	truncated := renderMarkdown(&summary, len(report)+markdownTruncationReserve-1)
+	require.Contains(t, truncated, "Query &lt;1&gt;")
+	require.Contains(t, truncated, "- [`main.tf:10`](main.tf#L10)\n")
+	require.NotContains(t, truncated, "main.tf:20")
+	require.True(t, strings.HasSuffix(truncated, "_1 results were truncated, see the full report for all the results._\n"))
+
+	headerOnly := renderMarkdown(&summary, 1)
+	require.NotContains(t, headerOnly, "<details>")
+	require.Contains(t, headerOnly, "_3 results were truncated")
+}
@@ -0,0 +1,81 @@
+package model
+
+import (
+	"encoding/xml"
+	"fmt"
+	"sort"
+
+	"github.com/Checkmarx/kics/v2/pkg/model"
+)
+
+// checkstyleVersion is the version of the Checkstyle XML format, as written by Checkstyle itself
+const checkstyleVersion = "4.3"
+
+var checkstyleSeverities = map[model.Severity]string{
+	model.SeverityCritical: "error",
+	model.SeverityHigh:     "error",
+	model.SeverityMedium:   "warning",
+	model.SeverityLow:      "info",
+	model.SeverityInfo:     "info",
+	model.SeverityTrace:    "info",
+}
+
+// CheckstyleReport is the Checkstyle XML report, read by reviewdog and Jenkins Warnings NG
+type CheckstyleReport struct {
+	XMLName xml.Name         `xml:"checkstyle"`
+	Version string           `xml:"version,attr"`
+	Files   []checkstyleFile `xml:"file"`
+}
+
+type checkstyleFile struct {
+	Name   string            `xml:"name,attr"`
+	Errors []checkstyleError `xml:"error"`
+}
+
+type checkstyleError struct {
+	Line     int    `xml:"line,attr"`
+	Severity string `xml:"severity,attr"`
+	Message  string `xml:"message,attr"`
+	Source   string `xml:"source,attr"`
+}
+
+// BuildCheckstyleReport builds the Checkstyle report, with the results grouped by file and sorted by line
+func BuildCheckstyleReport(summary *model.Summary) *CheckstyleReport {
+	errorsByFile := make(map[string][]checkstyleError)
+	for i := range summary.Queries {
+		query := &summary.Queries[i]
+		for j := range query.Files {
+			file := &query.Files[j]
+			line := file.Line
+			if line < 1 {
+				line = 1
+			}
+			errorsByFile[file.FileName] = append(errorsByFile[file.FileName], checkstyleError{
+				Line:     line,
+				Severity: checkstyleSeverities[query.Severity],
+				Message:  fmt.Sprintf("[%s] %s: %s", query.Severity, query.QueryName, file.KeyActualValue),
+				Source:   "kics." + query.QueryID,
+			})
+		}
+	}
+
+	fileNames := make([]string, 0, len(errorsByFile))
+	for fileName := range errorsByFile {
+		fileNames = append(fileNames, fileName)
+	}
+	sort.Strings(fileNames)
+
+	report := &CheckstyleReport{
+		Version: checkstyleVersion,
+		Files:   make([]checkstyleFile, 0, len(fileNames)),
+	}
+	for _, fileName := range fileNames {
+		errors := errorsByFile[fileName]
+		sort.SliceStable(errors, func(i, j int) bool {
+			return errors[i].Line < errors[j].Line
+		})
+		report.Files = append(report.Files, checkstyleFile{Name: fileName, Errors: errors})
+	}
+
+	return report
+}
@@ -0,0 +1,75 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/Checkmarx/kics/v2/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildCheckstyleReport(t *testing.T) {
+	summary := &model.Summary{
+		Queries: model.QueryResultSlice{
+			{
+				QueryName: "S3 Bucket Without Versioning",
+				QueryID:   "568a4d22-3517-44a6-a7ad-6a7eed88722c",
+				Severity:  model.SeverityMedium,
+				Files: []model.VulnerableFile{
+					{FileName: "main.tf", Line: 24, KeyActualValue: "'versioning.enabled' is set to false"},
+					{FileName: "bucket.tf", Line: 3, KeyActualValue: "'versioning' is undefined or null"},
+				},
+			},
+			{
+				QueryName: "S3 Bucket ACL Allows Read Or Write to All Users",
+				QueryID:   "38c5ee0d-7f22-4260-ab72-5073048df100",
+				Severity:  model.SeverityHigh,
+				Files: []model.VulnerableFile{
+					{FileName: "main.tf", Line: 16, KeyActualValue: "'acl' is equal 'public-read'"},
+					{FileName: "main.tf", Line: -1, KeyActualValue: "'acl' is undefined"},
+				},
+			},
+		},
+	}
+
+	want := &CheckstyleReport{
+		Version: "4.3",
+		Files: []checkstyleFile{
+			{
+				Name: "bucket.tf",
+				Errors: []checkstyleError{
+					{
+						Line:     3,
+						Severity: "warning",
+						Message:  "[MEDIUM] S3 Bucket Without Versioning: 'versioning' is undefined or null",
+						Source:   "kics.568a4d22-3517-44a6-a7ad-6a7eed88722c",
+					},
+				},
+			},
+			{
+				Name: "main.tf",
+				Errors: []checkstyleError{
+					{
+						Line:     1,
+						Severity: "error",
+						Message:  "[HIGH] S3 Bucket ACL Allows Read Or Write to All Users: 'acl' is undefined",
+						Source:   "kics.38c5ee0d-7f22-4260-ab72-5073048df100",
+					},
+					{
+						Line:     16,
+						Severity: "error",
+						Message:  "[HIGH] S3 Bucket ACL Allows Read Or Write to All Users: 'acl' is equal 'public-read'",
+						Source:   "kics.38c5ee0d-7f22-4260-ab72-5073048df100",
+					},
+					{
+						Line:     24,
+						Severity: "warning",
+						Message:  "[MEDIUM] S3 Bucket Without Versioning: 'versioning.enabled' is set to false",
+						Source:   "kics.568a4d22-3517-44a6-a7ad-6a7eed88722c",
+					},
+				},
+			},
+		},
+	}
+
+	require.Equal(t, want, BuildCheckstyleReport(summary))
+}
@@ -0,0 +1,131 @@
+package model
+
+import (
+	"fmt"
+
+	"github.com/Checkmarx/kics/v2/internal/constants"
+	"github.com/Checkmarx/kics/v2/pkg/model"
+	"github.com/Checkmarx/kics/v2/pkg/remediation/fix"
+)
+
+const rdjsonSourceName = "KICS"
+
+var rdjsonSeverities = map[model.Severity]string{
+	model.SeverityCritical: "ERROR",
+	model.SeverityHigh:     "ERROR",
+	model.SeverityMedium:   "WARNING",
+	model.SeverityLow:      "INFO",
+	model.SeverityInfo:     "INFO",
+	model.SeverityTrace:    "INFO",
+}
+
+// RDJSONReport is the reviewdog diagnostic format (rdjson) report
+type RDJSONReport struct {
+	Source      rdjsonSource        `json:"source"`
+	Diagnostics []*RDJSONDiagnostic `json:"diagnostics"`
+}
+
+type rdjsonSource struct {
+	Name string `json:"name"`
+	URL  string `json:"url,omitempty"`
+}
+
+type rdjsonPosition struct {
+	Line   int `json:"line"`
+	Column int `json:"column,omitempty"`
+}
+
+type rdjsonRange struct {
+	Start rdjsonPosition  `json:"start"`
+	End   *rdjsonPosition `json:"end,omitempty"`
+}
+
+type rdjsonLocation struct {
+	Path  string      `json:"path"`
+	Range rdjsonRange `json:"range"`
+}
+
+type rdjsonCode struct {
+	Value string `json:"value"`
+	URL   string `json:"url,omitempty"`
+}
+
+type rdjsonSuggestion struct {
+	Range rdjsonRange `json:"range"`
+	Text  string      `json:"text"`
+}
+
+// RDJSONDiagnostic is a result of the rdjson report, also a line of the rdjsonl report
+type RDJSONDiagnostic struct {
+	Message     string             `json:"message"`
+	Location    rdjsonLocation     `json:"location"`
+	Severity    string             `json:"severity"`
+	Source      *rdjsonSource      `json:"source,omitempty"`
+	Code        rdjsonCode         `json:"code"`
+	Suggestions []rdjsonSuggestion `json:"suggestions,omitempty"`
+}
+
+// BuildRDJSONReport builds the rdjson report, results with a replacement remediation have it as a suggestion
+func BuildRDJSONReport(summary *model.Summary) *RDJSONReport {
+	return &RDJSONReport{
+		Source:      rdjsonSource{Name: rdjsonSourceName, URL: constants.URL},
+		Diagnostics: BuildRDJSONDiagnostics(summary),
+	}
+}
+
+// BuildRDJSONDiagnostics builds the diagnostics of the results, each one with its source so they can be written
+// as the lines of the rdjsonl report
+func BuildRDJSONDiagnostics(summary *model.Summary) []*RDJSONDiagnostic {
+	diagnostics := make([]*RDJSONDiagnostic, 0, summary.TotalCounter)
+	fileContents := make(fileContentCache)
+	for i := range summary.Queries {
+		query := &summary.Queries[i]
+		for j := range query.Files {
+			file := &query.Files[j]
+			line := file.Line
+			if line < 1 {
+				line = 1
+			}
+			message := file.KeyActualValue
+			if file.KeyExpectedValue != "" {
+				message += fmt.Sprintf("\nExpected: %s", file.KeyExpectedValue)
+			}
+			diagnostics = append(diagnostics, &RDJSONDiagnostic{
+				Message: fmt.Sprintf("[%s] %s: %s", query.Severity, query.QueryName, message),
+				Location: rdjsonLocation{
+					Path:  file.FileName,
+					Range: rdjsonRange{Start: rdjsonPosition{Line: line}},
+				},
+				Severity:    rdjsonSeverities[query.Severity],
+				Source:      &rdjsonSource{Name: rdjsonSourceName, URL: constants.URL},
+				Code:        rdjsonCode{Value: query.QueryID, URL: query.QueryURI},
+				Suggestions: rdjsonSuggestions(file, fileContents),
+			})
+		}
+	}
+	return diagnostics
+}
+
+// rdjsonSuggestions suggests the replacement remediation of a result, reviewdog shows it as a suggested change
+func rdjsonSuggestions(file *model.VulnerableFile, fileContents fileContentCache) []rdjsonSuggestion {
+	if file.RemediationType != fix.TypeReplacement || file.Remediation == "" {
+		return nil
+	}
+	content, ok := fileContents.get(file.FileName)
+	if !ok {
+		return nil
+	}
+	edit, ok := locateRemediation(file, content)
+	if !ok {
+		return nil
+	}
+	return []rdjsonSuggestion{
+		{
+			Range: rdjsonRange{
+				Start: rdjsonPosition{Line: edit.startLine, Column: edit.startColumn},
+				End:   &rdjsonPosition{Line: edit.endLine, Column: edit.endColumn},
+			},
+			Text: edit.text,
+		},
+	}
+}
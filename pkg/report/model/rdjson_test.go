@@ -0,0 +1,84 @@
+package model
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Checkmarx/kics/v2/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildRDJSONReport(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "main.tf")
+	require.NoError(t, os.WriteFile(fileName, []byte(sarifFixesContent), 0600))
+
+	summary := &model.Summary{
+		Queries: model.QueryResultSlice{
+			{
+				QueryName: "S3 Bucket ACL Allows Read Or Write to All Users",
+				QueryID:   "38c5ee0d-7f22-4260-ab72-5073048df100",
+				QueryURI:  "https://docs.kics.io/s3",
+				Severity:  model.SeverityHigh,
+				Files: []model.VulnerableFile{
+					{
+						FileName:         fileName,
+						Line:             3,
+						KeyActualValue:   "'acl' is equal 'public-read'",
+						KeyExpectedValue: "'acl' should equal 'private'",
+						Remediation:      `{"after":"private","before":"public-read"}`,
+						RemediationType:  "replacement",
+					},
+				},
+			},
+			{
+				QueryName: "S3 Bucket Logging Disabled",
+				QueryID:   "f861041c-8c9f-4156-acfc-5e6e524f5884",
+				Severity:  model.SeverityLow,
+				Files: []model.VulnerableFile{
+					{
+						FileName:        fileName,
+						Line:            1,
+						KeyActualValue:  "'logging' is undefined or null",
+						Remediation:     "logging {}",
+						RemediationType: "addition",
+					},
+				},
+			},
+		},
+	}
+
+	source := &rdjsonSource{Name: "KICS", URL: "https://www.kics.io/"}
+	want := &RDJSONReport{
+		Source: *source,
+		Diagnostics: []*RDJSONDiagnostic{
+			{
+				Message: "[HIGH] S3 Bucket ACL Allows Read Or Write to All Users: 'acl' is equal 'public-read'\n" +
+					"Expected: 'acl' should equal 'private'",
+				Location: rdjsonLocation{Path: fileName, Range: rdjsonRange{Start: rdjsonPosition{Line: 3}}},
+				Severity: "ERROR",
+				Source:   source,
+				Code:     rdjsonCode{Value: "38c5ee0d-7f22-4260-ab72-5073048df100", URL: "https://docs.kics.io/s3"},
+				Suggestions: []rdjsonSuggestion{
+					{
+						Range: rdjsonRange{
+							Start: rdjsonPosition{Line: 3, Column: 13},
+							End:   &rdjsonPosition{Line: 3, Column: 24},
+						},
+						Text: "private",
+					},
+				},
+			},
+			{
+				// only replacements are suggested
+				Message:  "[LOW] S3 Bucket Logging Disabled: 'logging' is undefined or null",
+				Location: rdjsonLocation{Path: fileName, Range: rdjsonRange{Start: rdjsonPosition{Line: 1}}},
+				Severity: "INFO",
+				Source:   source,
+				Code:     rdjsonCode{Value: "f861041c-8c9f-4156-acfc-5e6e524f5884"},
+			},
+		},
+	}
+
+	require.Equal(t, want, BuildRDJSONReport(summary))
+}
@@ -0,0 +1,76 @@
+package model
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Checkmarx/kics/v2/pkg/model"
+	"github.com/Checkmarx/kics/v2/pkg/remediation/fix"
+)
+
+// remediationEdit is the change of a remediation located in the file of its result, the same change done by
+// 'kics remediate'. Lines and columns start at 1, the end column is exclusive and the columns are counted in bytes
+type remediationEdit struct {
+	startLine   int
+	startColumn int
+	endLine     int
+	endColumn   int
+	text        string
+}
+
+// fileContentCache keeps the content of the files with results, read once for all their remediations
+type fileContentCache map[string]*string
+
+func (c fileContentCache) get(fileName string) (string, bool) {
+	if content, ok := c[fileName]; ok {
+		if content == nil {
+			return "", false
+		}
+		return *content, true
+	}
+	data, err := os.ReadFile(filepath.Clean(fileName))
+	if err != nil {
+		c[fileName] = nil
+		return "", false
+	}
+	content := string(data)
+	c[fileName] = &content
+	return content, true
+}
+
+// locateRemediation locates the remediation of a result in the content of its file. Results without remediation,
+// or whose remediation can not be found in the file, have no edit
+func locateRemediation(file *model.VulnerableFile, content string) (remediationEdit, bool) {
+	if file.Remediation == "" || file.Line < 1 {
+		return remediationEdit{}, false
+	}
+
+	edit, ok := fix.Locate(file.FileName, content, &fix.Remediation{
+		Type:         file.RemediationType,
+		Line:         file.Line,
+		Remediation:  file.Remediation,
+		SearchKey:    file.SearchKey,
+		SimilarityID: file.SimilarityID,
+	})
+	if !ok {
+		return remediationEdit{}, false
+	}
+
+	startLine, startColumn := offsetPosition(content, edit.Start)
+	endLine, endColumn := offsetPosition(content, edit.End)
+
+	return remediationEdit{
+		startLine:   startLine,
+		startColumn: startColumn,
+		endLine:     endLine,
+		endColumn:   endColumn,
+		text:        edit.Text,
+	}, true
+}
+
+// offsetPosition returns the line and the column, counted in bytes, of the offset of the content
+func offsetPosition(content string, offset int) (line, column int) {
+	before := content[:offset]
+	return strings.Count(before, "\n") + 1, offset - strings.LastIndex(before, "\n")
+}
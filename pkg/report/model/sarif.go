@@ -22,6 +22,19 @@ var severityLevelEquivalence = map[model.Severity]string{
 	"CRITICAL": "error",
 }
 
+// sarifSuppressionJustifications explains why each kind of suppressed result is not reported
+var sarifSuppressionJustifications = map[string]string{
+	model.SuppressionInSource: "Ignored by a kics-scan comment",
+	model.SuppressionExternal: "Excluded by --exclude-results",
+}
+
+// sarifBaselineStates maps the baseline states of the results to the SARIF ones
+var sarifBaselineStates = map[string]string{
+	model.BaselineStateNew:      "new",
+	model.BaselineStateExisting: "unchanged",
+	model.BaselineStateFixed:    "absent",
+}
+
 var targetTemplate = sarifDescriptorReference{
 	ToolComponent: sarifComponentReference{
 		ComponentReferenceGUID:  "58cdcc6f-fe41-4724-bfb3-131a93df4c3f",
@@ -144,12 +157,21 @@ type sarifLocation struct {
 	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
 }
 
+type sarifSuppression struct {
+	Kind          string `json:"kind"`
+	Justification string `json:"justification,omitempty"`
+}
+
 type sarifResult struct {
-	ResultRuleID    string          `json:"ruleId"`
-	ResultRuleIndex int             `json:"ruleIndex"`
-	ResultKind      string          `json:"kind"`
-	ResultMessage   sarifMessage    `json:"message"`
-	ResultLocations []sarifLocation `json:"locations"`
+	ResultRuleID        string             `json:"ruleId"`
+	ResultRuleIndex     int                `json:"ruleIndex"`
+	ResultKind          string             `json:"kind"`
+	ResultMessage       sarifMessage       `json:"message"`
+	ResultLocations     []sarifLocation    `json:"locations"`
+	PartialFingerprints map[string]string  `json:"partialFingerprints,omitempty"`
+	BaselineState       string             `json:"baselineState,omitempty"`
+	Fixes               []sarifFix         `json:"fixes,omitempty"`
+	Suppressions        []sarifSuppression `json:"suppressions,omitempty"`
 }
 
 type taxonomyDefinitions struct {
@@ -192,12 +214,15 @@ type SarifReport interface {
 	BuildSarifIssue(issue *model.QueryResult) string
 	RebuildTaxonomies(cwes []string, guids map[string]string)
 	GetGUIDFromRelationships(idx int, cweID string) string
+	GetRuleIndex(ruleID string) int
 }
 
 type sarifReport struct {
 	Schema       string     `json:"$schema"`
 	SarifVersion string     `json:"version"`
 	Runs         []SarifRun `json:"runs"`
+	// fileContents caches the content of the files with results, used to locate the fixes
+	fileContents fileContentCache
 }
 
 func initSarifTool() sarifTool {
@@ -339,6 +364,7 @@ func NewSarifReport() SarifReport {
 		Schema:       "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json",
 		SarifVersion: "2.1.0",
 		Runs:         initSarifRun(),
+		fileContents: make(fileContentCache),
 	}
 }
 
@@ -522,6 +548,11 @@ func (sr *sarifReport) findSarifRuleIndex(ruleID string) int {
 	return -1
 }
 
+// GetRuleIndex gets the index of the rule of a query, -1 if the rule was not built
+func (sr *sarifReport) GetRuleIndex(ruleID string) int {
+	return sr.findSarifRuleIndex(ruleID)
+}
+
 func (sr *sarifReport) buildSarifRule(queryMetadata *ruleMetadata, cisMetadata ruleCISMetadata) int {
 	index := sr.findSarifRuleIndex(queryMetadata.queryID)
 
@@ -601,7 +632,8 @@ func (sr *sarifReport) RebuildTaxonomies(cwes []string, guids map[string]string)
 	}
 }
 
-// BuildSarifIssue creates a new entries in Results (one for each file) and new entry in Rules and Taxonomy if necessary
+// BuildSarifIssue creates a new entries in Results (one for each file) and new entry in Rules and Taxonomy if necessary.
+// Files with a suppression, ignored by a comment or excluded, are added with it so viewers hide them
 func (sr *sarifReport) BuildSarifIssue(issue *model.QueryResult) string {
 	if len(issue.Files) > 0 {
 		metadata := ruleMetadata{
@@ -625,7 +657,8 @@ func (sr *sarifReport) BuildSarifIssue(issue *model.QueryResult) string {
 			kind = "informational"
 		}
 		for idx := range issue.Files {
-			line := issue.Files[idx].Line
+			file := &issue.Files[idx]
+			line := file.Line
 			if line < 1 {
 				line = 1
 			}
@@ -634,7 +667,7 @@ func (sr *sarifReport) BuildSarifIssue(issue *model.QueryResult) string {
 				ResultRuleIndex: ruleIndex,
 				ResultKind:      kind,
 				ResultMessage: sarifMessage{
-					Text: issue.Files[idx].KeyActualValue,
+					Text: file.KeyActualValue,
 					MessageProperties: sarifProperties{
 						"platform": issue.Platform,
 					},
@@ -642,11 +675,26 @@ func (sr *sarifReport) BuildSarifIssue(issue *model.QueryResult) string {
 				ResultLocations: []sarifLocation{
 					{
 						PhysicalLocation: sarifPhysicalLocation{
-							ArtifactLocation: sarifArtifactLocation{ArtifactURI: issue.Files[idx].FileName},
+							ArtifactLocation: sarifArtifactLocation{ArtifactURI: file.FileName},
 							Region:           sarifRegion{StartLine: line},
 						},
 					},
 				},
+				BaselineState: sarifBaselineStates[file.BaselineState],
+			}
+			if file.SimilarityID != "" {
+				result.PartialFingerprints = map[string]string{sarifSimilarityFingerprint: file.SimilarityID}
+			}
+			if fix, ok := sr.buildSarifFix(file); ok {
+				result.Fixes = []sarifFix{fix}
+			}
+			if file.Suppression != "" {
+				result.Suppressions = []sarifSuppression{
+					{
+						Kind:          file.Suppression,
+						Justification: sarifSuppressionJustifications[file.Suppression],
+					},
+				}
 			}
 			sr.Runs[0].Results = append(sr.Runs[0].Results, result)
 		}
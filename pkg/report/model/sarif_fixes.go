@@ -0,0 +1,75 @@
+package model
+
+import (
+	"fmt"
+
+	"github.com/Checkmarx/kics/v2/pkg/model"
+)
+
+// sarifSimilarityFingerprint is the partial fingerprint with the similarity ID of the result, it keeps matching
+// the result when lines are added or removed above it
+const sarifSimilarityFingerprint = "similarityId/v1"
+
+type sarifArtifactContent struct {
+	Text string `json:"text"`
+}
+
+type sarifFixRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn"`
+	EndLine     int `json:"endLine"`
+	EndColumn   int `json:"endColumn"`
+}
+
+type sarifReplacement struct {
+	DeletedRegion   sarifFixRegion        `json:"deletedRegion"`
+	InsertedContent *sarifArtifactContent `json:"insertedContent,omitempty"`
+}
+
+type sarifArtifactChange struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Replacements     []sarifReplacement    `json:"replacements"`
+}
+
+type sarifFix struct {
+	Description     sarifMessage          `json:"description"`
+	ArtifactChanges []sarifArtifactChange `json:"artifactChanges"`
+}
+
+// buildSarifFix builds the fix of a result from its replacement or addition remediation
+func (sr *sarifReport) buildSarifFix(file *model.VulnerableFile) (sarifFix, bool) {
+	if file.Remediation == "" {
+		return sarifFix{}, false
+	}
+	if sr.fileContents == nil {
+		sr.fileContents = make(fileContentCache)
+	}
+	content, ok := sr.fileContents.get(file.FileName)
+	if !ok {
+		return sarifFix{}, false
+	}
+	edit, ok := locateRemediation(file, content)
+	if !ok {
+		return sarifFix{}, false
+	}
+
+	return sarifFix{
+		Description: sarifMessage{Text: fmt.Sprintf("Expected: %s", file.KeyExpectedValue)},
+		ArtifactChanges: []sarifArtifactChange{
+			{
+				ArtifactLocation: sarifArtifactLocation{ArtifactURI: file.FileName},
+				Replacements: []sarifReplacement{
+					{
+						DeletedRegion: sarifFixRegion{
+							StartLine:   edit.startLine,
+							StartColumn: edit.startColumn,
+							EndLine:     edit.endLine,
+							EndColumn:   edit.endColumn,
+						},
+						InsertedContent: &sarifArtifactContent{Text: edit.text},
+					},
+				},
+			},
+		},
+	}, true
+}
@@ -0,0 +1,127 @@
+package model
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Checkmarx/kics/v2/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+const sarifFixesContent = `resource "aws_s3_bucket" "b" {
+  bucket = "my-tf-test-bucket"
+  acl    = "public-read"
+
+  versioning {
+    enabled = false
+  }
+}
+`
+
+func TestBuildSarifFix(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "main.tf")
+	require.NoError(t, os.WriteFile(fileName, []byte(sarifFixesContent), 0600))
+
+	tests := []struct {
+		name string
+		file model.VulnerableFile
+		want *sarifReplacement
+	}{
+		{
+			name: "replacement",
+			file: model.VulnerableFile{
+				FileName:        fileName,
+				Line:            3,
+				Remediation:     `{"after":"private","before":"public-read"}`,
+				RemediationType: "replacement",
+			},
+			want: &sarifReplacement{
+				DeletedRegion:   sarifFixRegion{StartLine: 3, StartColumn: 13, EndLine: 3, EndColumn: 24},
+				InsertedContent: &sarifArtifactContent{Text: "private"},
+			},
+		},
+		{
+			name: "addition",
+			file: model.VulnerableFile{
+				FileName:        fileName,
+				Line:            1,
+				Remediation:     "logging {\n  target_bucket = \"logs\"\n}",
+				RemediationType: "addition",
+			},
+			want: &sarifReplacement{
+				DeletedRegion:   sarifFixRegion{StartLine: 8, StartColumn: 1, EndLine: 8, EndColumn: 1},
+				InsertedContent: &sarifArtifactContent{Text: "  logging {\n    target_bucket = \"logs\"\n  }\n"},
+			},
+		},
+		{
+			name: "replacement not found in the line",
+			file: model.VulnerableFile{
+				FileName:        fileName,
+				Line:            2,
+				Remediation:     `{"after":"private","before":"public-read"}`,
+				RemediationType: "replacement",
+			},
+		},
+		{
+			name: "addition already done",
+			file: model.VulnerableFile{
+				FileName:        fileName,
+				Line:            1,
+				Remediation:     `bucket = "my-tf-test-bucket"`,
+				RemediationType: "addition",
+			},
+		},
+		{
+			name: "without remediation",
+			file: model.VulnerableFile{FileName: fileName, Line: 3},
+		},
+		{
+			name: "missing file",
+			file: model.VulnerableFile{
+				FileName:        filepath.Join(t.TempDir(), "missing.tf"),
+				Line:            3,
+				Remediation:     `{"after":"private","before":"public-read"}`,
+				RemediationType: "replacement",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sr := NewSarifReport().(*sarifReport)
+			fix, ok := sr.buildSarifFix(&tt.file)
+			if tt.want == nil {
+				require.False(t, ok)
+				return
+			}
+			require.True(t, ok)
+			require.Len(t, fix.ArtifactChanges, 1)
+			require.Equal(t, tt.file.FileName, fix.ArtifactChanges[0].ArtifactLocation.ArtifactURI)
+			require.Equal(t, []sarifReplacement{*tt.want}, fix.ArtifactChanges[0].Replacements)
+		})
+	}
+}
+
+func TestBuildSarifIssue_Suppressions(t *testing.T) {
+	sr := NewSarifReport().(*sarifReport)
+	sr.BuildSarifIssue(&model.QueryResult{
+		QueryName: "test",
+		QueryID:   "1",
+		Severity:  model.SeverityHigh,
+		Files: []model.VulnerableFile{
+			{FileName: "main.tf", Line: 1, SimilarityID: "simID1"},
+			{FileName: "main.tf", Line: 2, SimilarityID: "simID2", Suppression: model.SuppressionInSource},
+			{FileName: "main.tf", Line: 3, SimilarityID: "simID3", Suppression: model.SuppressionExternal},
+		},
+	})
+
+	results := sr.Runs[0].Results
+	require.Len(t, results, 3)
+	require.Equal(t, map[string]string{"similarityId/v1": "simID1"}, results[0].PartialFingerprints)
+	require.Empty(t, results[0].Suppressions)
+	require.Equal(t, []sarifSuppression{{Kind: "inSource", Justification: "Ignored by a kics-scan comment"}},
+		results[1].Suppressions)
+	require.Equal(t, []sarifSuppression{{Kind: "external", Justification: "Excluded by --exclude-results"}},
+		results[2].Suppressions)
+}
@@ -0,0 +1,69 @@
+package report
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Checkmarx/kics/v2/pkg/model"
+	reportModel "github.com/Checkmarx/kics/v2/pkg/report/model"
+)
+
+const rdjsonPrefix = "rdjson-"
+
+// PrintRDJSONReport prints the reviewdog diagnostic format (rdjson) report in the given path and filename with the
+// given body
+func PrintRDJSONReport(path, filename string, body interface{}) error {
+	if !strings.HasPrefix(filename, rdjsonPrefix) {
+		filename = rdjsonPrefix + filename
+	}
+
+	summary := model.Summary{}
+	if body != "" {
+		var err error
+		summary, err = getSummary(body)
+		if err != nil {
+			return err
+		}
+	}
+
+	return ExportJSONReport(path, filename, reportModel.BuildRDJSONReport(&summary))
+}
+
+// PrintRDJSONLReport prints the reviewdog diagnostic format report with a diagnostic per line (rdjsonl) in the given
+// path and filename with the given body
+func PrintRDJSONLReport(path, filename string, body interface{}) error {
+	if !strings.HasPrefix(filename, rdjsonPrefix) {
+		filename = rdjsonPrefix + filename
+	}
+	if !strings.HasSuffix(filename, jsonlExtension) {
+		filename += jsonlExtension
+	}
+
+	summary := model.Summary{}
+	if body != "" {
+		var err error
+		summary, err = getSummary(body)
+		if err != nil {
+			return err
+		}
+	}
+
+	fullPath := filepath.Join(path, filename)
+	f, err := os.OpenFile(filepath.Clean(fullPath), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, os.ModePerm)
+	if err != nil {
+		return err
+	}
+	defer closeFile(fullPath, filename, f)
+
+	writer := bufio.NewWriter(f)
+	encoder := json.NewEncoder(writer)
+	for _, diagnostic := range reportModel.BuildRDJSONDiagnostics(&summary) {
+		if err := encoder.Encode(diagnostic); err != nil {
+			return err
+		}
+	}
+	return writer.Flush()
+}
@@ -0,0 +1,41 @@
+package report
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	reportModel "github.com/Checkmarx/kics/v2/pkg/report/model"
+	"github.com/Checkmarx/kics/v2/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrintRDJSONReport(t *testing.T) {
+	path := t.TempDir()
+
+	require.NoError(t, PrintRDJSONReport(path, "results", test.SummaryMock))
+
+	content, err := os.ReadFile(filepath.Join(path, "rdjson-results.json"))
+	require.NoError(t, err)
+	var report reportModel.RDJSONReport
+	require.NoError(t, json.Unmarshal(content, &report))
+	require.Len(t, report.Diagnostics, test.SummaryMock.TotalCounter)
+}
+
+func TestPrintRDJSONLReport(t *testing.T) {
+	path := t.TempDir()
+
+	require.NoError(t, PrintRDJSONLReport(path, "results", test.SummaryMock))
+
+	content, err := os.ReadFile(filepath.Join(path, "rdjson-results.jsonl"))
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	require.Len(t, lines, test.SummaryMock.TotalCounter)
+	for _, line := range lines {
+		var diagnostic reportModel.RDJSONDiagnostic
+		require.NoError(t, json.Unmarshal([]byte(line), &diagnostic))
+		require.NotEmpty(t, diagnostic.Code.Value)
+	}
+}
@@ -28,6 +28,13 @@ func PrintSarifReport(path, filename string, body interface{}) error {
 				auxGUID[x] = guid
 			}
 		}
+		for idx := range summary.Suppressed {
+			x := sarifReport.BuildSarifIssue(&summary.Suppressed[idx])
+			if _, exists := auxGUID[x]; x != "" && !exists {
+				auxID = append(auxID, x)
+				auxGUID[x] = sarifReport.GetGUIDFromRelationships(sarifReport.GetRuleIndex(summary.Suppressed[idx].QueryID), x)
+			}
+		}
 		sarifReport.RebuildTaxonomies(auxID, auxGUID)
 		body = sarifReport
 	}
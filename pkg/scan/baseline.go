@@ -0,0 +1,37 @@
+package scan
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/Checkmarx/kics/v2/pkg/model"
+	"github.com/Checkmarx/kics/v2/pkg/report"
+	"github.com/rs/zerolog/log"
+)
+
+// applyBaseline compares the summary with the baseline report on path
+func applyBaseline(summary *model.Summary, path string) error {
+	baseline, err := report.ReadJSONReport(path)
+	if err != nil {
+		return err
+	}
+	model.ApplyBaseline(summary, baseline, path)
+	log.Info().Msgf("Baseline results: %d new, %d existing, %d fixed",
+		summary.Baseline.NewCounter, summary.Baseline.ExistingCounter, summary.Baseline.FixedCounter)
+	return nil
+}
+
+// writeBaseline exports the summary as a JSON report to be used as baseline on later scans
+func writeBaseline(summary *model.Summary, path string) error {
+	baseline := *summary
+	baseline.Baseline = nil
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return err
+	}
+	if err := report.PrintJSONReport(filepath.Dir(path), filepath.Base(path), &baseline); err != nil {
+		return fmt.Errorf("failed to write baseline %s: %w", path, err)
+	}
+	log.Info().Msgf("Baseline written to %s", path)
+	return nil
+}
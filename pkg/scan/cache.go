@@ -0,0 +1,56 @@
+package scan
+
+import (
+	"os"
+	"strconv"
+
+	"github.com/Checkmarx/kics/v2/pkg/cache"
+	"github.com/Checkmarx/kics/v2/pkg/model"
+	"github.com/rs/zerolog/log"
+)
+
+// initCache opens the cache of parsed files and query results, the scan runs without it when it can not be opened
+func (c *Client) initCache() {
+	if c.ScanParams.CachePath == "" {
+		return
+	}
+
+	scanCache, err := cache.New(c.ScanParams.CachePath, c.cacheOptions()...)
+	if err != nil {
+		log.Warn().Msgf("Scanning without cache, failed to open %s: %s", c.ScanParams.CachePath, err)
+		return
+	}
+	c.cache = scanCache
+}
+
+// cacheOptions returns the scan parameters that change the documents produced by the parsers
+func (c *Client) cacheOptions() []string {
+	options := []string{
+		strconv.FormatBool(c.ScanParams.TerraformExpandResources),
+		strconv.FormatBool(c.ScanParams.OpenAPIResolveReferences),
+		strconv.Itoa(c.ScanParams.MaxResolverDepth),
+	}
+
+	for _, path := range []string{c.ScanParams.TerraformVarsPath, os.Getenv("ANSIBLE_VAULT_PASSWORD_FILE")} {
+		var content []byte
+		if path != "" {
+			content, _ = os.ReadFile(path)
+		}
+		options = append(options, path, string(content))
+	}
+
+	return options
+}
+
+// setCacheCounters adds the files and query results found on the cache to the counters
+func (c *Client) setCacheCounters(counters *model.Counters) {
+	if c.cache == nil {
+		return
+	}
+
+	stats := c.cache.Stats()
+	counters.CacheFileHits = stats.FileHits
+	counters.CacheFileMisses = stats.FileMisses
+	counters.CacheResultHits = stats.ResultHits
+	counters.CacheResultMisses = stats.ResultMisses
+}
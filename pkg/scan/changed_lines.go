@@ -0,0 +1,59 @@
+package scan
+
+import (
+	"context"
+
+	"github.com/Checkmarx/kics/v2/pkg/git"
+	"github.com/Checkmarx/kics/v2/pkg/model"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+// readChangedLines reads the lines added or modified by the diff file, with its paths relative to the local git
+// repository of the first scan path, or, when a git reference is given, by the changes of that repository
+func (c *Client) readChangedLines(ctx context.Context) error {
+	var err error
+	regularPaths, _ := extractPathType(c.ScanParams.Path)
+	switch {
+	case c.ScanParams.ChangedLinesDiff != "":
+		repositoryPath := "."
+		if len(regularPaths) > 0 {
+			repositoryPath = regularPaths[0]
+		}
+		c.changedLines, err = git.ReadDiffFile(ctx, c.ScanParams.ChangedLinesDiff, repositoryPath)
+	case c.ScanParams.ChangedLinesSince != "":
+		if len(regularPaths) == 0 {
+			return errors.New("changed lines of a git reference can only be read for local paths")
+		}
+		c.changedLines, err = git.ChangedLinesSince(ctx, regularPaths[0], c.ScanParams.ChangedLinesSince)
+	default:
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, "failed to read changed lines")
+	}
+	log.Info().Msgf("Only reporting results on the changed lines of %d files", len(c.changedLines))
+	return nil
+}
+
+// filterChangedLines splits the results in the ones on changed lines and the ones outside of the diff
+func filterChangedLines(results []model.Vulnerability, changedLines git.ChangedLines) (inside, outside []model.Vulnerability) {
+	inside = make([]model.Vulnerability, 0, len(results))
+	outside = make([]model.Vulnerability, 0)
+	for i := range results {
+		if changedLines.Contains(results[i].FileName, results[i].Line) {
+			inside = append(inside, results[i])
+		} else {
+			outside = append(outside, results[i])
+		}
+	}
+	return inside, outside
+}
+
+// setOutsideDiff adds the results outside of the diff to their own section of the summary
+func (c *Client) setOutsideDiff(summary *model.Summary, outside []model.Vulnerability,
+	pathExtractionMap map[string]model.ExtractedPathObject) {
+	outsideSummary := model.CreateSummary(model.Counters{}, outside, c.ScanParams.ScanID, pathExtractionMap, c.Tracker.Version)
+	summary.OutsideDiff = outsideSummary.Queries
+	summary.ResultsOutsideDiff = outsideSummary.TotalCounter
+}
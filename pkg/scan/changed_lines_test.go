@@ -0,0 +1,40 @@
+package scan
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/Checkmarx/kics/v2/internal/tracker"
+	"github.com/Checkmarx/kics/v2/pkg/git"
+	"github.com/Checkmarx/kics/v2/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_filterChangedLines(t *testing.T) {
+	root, err := filepath.Abs("project")
+	require.NoError(t, err)
+	results := []model.Vulnerability{
+		{SimilarityID: "1", QueryID: "query", Severity: model.SeverityHigh, FileName: filepath.Join(root, "main.tf"), Line: 2},
+		{SimilarityID: "2", QueryID: "query", Severity: model.SeverityHigh, FileName: filepath.Join(root, "main.tf"), Line: 5},
+		{SimilarityID: "3", QueryID: "query", Severity: model.SeverityLow, FileName: filepath.Join(root, "other.tf"), Line: 2},
+		{SimilarityID: "4", QueryID: "query", Severity: model.SeverityLow, FileName: filepath.Join(root, "modules", "x", "main.tf"), Line: 2},
+	}
+	changedLines := git.ChangedLines{
+		filepath.ToSlash(filepath.Join(root, "main.tf")): {{Start: 1, End: 3}},
+	}
+
+	inside, outside := filterChangedLines(results, changedLines)
+	require.Len(t, inside, 1)
+	require.Equal(t, "1", inside[0].SimilarityID)
+	require.Len(t, outside, 3)
+
+	c := &Client{
+		ScanParams: &Parameters{ScanID: "console"},
+		Tracker:    &tracker.CITracker{},
+	}
+	summary := model.Summary{}
+	c.setOutsideDiff(&summary, outside, map[string]model.ExtractedPathObject{})
+	require.Equal(t, 3, summary.ResultsOutsideDiff)
+	require.Len(t, summary.OutsideDiff, 1)
+	require.Len(t, summary.OutsideDiff[0].Files, 3)
+}
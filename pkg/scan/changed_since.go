@@ -0,0 +1,273 @@
+package scan
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Checkmarx/kics/v2/pkg/git"
+	"github.com/Checkmarx/kics/v2/pkg/model"
+	"github.com/Checkmarx/kics/v2/pkg/utils"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	// terraformModuleExtensions are the extensions of the files that are evaluated together in a terraform module
+	terraformModuleExtensions = []string{".tf", ".tfvars"}
+	// referenceExtensions are the extensions of the files whose references are resolved by the file resolver
+	referenceExtensions = []string{".json", ".yaml", ".yml"}
+)
+
+// changedScanPaths restricts the scan paths to what changed in the local git repository since ref. Besides the changed
+// files, the files of the same terraform module, the files referencing a changed file and the helm charts containing
+// them are scanned, so the results of the changed files are the same as in a full scan
+func changedScanPaths(ctx context.Context, paths []string, ref string, maxResolverDepth int) (
+	targets []string, changedSince *model.ChangedSince, err error) {
+	targetSet := make(map[string]bool)
+	changedSince = &model.ChangedSince{
+		Ref:          ref,
+		ChangedFiles: make([]string, 0),
+		ScannedPaths: make([]string, 0),
+	}
+	var root string
+
+	for _, path := range paths {
+		changes, err := git.ChangedFiles(ctx, path, ref)
+		if err != nil {
+			return nil, nil, err
+		}
+		if root == "" {
+			root, changedSince.Base = changes.Root, changes.Base
+		} else if root != changes.Root {
+			return nil, nil, errors.Errorf("scan paths must belong to the same git repository, found %s and %s",
+				root, changes.Root)
+		}
+
+		changed, err := changedInPath(path, changes)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, file := range changed {
+			changedSince.ChangedFiles = append(changedSince.ChangedFiles, relativeToRoot(path, file, changes.Root))
+		}
+
+		for _, target := range expandChanges(path, changed, maxResolverDepth) {
+			if !targetSet[target] {
+				targetSet[target] = true
+				targets = append(targets, target)
+				changedSince.ScannedPaths = append(changedSince.ScannedPaths, relativeToRoot(path, target, changes.Root))
+			}
+		}
+	}
+
+	sort.Strings(targets)
+	sort.Strings(changedSince.ChangedFiles)
+	sort.Strings(changedSince.ScannedPaths)
+
+	log.Info().Msgf("Scanning %d paths for %d files changed since %s",
+		len(targets), len(changedSince.ChangedFiles), ref)
+
+	return targets, changedSince, nil
+}
+
+// changedInPath returns the changed files inside the scan path, using the scan path as their prefix
+func changedInPath(path string, changes *git.Changes) ([]string, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	realPath, err := filepath.EvalSymlinks(absPath)
+	if err != nil {
+		return nil, err
+	}
+
+	changed := make([]string, 0)
+	for _, file := range changes.Files {
+		rel, err := filepath.Rel(realPath, file)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(os.PathSeparator)) {
+			continue
+		}
+		changed = append(changed, filepath.Join(path, rel))
+	}
+	return changed, nil
+}
+
+// relativeToRoot returns the path of a file of the scan path relative to the repository root
+func relativeToRoot(path, file, root string) string {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return filepath.ToSlash(file)
+	}
+	realPath, err := filepath.EvalSymlinks(absPath)
+	if err != nil {
+		return filepath.ToSlash(file)
+	}
+	prefix, err := filepath.Rel(root, realPath)
+	if err != nil {
+		return filepath.ToSlash(file)
+	}
+	suffix, err := filepath.Rel(path, file)
+	if err != nil {
+		return filepath.ToSlash(file)
+	}
+	return filepath.ToSlash(filepath.Join(prefix, suffix))
+}
+
+// expandChanges returns the existing files and helm charts to scan for the changed files of a scan path
+func expandChanges(path string, changed []string, maxResolverDepth int) []string {
+	if info, err := os.Stat(path); err == nil && !info.IsDir() {
+		// a file scan path is only scanned when changed, the files around it are not part of the scan
+		if len(changed) > 0 {
+			return []string{path}
+		}
+		return nil
+	}
+
+	files := make(map[string]bool)
+	for _, file := range changed {
+		files[file] = true
+		if utils.Contains(filepath.Ext(file), terraformModuleExtensions) {
+			for _, moduleFile := range terraformModuleFiles(filepath.Dir(file)) {
+				files[moduleFile] = true
+			}
+		}
+	}
+
+	for _, file := range referencingFiles(path, changed, maxResolverDepth) {
+		files[file] = true
+	}
+
+	targets := make([]string, 0, len(files))
+	seen := make(map[string]bool)
+	for file := range files {
+		if info, err := os.Stat(file); err != nil || info.IsDir() {
+			continue
+		}
+		target := file
+		if chart := helmChartRoot(path, file); chart != "" {
+			target = chart
+		}
+		if !seen[target] {
+			seen[target] = true
+			targets = append(targets, target)
+		}
+	}
+	return targets
+}
+
+func terraformModuleFiles(dir string) []string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	files := make([]string, 0)
+	for _, entry := range entries {
+		if !entry.IsDir() && utils.Contains(filepath.Ext(entry.Name()), terraformModuleExtensions) {
+			files = append(files, filepath.Join(dir, entry.Name()))
+		}
+	}
+	return files
+}
+
+// helmChartRoot returns the outermost directory of the scan path containing a Chart.yaml file and the given file,
+// the whole chart has to be rendered for any of its files to be scanned
+func helmChartRoot(path, file string) string {
+	chart := ""
+	cleanPath := filepath.Clean(path)
+	for dir := filepath.Dir(file); ; dir = filepath.Dir(dir) {
+		if _, err := os.Stat(filepath.Join(dir, "Chart.yaml")); err == nil {
+			chart = dir
+		}
+		if dir == cleanPath || dir == filepath.Dir(dir) {
+			return chart
+		}
+	}
+}
+
+// referencingFiles returns the files of the scan path that reference, directly or through other files, any of the
+// changed files. As in the file resolver, references are paths relative to the directory of the referencing file
+func referencingFiles(path string, changed []string, maxResolverDepth int) []string {
+	pending := make([]string, 0)
+	for _, file := range changed {
+		if utils.Contains(filepath.Ext(file), referenceExtensions) {
+			pending = append(pending, file)
+		}
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+
+	candidates := readReferenceCandidates(path)
+	found := make(map[string]bool)
+	for _, file := range pending {
+		found[file] = true
+	}
+
+	referencing := make([]string, 0)
+	for depth := 0; depth < maxResolverDepth && len(pending) > 0; depth++ {
+		next := make([]string, 0)
+		for candidate, content := range candidates {
+			if found[candidate] {
+				continue
+			}
+			for _, file := range pending {
+				if references(candidate, content, file) {
+					found[candidate] = true
+					referencing = append(referencing, candidate)
+					next = append(next, candidate)
+					break
+				}
+			}
+		}
+		pending = next
+	}
+	return referencing
+}
+
+func readReferenceCandidates(path string) map[string][]byte {
+	candidates := make(map[string][]byte)
+	if err := filepath.Walk(path, func(file string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil
+		}
+		if info.IsDir() {
+			if info.Name() == ".git" || strings.HasPrefix(info.Name(), ".terra") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !utils.Contains(filepath.Ext(file), referenceExtensions) {
+			return nil
+		}
+		content, err := os.ReadFile(filepath.Clean(file))
+		if err != nil {
+			log.Debug().Msgf("Failed to read %s looking for references: %s", file, err)
+			return nil
+		}
+		candidates[file] = content
+		return nil
+	}); err != nil {
+		log.Err(err).Msgf("Failed to look for references in %s", path)
+	}
+	return candidates
+}
+
+// references checks if the content of the candidate file mentions the path of file relative to the candidate
+func references(candidate string, content []byte, file string) bool {
+	rel, err := filepath.Rel(filepath.Dir(candidate), file)
+	if err != nil {
+		return false
+	}
+	rel = filepath.ToSlash(rel)
+	// most candidates do not mention the file, so they are skipped before compiling the pattern
+	if !bytes.Contains(content, []byte(rel)) {
+		return false
+	}
+	referenceRegex := regexp.MustCompile(`(^|[^\w./-])(\./)?` + regexp.QuoteMeta(rel) + `($|[^\w.-])`)
+	return referenceRegex.Match(content)
+}
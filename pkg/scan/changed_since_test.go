@@ -0,0 +1,130 @@
+package scan
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func writeTestFiles(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), os.ModePerm))
+		require.NoError(t, os.WriteFile(path, []byte(content), os.ModePerm))
+	}
+}
+
+func gitCommand(t *testing.T, dir string, args ...string) {
+	cmd := exec.Command("git", append([]string{"-c", "user.name=kics", "-c", "user.email=kics@checkmarx.com"}, args...)...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+}
+
+func Test_changedScanPaths(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	dir := t.TempDir()
+	writeTestFiles(t, dir, map[string]string{
+		"terraform/main.tf":               "resource \"aws_s3_bucket\" \"b\" {}",
+		"terraform/variables.tf":          "variable \"name\" {}",
+		"terraform/terraform.tfvars":      "name = \"bucket\"",
+		"terraform/other/main.tf":         "resource \"aws_s3_bucket\" \"c\" {}",
+		"openapi/api.yaml":                "paths:\n  /users:\n    $ref: './paths/users.yaml'\n",
+		"openapi/paths/users.yaml":        "get:\n  $ref: '../schemas/user.json#/User'\n",
+		"openapi/schemas/user.json":       "{\"User\": {}}",
+		"openapi/schemas/other_user.json": "{\"User\": {}}",
+		"openapi/unrelated.yaml":          "$ref: 'schemas/other_user.json'\n",
+		"chart/Chart.yaml":                "name: chart",
+		"chart/values.yaml":               "replicas: 1",
+		"chart/templates/deployment.yaml": "kind: Deployment",
+		"Dockerfile":                      "FROM alpine",
+	})
+	gitCommand(t, dir, "init", "-q")
+	gitCommand(t, dir, "add", "-A")
+	gitCommand(t, dir, "commit", "-q", "-m", "base")
+
+	writeTestFiles(t, dir, map[string]string{
+		"terraform/terraform.tfvars": "name = \"other\"",
+		"openapi/schemas/user.json":  "{\"User\": {\"type\": \"object\"}}",
+		"chart/values.yaml":          "replicas: 2",
+	})
+
+	targets, changedSince, err := changedScanPaths(context.Background(), []string{dir}, "HEAD", 15)
+	require.NoError(t, err)
+
+	require.Equal(t, "HEAD", changedSince.Ref)
+	require.Len(t, changedSince.Base, 40)
+	require.Equal(t, []string{
+		"chart/values.yaml",
+		"openapi/schemas/user.json",
+		"terraform/terraform.tfvars",
+	}, changedSince.ChangedFiles)
+	require.Equal(t, []string{
+		"chart",
+		"openapi/api.yaml",
+		"openapi/paths/users.yaml",
+		"openapi/schemas/user.json",
+		"terraform/main.tf",
+		"terraform/terraform.tfvars",
+		"terraform/variables.tf",
+	}, changedSince.ScannedPaths)
+	require.Contains(t, targets, filepath.Join(dir, "chart"))
+	require.Len(t, targets, 7)
+
+	// a file scan path is only scanned when it changed
+	targets, _, err = changedScanPaths(context.Background(), []string{filepath.Join(dir, "Dockerfile")}, "HEAD", 15)
+	require.NoError(t, err)
+	require.Empty(t, targets)
+
+	_, _, err = changedScanPaths(context.Background(), []string{dir}, "unknown", 15)
+	require.Error(t, err)
+}
+
+func Test_references(t *testing.T) {
+	candidate := filepath.Join("api", "openapi.yaml")
+	tests := []struct {
+		name    string
+		content string
+		file    string
+		want    bool
+	}{
+		{name: "relative reference", content: "$ref: './schemas/user.yaml#/User'", file: filepath.Join("api", "schemas", "user.yaml"), want: true},
+		{name: "reference without prefix", content: "\"$ref\": \"schemas/user.yaml\"", file: filepath.Join("api", "schemas", "user.yaml"), want: true},
+		{name: "parent reference", content: "$ref: ../common/user.yaml", file: filepath.Join("common", "user.yaml"), want: true},
+		{name: "longer file name", content: "$ref: './schemas/admin_user.yaml'", file: filepath.Join("api", "schemas", "user.yaml"), want: false},
+		{name: "different directory", content: "$ref: './other/schemas/user.yaml'", file: filepath.Join("api", "schemas", "user.yaml"), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, references(candidate, []byte(tt.content), tt.file))
+		})
+	}
+}
+
+func Benchmark_referencingFiles(b *testing.B) {
+	dir := b.TempDir()
+	changed := filepath.Join(dir, "schemas", "user.yaml")
+	require.NoError(b, os.MkdirAll(filepath.Dir(changed), os.ModePerm))
+	require.NoError(b, os.WriteFile(changed, []byte("type: object\n"), os.ModePerm))
+	for i := 0; i < 2000; i++ {
+		content := "openapi: 3.0.0\npaths: {}\n"
+		if i%500 == 0 {
+			content += "components:\n  schemas:\n    User:\n      $ref: '../schemas/user.yaml'\n"
+		}
+		file := filepath.Join(dir, fmt.Sprintf("api%d", i%20), fmt.Sprintf("openapi%d.yaml", i))
+		require.NoError(b, os.MkdirAll(filepath.Dir(file), os.ModePerm))
+		require.NoError(b, os.WriteFile(file, []byte(content), os.ModePerm))
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		require.Len(b, referencingFiles(dir, []string{changed}, 15), 4)
+	}
+}
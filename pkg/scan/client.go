@@ -2,11 +2,16 @@ package scan
 
 import (
 	"context"
+	"io"
 	"time"
 
 	"github.com/Checkmarx/kics/v2/internal/storage"
 	"github.com/Checkmarx/kics/v2/internal/tracker"
+	"github.com/Checkmarx/kics/v2/pkg/cache"
 	"github.com/Checkmarx/kics/v2/pkg/descriptions"
+	"github.com/Checkmarx/kics/v2/pkg/git"
+	"github.com/Checkmarx/kics/v2/pkg/kics"
+	"github.com/Checkmarx/kics/v2/pkg/model"
 	consolePrinter "github.com/Checkmarx/kics/v2/pkg/printer"
 	"github.com/Checkmarx/kics/v2/pkg/progress"
 	"github.com/rs/zerolog/log"
@@ -35,6 +40,8 @@ type Parameters struct {
 	Platform                    []string
 	ExcludePlatform             []string
 	TerraformVarsPath           string
+	TerraformExpandResources    bool
+	TerraformPlanChanges        bool
 	QueryExecTimeout            int
 	LineInfoPayload             bool
 	DisableSecrets              bool
@@ -50,6 +57,23 @@ type Parameters struct {
 	UseOldSeverities            bool
 	MaxResolverDepth            int
 	KicsComputeNewSimID         bool
+	StoragePath                 string
+	BaselinePath                string
+	WriteBaselinePath           string
+	ChangedSince                string
+	ChangedLinesDiff            string
+	ChangedLinesSince           string
+	MarkdownMaxLength           int
+	CIAnnotations               string
+	CachePath                   string
+	ExplainQuery                string
+	QueryProfilePath            string
+}
+
+// Storage is the kics.Storage used by the scan client, it also gives back the scanned files
+type Storage interface {
+	kics.Storage
+	GetFiles(ctx context.Context, scanID string) (model.FileMetadatas, error)
 }
 
 // Client represents a scan client
@@ -57,10 +81,15 @@ type Client struct {
 	ScanParams        *Parameters
 	ScanStartTime     time.Time
 	Tracker           *tracker.CITracker
-	Storage           *storage.MemoryStorage
+	Storage           Storage
 	ExcludeResultsMap map[string]bool
 	Printer           *consolePrinter.Printer
 	ProBarBuilder     *progress.PbBuilder
+	ChangedSince      *model.ChangedSince
+	changedPaths      []string
+	changedLines      git.ChangedLines
+	resultStream      *resultStream
+	cache             *cache.Cache
 }
 
 // NewClient initializes the client with all the required parameters
@@ -73,7 +102,11 @@ func NewClient(params *Parameters, proBarBuilder *progress.PbBuilder, customPrin
 
 	descriptions.CheckVersion(t)
 
-	store := storage.NewMemoryStorage()
+	store, err := newStorage(params)
+	if err != nil {
+		log.Err(err)
+		return nil, err
+	}
 
 	excludeResultsMap := getExcludeResultsMap(params.ExcludeResults)
 
@@ -90,6 +123,12 @@ func NewClient(params *Parameters, proBarBuilder *progress.PbBuilder, customPrin
 // PerformScan executes executeScan and postScan
 func (c *Client) PerformScan(ctx context.Context) error {
 	c.ScanStartTime = time.Now()
+	defer c.closeStorage()
+
+	if err := c.readChangedLines(ctx); err != nil {
+		log.Err(err)
+		return err
+	}
 
 	scanResults, err := c.executeScan(ctx)
 
@@ -107,3 +146,28 @@ func (c *Client) PerformScan(ctx context.Context) error {
 
 	return nil
 }
+
+// newStorage returns a SQLiteStorage when a storage path is given, otherwise a MemoryStorage
+// results of a previous scan with the same scan ID are replaced
+func newStorage(params *Parameters) (Storage, error) {
+	if params.StoragePath == "" {
+		return storage.NewMemoryStorage(), nil
+	}
+	store, err := storage.NewSQLiteStorage(params.StoragePath)
+	if err != nil {
+		return nil, err
+	}
+	if err := store.DeleteScan(context.Background(), params.ScanID); err != nil {
+		_ = store.Close()
+		return nil, err
+	}
+	return store, nil
+}
+
+func (c *Client) closeStorage() {
+	if closer, ok := c.Storage.(io.Closer); ok {
+		if err := closer.Close(); err != nil {
+			log.Err(err).Msg("failed to close storage")
+		}
+	}
+}
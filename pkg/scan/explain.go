@@ -0,0 +1,19 @@
+package scan
+
+import (
+	"context"
+
+	"github.com/Checkmarx/kics/v2/pkg/engine"
+	"github.com/Checkmarx/kics/v2/pkg/model"
+	consolePrinter "github.com/Checkmarx/kics/v2/pkg/printer"
+)
+
+// explainQuery evaluates the explained query again over the scanned files with tracing and prints its inputs
+// and traces
+func (c *Client) explainQuery(ctx context.Context, inspector *engine.Inspector, files model.FileMetadatas) error {
+	query, explanations, err := inspector.Explain(ctx, c.ScanParams.ExplainQuery, files)
+	if err != nil {
+		return err
+	}
+	return consolePrinter.PrintExplanations(query, explanations, c.Printer)
+}
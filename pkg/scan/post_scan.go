@@ -29,12 +29,14 @@ func (c *Client) getSummary(results []model.Vulnerability, end time.Time, pathPa
 		FailedToExecuteQueries: c.Tracker.ExecutingQueries - c.Tracker.ExecutedQueries,
 		FailedSimilarityID:     c.Tracker.FailedSimilarityID,
 	}
+	c.setCacheCounters(&counters)
 
 	summary := model.CreateSummary(counters, results, c.ScanParams.ScanID, pathParameters.PathExtractionMap, c.Tracker.Version)
 	summary.Times = model.Times{
 		Start: c.ScanStartTime,
 		End:   end,
 	}
+	summary.ChangedSince = c.ChangedSince
 
 	if c.ScanParams.DisableFullDesc {
 		log.Warn().Msg("Skipping descriptions because provided disable flag is set")
@@ -61,6 +63,11 @@ func (c *Client) resolveOutputs(
 	if err := consolePrinter.PrintResult(summary, printer, usingCustomQueries); err != nil {
 		return err
 	}
+	if c.ScanParams.CIAnnotations != "" {
+		if err := consolePrinter.PrintAnnotations(summary, c.ScanParams.CIAnnotations); err != nil {
+			return err
+		}
+	}
 	if c.ScanParams.PayloadPath != "" {
 		if err := report.ExportJSONReport(
 			filepath.Dir(c.ScanParams.PayloadPath),
@@ -71,10 +78,24 @@ func (c *Client) resolveOutputs(
 		}
 	}
 
+	summary.MarkdownMaxLength = c.ScanParams.MarkdownMaxLength
+
+	formats := c.ScanParams.ReportFormats
+	if c.resultStream != nil {
+		// the results were already streamed to the jsonl report, only its summary line is left
+		formats = withoutFormat(formats, jsonlFormat)
+		if err := c.resultStream.close(summary); err != nil {
+			return err
+		}
+		if len(formats) == 0 {
+			return nil
+		}
+	}
+
 	return printOutput(
 		c.ScanParams.OutputPath,
 		c.ScanParams.OutputName,
-		summary, c.ScanParams.ReportFormats,
+		summary, formats,
 		proBarBuilder,
 	)
 }
@@ -94,6 +115,42 @@ func printOutput(outputPath, filename string, body interface{}, formats []string
 	return err
 }
 
+// filterPlanChanges removes the results on terraform plan resources that the plan does not create, update or replace,
+// results from other files are kept
+func filterPlanChanges(results []model.Vulnerability) []model.Vulnerability {
+	filtered := make([]model.Vulnerability, 0, len(results))
+	for i := range results {
+		switch results[i].ChangeAction {
+		case "", model.ChangeActionCreate, model.ChangeActionUpdate, model.ChangeActionReplace:
+			filtered = append(filtered, results[i])
+		default:
+			log.Debug().Msgf("Excluding result on a resource with the '%s' plan action: %s",
+				results[i].ChangeAction, results[i].SimilarityID)
+		}
+	}
+	return filtered
+}
+
+// setSuppressed adds the results excluded by their similarity ID or ignored by comments to their own section of
+// the summary, they are not part of the reported results
+func (c *Client) setSuppressed(summary *model.Summary, suppressed []model.Vulnerability,
+	pathExtractionMap map[string]model.ExtractedPathObject) {
+	if len(suppressed) == 0 {
+		return
+	}
+	seen := make(map[string]bool, len(suppressed))
+	unique := make([]model.Vulnerability, 0, len(suppressed))
+	for i := range suppressed {
+		if key := resultKey(&suppressed[i]); !seen[key] {
+			seen[key] = true
+			unique = append(unique, suppressed[i])
+		}
+	}
+	suppressedSummary := model.CreateSummary(model.Counters{}, unique, c.ScanParams.ScanID, pathExtractionMap, c.Tracker.Version)
+	summary.Suppressed = suppressedSummary.Queries
+	summary.ResultsSuppressed = suppressedSummary.TotalCounter
+}
+
 // postScan is responsible for the output results
 func (c *Client) postScan(scanResults *Results) error {
 	if scanResults == nil {
@@ -106,7 +163,11 @@ func (c *Client) postScan(scanResults *Results) error {
 		}
 	}
 
-	// mask results preview if Secrets Scan is disabled
+	if c.ScanParams.TerraformPlanChanges {
+		scanResults.Results = filterPlanChanges(scanResults.Results)
+	}
+
+	// mask results preview if Secrets Scan is disabled, before the results outside the diff are split off
 	if c.ScanParams.DisableSecrets {
 		err := maskPreviewLines(c.ScanParams.SecretsRegexesPath, scanResults)
 		if err != nil {
@@ -114,12 +175,35 @@ func (c *Client) postScan(scanResults *Results) error {
 			return err
 		}
 	}
+
+	var outsideDiff []model.Vulnerability
+	if c.changedLines != nil {
+		scanResults.Results, outsideDiff = filterChangedLines(scanResults.Results, c.changedLines)
+	}
 	sort.Strings(c.ScanParams.Path)
 	summary := c.getSummary(scanResults.Results, time.Now(), model.PathParameters{
 		ScannedPaths:      c.ScanParams.Path,
 		PathExtractionMap: scanResults.ExtractedPaths.ExtractionMap,
 	})
 
+	if c.changedLines != nil {
+		c.setOutsideDiff(&summary, outsideDiff, scanResults.ExtractedPaths.ExtractionMap)
+	}
+
+	c.setSuppressed(&summary, scanResults.Suppressed, scanResults.ExtractedPaths.ExtractionMap)
+	summary.QueryProfile = scanResults.QueryProfile
+
+	if c.resultStream != nil && c.resultStream.storage == nil {
+		c.resultStream.setCounters(&summary)
+	}
+
+	if c.ScanParams.BaselinePath != "" {
+		if err := applyBaseline(&summary, c.ScanParams.BaselinePath); err != nil {
+			log.Err(err)
+			return err
+		}
+	}
+
 	if err := c.resolveOutputs(
 		&summary,
 		scanResults.Files.Combine(c.ScanParams.LineInfoPayload),
@@ -129,6 +213,18 @@ func (c *Client) postScan(scanResults *Results) error {
 		return err
 	}
 
+	if c.ScanParams.WriteBaselinePath != "" {
+		if err := writeBaseline(&summary, c.ScanParams.WriteBaselinePath); err != nil {
+			log.Err(err)
+			return err
+		}
+	}
+
+	if err := c.writeQueryProfile(summary.QueryProfile); err != nil {
+		log.Err(err)
+		return err
+	}
+
 	deleteExtractionFolder(scanResults.ExtractedPaths.ExtractionMap)
 
 	logger := consolePrinter.NewLogger(nil)
@@ -335,3 +335,48 @@ func Test_resolveOutputs(t *testing.T) {
 		})
 	}
 }
+
+func Test_filterPlanChanges(t *testing.T) {
+	results := []model.Vulnerability{
+		{SimilarityID: "1", ChangeAction: model.ChangeActionCreate},
+		{SimilarityID: "2", ChangeAction: model.ChangeActionUpdate},
+		{SimilarityID: "3", ChangeAction: model.ChangeActionReplace},
+		{SimilarityID: "4", ChangeAction: model.ChangeActionNoOp},
+		{SimilarityID: "5", ChangeAction: model.ChangeActionDelete},
+		{SimilarityID: "6"},
+	}
+
+	filtered := filterPlanChanges(results)
+
+	ids := make([]string, 0, len(filtered))
+	for i := range filtered {
+		ids = append(ids, filtered[i].SimilarityID)
+	}
+	require.Equal(t, []string{"1", "2", "3", "6"}, ids)
+}
+
+func Test_setSuppressed(t *testing.T) {
+	suppressed := []model.Vulnerability{
+		{SimilarityID: "1", QueryID: "query", QueryName: "Query", Severity: model.SeverityHigh, FileName: "main.tf", Line: 2,
+			Suppression: model.SuppressionInSource},
+		{SimilarityID: "1", QueryID: "query", QueryName: "Query", Severity: model.SeverityHigh, FileName: "main.tf", Line: 2,
+			Suppression: model.SuppressionInSource},
+		{SimilarityID: "2", QueryID: "other", QueryName: "Other", Severity: model.SeverityLow, FileName: "main.tf", Line: 5,
+			Suppression: model.SuppressionExternal},
+	}
+	c := &Client{
+		ScanParams: &Parameters{ScanID: "console"},
+		Tracker:    &tracker.CITracker{},
+	}
+
+	summary := model.CreateSummary(model.Counters{}, nil, "console", nil, model.Version{})
+	c.setSuppressed(&summary, suppressed, map[string]model.ExtractedPathObject{})
+
+	require.Equal(t, 2, summary.ResultsSuppressed)
+	require.Len(t, summary.Suppressed, 2)
+	require.Equal(t, 0, summary.TotalCounter)
+	for idx := range summary.Suppressed {
+		require.Len(t, summary.Suppressed[idx].Files, 1)
+		require.NotEmpty(t, summary.Suppressed[idx].Files[0].Suppression)
+	}
+}
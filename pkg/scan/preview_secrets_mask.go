@@ -37,6 +37,9 @@ func maskPreviewLines(secretsPath string, scanResults *Results) error {
 		item := scanResults.Results[i]
 		hideSecret(item.VulnLines, &allowRules, &rules)
 	}
+	for i := range scanResults.Suppressed {
+		hideSecret(scanResults.Suppressed[i].VulnLines, &allowRules, &rules)
+	}
 	return nil
 }
 
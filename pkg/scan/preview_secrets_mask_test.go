@@ -1,10 +1,12 @@
 package scan
 
 import (
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/Checkmarx/kics/v2/internal/tracker"
+	"github.com/Checkmarx/kics/v2/pkg/git"
 	"github.com/Checkmarx/kics/v2/pkg/model"
 	"github.com/Checkmarx/kics/v2/pkg/printer"
 	"github.com/Checkmarx/kics/v2/pkg/progress"
@@ -152,3 +154,41 @@ func Test_maskSecretsEntropies(t *testing.T) {
 
 	}
 }
+
+func Test_maskSecretsOutsideDiff(t *testing.T) {
+	c := Client{}
+	c.Tracker = &tracker.CITracker{}
+	c.ScanParams = &Parameters{DisableSecrets: true}
+	c.ProBarBuilder = progress.InitializePbBuilder(true, false, true)
+	c.Printer = printer.NewPrinter(true)
+	secretFile, err := filepath.Abs("secret.yaml")
+	require.NoError(t, err)
+	c.changedLines = git.ChangedLines{
+		filepath.ToSlash(secretFile): {{Start: 1, End: 2}},
+	}
+
+	vulnLines := &[]model.CodeLine{
+		{
+			Position: 6,
+			Line:     "  password: \"abcd\"",
+		},
+	}
+	scanResults := &Results{
+		Results: []model.Vulnerability{
+			{
+				SimilarityID: "1",
+				QueryID:      "query",
+				Severity:     model.SeverityHigh,
+				FileName:     "secret.yaml",
+				Line:         6,
+				VulnLines:    vulnLines,
+			},
+		},
+	}
+
+	err = c.postScan(scanResults)
+	require.NoError(t, err)
+
+	require.Empty(t, scanResults.Results)
+	require.Contains(t, (*vulnLines)[0].Line, "<SECRET-MASKED-ON-PURPOSE>")
+}
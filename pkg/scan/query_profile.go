@@ -0,0 +1,20 @@
+package scan
+
+import (
+	"path/filepath"
+
+	"github.com/Checkmarx/kics/v2/pkg/model"
+	"github.com/Checkmarx/kics/v2/pkg/report"
+)
+
+// writeQueryProfile writes the profile of the queries to the query profile path, when one is given
+func (c *Client) writeQueryProfile(profile *model.QueryProfile) error {
+	if c.ScanParams.QueryProfilePath == "" || profile == nil {
+		return nil
+	}
+	return report.ExportJSONReport(
+		filepath.Dir(c.ScanParams.QueryProfilePath),
+		filepath.Base(c.ScanParams.QueryProfilePath),
+		profile,
+	)
+}
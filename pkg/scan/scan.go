@@ -34,12 +34,15 @@ type Results struct {
 	ExtractedPaths provider.ExtractedPath
 	Files          model.FileMetadatas
 	FailedQueries  map[string]error
+	Suppressed     []model.Vulnerability
+	QueryProfile   *model.QueryProfile
 }
 
 type executeScanParameters struct {
-	services       []*kics.Service
-	inspector      *engine.Inspector
-	extractedPaths provider.ExtractedPath
+	services         []*kics.Service
+	inspector        *engine.Inspector
+	secretsInspector *secrets.Inspector
+	extractedPaths   provider.ExtractedPath
 }
 
 func (c *Client) initScan(ctx context.Context) (*executeScanParameters, error) {
@@ -56,6 +59,10 @@ func (c *Client) initScan(ctx context.Context) (*executeScanParameters, error) {
 		return nil, nil
 	}
 
+	if err := c.initResultStream(extractedPaths.ExtractionMap); err != nil {
+		return nil, err
+	}
+
 	paramsPlatforms := c.ScanParams.Platform
 	useDifferentPlatformQueries(&paramsPlatforms)
 
@@ -84,6 +91,15 @@ func (c *Client) initScan(ctx context.Context) (*executeScanParameters, error) {
 		return nil, err
 	}
 
+	if c.ScanParams.QueryProfilePath != "" {
+		inspector.EnableQueryProfile()
+	}
+
+	c.initCache()
+	if c.cache != nil {
+		inspector.SetCache(c.cache)
+	}
+
 	secretsRegexRulesContent, err := getSecretsRegexRules(c.ScanParams.SecretsRegexesPath)
 	if err != nil {
 		return nil, err
@@ -124,9 +140,10 @@ func (c *Client) initScan(ctx context.Context) (*executeScanParameters, error) {
 	}
 
 	return &executeScanParameters{
-		services:       services,
-		inspector:      inspector,
-		extractedPaths: extractedPaths,
+		services:         services,
+		inspector:        inspector,
+		secretsInspector: secretsInspector,
+		extractedPaths:   extractedPaths,
 	}, nil
 }
 
@@ -164,11 +181,25 @@ func (c *Client) executeScan(ctx context.Context) (*Results, error) {
 		return nil, err
 	}
 
+	if c.ScanParams.ExplainQuery != "" {
+		if err := c.explainQuery(ctx, executeScanParameters.inspector, files); err != nil {
+			log.Err(err)
+			return nil, err
+		}
+	}
+
+	var suppressed []model.Vulnerability
+	suppressed = append(suppressed, executeScanParameters.inspector.GetSuppressedResults()...)
+	suppressed = append(suppressed, executeScanParameters.secretsInspector.GetSuppressedResults()...)
+	kics.MaskSecrets(suppressed, executeScanParameters.secretsInspector)
+
 	return &Results{
 		Results:        results,
 		ExtractedPaths: executeScanParameters.extractedPaths,
 		Files:          files,
 		FailedQueries:  failedQueries,
+		Suppressed:     suppressed,
+		QueryProfile:   executeScanParameters.inspector.GetQueryProfile(),
 	}, nil
 }
 
@@ -224,6 +255,10 @@ func (c *Client) createQueryFilter() *source.QueryInspectorParameters {
 	includeQueries := source.IncludeQueries{
 		ByIDs: c.ScanParams.IncludeQueries,
 	}
+	if c.ScanParams.ExplainQuery != "" {
+		// only the explained query is evaluated
+		includeQueries.ByIDs = []string{c.ScanParams.ExplainQuery}
+	}
 
 	queryFilter := source.QueryInspectorParameters{
 		IncludeQueries:      includeQueries,
@@ -251,7 +286,7 @@ func (c *Client) createService(
 	combinedParser, err := parser.NewBuilder().
 		Add(&jsonParser.Parser{}).
 		Add(&yamlParser.Parser{}).
-		Add(terraformParser.NewDefaultWithVarsPath(c.ScanParams.TerraformVarsPath)).
+		Add(terraformParser.NewDefaultWithParams(c.ScanParams.TerraformVarsPath, c.ScanParams.TerraformExpandResources)).
 		Add(&bicepParser.Parser{}).
 		Add(&dockerParser.Parser{}).
 		Add(&protoParser.Parser{}).
@@ -285,8 +320,12 @@ func (c *Client) createService(
 				Tracker:          t,
 				Resolver:         combinedResolver,
 				MaxFileSize:      c.ScanParams.MaxFileSizeFlag,
+				Cache:            c.cache,
 			},
 		)
+		if c.resultStream != nil {
+			services[len(services)-1].Sink = c.resultStream
+		}
 	}
 	return services, nil
 }
@@ -305,5 +344,11 @@ func (c *Client) getFileSystemSourceProvider(paths []string) (*provider.FileSyst
 	if err != nil {
 		return nil, err
 	}
+
+	if c.ChangedSince != nil {
+		if err := filesSource.AddIncluded(c.changedPaths); err != nil {
+			return nil, err
+		}
+	}
 	return filesSource, nil
 }
@@ -0,0 +1,144 @@
+package scan
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/Checkmarx/kics/v2/pkg/kics"
+	"github.com/Checkmarx/kics/v2/pkg/model"
+	"github.com/Checkmarx/kics/v2/pkg/report"
+	"github.com/Checkmarx/kics/v2/pkg/utils"
+	"github.com/rs/zerolog/log"
+)
+
+const jsonlFormat = "jsonl"
+
+// resultStream is the sink of the scan services when the jsonl report format is set, it writes the results to the
+// report as soon as each query finishes. The results are also saved on the storage only when other outputs need them
+// all together, otherwise only their counters are kept
+type resultStream struct {
+	mu                sync.Mutex
+	writer            *report.JSONLWriter
+	storage           kics.Storage
+	client            *Client
+	pathExtractionMap map[string]model.ExtractedPathObject
+	seen              map[string]bool
+	severityCounters  map[model.Severity]int
+	totalCounter      int
+	outsideDiff       int
+}
+
+// initResultStream starts the jsonl report when it is one of the report formats, before the scan starts
+func (c *Client) initResultStream(pathExtractionMap map[string]model.ExtractedPathObject) error {
+	if c.ScanParams.OutputPath == "" || !utils.Contains(jsonlFormat, c.ScanParams.ReportFormats) {
+		return nil
+	}
+	writer, err := report.NewJSONLWriter(c.ScanParams.OutputPath, c.ScanParams.OutputName)
+	if err != nil {
+		return fmt.Errorf("failed to create the jsonl report: %w", err)
+	}
+	c.resultStream = &resultStream{
+		writer:            writer,
+		client:            c,
+		pathExtractionMap: pathExtractionMap,
+		seen:              make(map[string]bool),
+		severityCounters:  make(map[model.Severity]int),
+	}
+	if c.keepStreamedResults() {
+		c.resultStream.storage = c.Storage
+	} else {
+		log.Info().Msg("Streaming the results to the jsonl report, only their counters are kept for the other outputs")
+	}
+	return nil
+}
+
+// keepStreamedResults checks if any output other than the jsonl report needs all the results of the scan
+func (c *Client) keepStreamedResults() bool {
+	for _, format := range c.ScanParams.ReportFormats {
+		if format != jsonlFormat {
+			return true
+		}
+	}
+	return c.ScanParams.StoragePath != "" ||
+		c.ScanParams.BaselinePath != "" ||
+		c.ScanParams.WriteBaselinePath != "" ||
+		c.ScanParams.CIAnnotations != ""
+}
+
+// Write writes the results of a query to the jsonl report, with the same filters applied to the full reports
+func (r *resultStream) Write(ctx context.Context, vulnerabilities []model.Vulnerability) error {
+	if r.storage != nil {
+		if err := r.storage.SaveVulnerabilities(ctx, vulnerabilities); err != nil {
+			return err
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	unique := make([]model.Vulnerability, 0, len(vulnerabilities))
+	for i := range vulnerabilities {
+		key := resultKey(&vulnerabilities[i])
+		if !r.seen[key] {
+			r.seen[key] = true
+			unique = append(unique, vulnerabilities[i])
+		}
+	}
+
+	if r.client.ScanParams.TerraformPlanChanges {
+		unique = filterPlanChanges(unique)
+	}
+	if r.client.changedLines != nil {
+		var outside []model.Vulnerability
+		unique, outside = filterChangedLines(unique, r.client.changedLines)
+		r.outsideDiff += len(outside)
+	}
+
+	summary := model.CreateSummary(model.Counters{}, unique, r.client.ScanParams.ScanID, r.pathExtractionMap,
+		r.client.Tracker.Version)
+	for severity, counter := range summary.SeverityCounters {
+		r.severityCounters[severity] += counter
+	}
+	r.totalCounter += summary.TotalCounter
+
+	return r.writer.WriteQueries(summary.Queries)
+}
+
+// setCounters replaces the counters of a summary built without results with the counters of the streamed results
+func (r *resultStream) setCounters(summary *model.Summary) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for severity, counter := range r.severityCounters {
+		summary.SeverityCounters[severity] = counter
+	}
+	summary.TotalCounter = r.totalCounter
+	summary.ResultsOutsideDiff = r.outsideDiff
+}
+
+// close writes the summary line of the jsonl report
+func (r *resultStream) close(summary *model.Summary) error {
+	return r.writer.Close(summary)
+}
+
+// resultKey identifies a result, results with the same key are duplicates as in the storage
+func resultKey(vulnerability *model.Vulnerability) string {
+	return fmt.Sprintf("%s:%s:%d:%s:%s:%s",
+		vulnerability.QueryID,
+		vulnerability.FileName,
+		vulnerability.Line,
+		vulnerability.SimilarityID,
+		vulnerability.SearchKey,
+		vulnerability.KeyActualValue,
+	)
+}
+
+func withoutFormat(formats []string, format string) []string {
+	filtered := make([]string, 0, len(formats))
+	for _, f := range formats {
+		if f != format {
+			filtered = append(filtered, f)
+		}
+	}
+	return filtered
+}
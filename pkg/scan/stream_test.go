@@ -0,0 +1,114 @@
+package scan
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Checkmarx/kics/v2/internal/storage"
+	"github.com/Checkmarx/kics/v2/internal/tracker"
+	"github.com/Checkmarx/kics/v2/pkg/git"
+	"github.com/Checkmarx/kics/v2/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_resultStream(t *testing.T) {
+	mainFile, err := filepath.Abs("main.tf")
+	require.NoError(t, err)
+	mainFile = filepath.ToSlash(mainFile)
+	results := []model.Vulnerability{
+		{SimilarityID: "1", QueryID: "query", QueryName: "Query", Severity: model.SeverityHigh, FileName: "main.tf", Line: 2},
+		{SimilarityID: "2", QueryID: "query", QueryName: "Query", Severity: model.SeverityHigh, FileName: "main.tf", Line: 5},
+		{SimilarityID: "3", QueryID: "other", QueryName: "Other", Severity: model.SeverityLow, FileName: "other.tf", Line: 2},
+	}
+
+	tests := []struct {
+		name         string
+		params       *Parameters
+		changedLines git.ChangedLines
+		wantKept     bool
+		wantTotal    int
+		wantOutside  int
+	}{
+		{
+			name:      "only jsonl does not keep the results",
+			params:    &Parameters{ReportFormats: []string{"jsonl"}},
+			wantKept:  false,
+			wantTotal: 3,
+		},
+		{
+			name:      "other report formats keep the results",
+			params:    &Parameters{ReportFormats: []string{"jsonl", "sarif"}},
+			wantKept:  true,
+			wantTotal: 3,
+		},
+		{
+			name:      "baseline keeps the results",
+			params:    &Parameters{ReportFormats: []string{"jsonl"}, BaselinePath: "baseline.json"},
+			wantKept:  true,
+			wantTotal: 3,
+		},
+		{
+			name:         "changed lines",
+			params:       &Parameters{ReportFormats: []string{"jsonl"}},
+			changedLines: git.ChangedLines{mainFile: {{Start: 1, End: 3}}},
+			wantKept:     false,
+			wantTotal:    1,
+			wantOutside:  2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.params.OutputPath = t.TempDir()
+			tt.params.OutputName = "results"
+			tt.params.ScanID = "console"
+			store := storage.NewMemoryStorage()
+			c := &Client{
+				ScanParams:   tt.params,
+				Tracker:      &tracker.CITracker{},
+				Storage:      store,
+				changedLines: tt.changedLines,
+			}
+			require.NoError(t, c.initResultStream(map[string]model.ExtractedPathObject{}))
+			require.NotNil(t, c.resultStream)
+			require.Equal(t, tt.wantKept, c.resultStream.storage != nil)
+
+			require.NoError(t, c.resultStream.Write(context.Background(), results[:2]))
+			// duplicated results are only written once
+			require.NoError(t, c.resultStream.Write(context.Background(), results))
+
+			summary := model.CreateSummary(model.Counters{}, nil, "console", nil, model.Version{})
+			if !tt.wantKept {
+				c.resultStream.setCounters(&summary)
+				require.Equal(t, tt.wantTotal, summary.TotalCounter)
+				require.Equal(t, tt.wantOutside, summary.ResultsOutsideDiff)
+			}
+			require.NoError(t, c.resultStream.close(&summary))
+
+			stored, err := store.GetVulnerabilities(context.Background(), "console")
+			require.NoError(t, err)
+			if tt.wantKept {
+				require.Len(t, stored, 3)
+			} else {
+				require.Empty(t, stored)
+			}
+
+			content, err := os.ReadFile(filepath.Join(tt.params.OutputPath, "results.jsonl"))
+			require.NoError(t, err)
+			lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+			require.Len(t, lines, tt.wantTotal+1)
+			require.Contains(t, lines[len(lines)-1], `"type":"summary"`)
+		})
+	}
+}
+
+func Test_initResultStream_WithoutJSONL(t *testing.T) {
+	c := &Client{
+		ScanParams: &Parameters{ReportFormats: []string{"json"}, OutputPath: t.TempDir()},
+	}
+	require.NoError(t, c.initResultStream(nil))
+	require.Nil(t, c.resultStream)
+}
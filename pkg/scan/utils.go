@@ -46,8 +46,24 @@ func (c *Client) prepareAndAnalyzePaths(ctx context.Context) (provider.Extracted
 	}
 	log.Info().Msgf("Total files in the project: %d", getTotalFiles(allPaths.Path))
 
+	analyzedPaths := allPaths.Path
+	if c.ScanParams.ChangedSince != "" {
+		if len(kuberneterPaths) > 0 {
+			return provider.ExtractedPath{}, errors.New("kuberneter paths can not be scanned for git changes")
+		}
+		c.changedPaths, c.ChangedSince, err = changedScanPaths(
+			ctx, regularExPaths.Path, c.ScanParams.ChangedSince, c.ScanParams.MaxResolverDepth)
+		if err != nil {
+			return provider.ExtractedPath{}, err
+		}
+		if len(c.changedPaths) == 0 {
+			return provider.ExtractedPath{}, nil
+		}
+		analyzedPaths = c.changedPaths
+	}
+
 	a := &analyzer.Analyzer{
-		Paths:             allPaths.Path,
+		Paths:             analyzedPaths,
 		Types:             c.ScanParams.Platform,
 		ExcludeTypes:      c.ScanParams.ExcludePlatform,
 		Exc:               c.ScanParams.ExcludePaths,
@@ -0,0 +1,15 @@
+package Cx
+
+CxPolicy[result] {
+	resource := input.document[i].command[name][_]
+	resource.Cmd == "from"
+	endswith(resource.Value[0], ":latest")
+
+	result := {
+		"documentId": input.document[i].id,
+		"searchKey": sprintf("FROM={{%s}}", [name]),
+		"issueType": "IncorrectValue",
+		"keyExpectedValue": sprintf("FROM %s should use a pinned tag", [resource.Value[0]]),
+		"keyActualValue": sprintf("FROM %s uses the 'latest' tag", [resource.Value[0]]),
+	}
+}
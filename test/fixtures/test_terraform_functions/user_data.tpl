@@ -0,0 +1,3 @@
+#!/bin/bash
+echo "${name}" > /tmp/%{ for port in ports }${port}%{ endfor }
+echo ${upper(name)}
@@ -0,0 +1,6 @@
+locals {
+  bucket_name = "${local.prefix}-${var.environment}"
+  encryption  = local.encryption_settings.algorithm
+  cycle_a     = local.cycle_b
+  cycle_b     = local.cycle_a
+}
@@ -0,0 +1,22 @@
+variable "environment" {
+  default = "dev"
+}
+
+locals {
+  prefix = upper("kics")
+  encryption_settings = {
+    algorithm = "aws:kms"
+  }
+}
+
+resource "aws_s3_bucket" "b" {
+  bucket = local.bucket_name
+
+  server_side_encryption_configuration {
+    rule {
+      apply_server_side_encryption_by_default {
+        sse_algorithm = local.encryption
+      }
+    }
+  }
+}
@@ -0,0 +1,15 @@
+variable "bucket_acl" {
+  default = "public-read"
+}
+
+module "bucket" {
+  source = "./modules/bucket"
+  name   = "kics-module-bucket"
+  acl    = var.bucket_acl
+}
+
+module "logs" {
+  source  = "example/logs/aws"
+  version = "1.0.0"
+  name    = "kics-logs"
+}
@@ -0,0 +1,12 @@
+variable "name" {
+  type = string
+}
+
+variable "acl" {
+  default = "private"
+}
+
+resource "aws_s3_bucket" "this" {
+  bucket = var.name
+  acl    = var.acl
+}